@@ -38,6 +38,12 @@ type Axis struct {
 	// values represented by the axis.
 	Min, Max float64
 
+	// Window, if positive, makes the axis scroll with
+	// the data of the plot.  The plot is drawn with Max
+	// set to the largest value of the data of the plotters
+	// implementing DataRanger and Min set to Max-Window.
+	Window float64
+
 	Label struct {
 		// Text is the axis label string.
 		Text string
@@ -153,7 +159,8 @@ func (a *Axis) sanitizeRange() {
 // createHorizontalMarker generates a set of marks suited for use on a horizontal axis
 func (a *Axis) CreateHorizontalMarks(c draw.Canvas) []Tick {
 	width := c.X(a.Norm(a.Max)) - c.X(a.Norm(a.Min))
-	stringSizer := func(str string) vg.Length { return a.Tick.Label.Font.Width(str) }
+	fnt := a.Tick.Label.SelectedFont()
+	stringSizer := func(str string) vg.Length { return fnt.Width(str) }
 	return a.Tick.Marker.Ticks(a.Min, a.Max, stringSizer, width)
 }
 
@@ -224,7 +231,8 @@ type horizontalAxis struct {
 // size returns the height of the axis.
 func (a horizontalAxis) size(c draw.Canvas) (h vg.Length) {
 	if a.Label.Text != "" { // We assume that the label isn't rotated.
-		h -= a.Label.Font.Extents().Descent
+		fnt := a.Label.SelectedFont()
+		h -= fnt.Extents().Descent
 		h += a.Label.Height(a.Label.Text)
 	}
 
@@ -241,16 +249,17 @@ func (a horizontalAxis) size(c draw.Canvas) (h vg.Length) {
 	return h
 }
 
-// draw draws the axis along the lower edge of a draw.Canvas.
-func (a horizontalAxis) draw(c draw.Canvas) {
+// draw draws the axis with the given tick marks
+// along the lower edge of a draw.Canvas.
+func (a horizontalAxis) draw(c draw.Canvas, marks []Tick) {
 	y := c.Min.Y
 	if a.Label.Text != "" {
-		y -= a.Label.Font.Extents().Descent
+		fnt := a.Label.SelectedFont()
+		y -= fnt.Extents().Descent
 		c.FillText(a.Label.TextStyle, vg.Point{X: c.Center().X, Y: y}, a.Label.Text)
 		y += a.Label.Height(a.Label.Text)
 	}
 
-	marks := a.CreateHorizontalMarks(c)
 	ticklabelheight := tickLabelHeight(a.Tick.Label, marks)
 	for _, t := range marks {
 		x := c.X(a.Norm(t.Value))
@@ -306,7 +315,8 @@ type verticalAxis struct {
 // size returns the width of the axis.
 func (a verticalAxis) size(c draw.Canvas) (w vg.Length) {
 	if a.Label.Text != "" { // We assume that the label isn't rotated.
-		w -= a.Label.Font.Extents().Descent
+		fnt := a.Label.SelectedFont()
+		w -= fnt.Extents().Descent
 		w += a.Label.Height(a.Label.Text)
 	}
 
@@ -326,17 +336,18 @@ func (a verticalAxis) size(c draw.Canvas) (w vg.Length) {
 	return w
 }
 
-// draw draws the axis along the left side of a draw.Canvas.
-func (a verticalAxis) draw(c draw.Canvas) {
+// draw draws the axis with the given tick marks
+// along the left side of a draw.Canvas.
+func (a verticalAxis) draw(c draw.Canvas, marks []Tick) {
 	x := c.Min.X
 	if a.Label.Text != "" {
 		sty := a.Label.TextStyle
 		sty.Rotation += math.Pi / 2
 		x += a.Label.Height(a.Label.Text)
 		c.FillText(sty, vg.Point{X: x, Y: c.Center().Y}, a.Label.Text)
-		x += -a.Label.Font.Extents().Descent
+		fnt := a.Label.SelectedFont()
+		x += -fnt.Extents().Descent
 	}
-	marks := a.CreateVerticalMarks(c)
 	if w := tickLabelWidth(a.Tick.Label, marks); len(marks) > 0 && w > 0 {
 		x += w
 	}
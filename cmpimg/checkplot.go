@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"flag"
+	"html/template"
 	"image"
 	"image/png"
 	"io/ioutil"
@@ -29,7 +30,19 @@ func goldenPath(path string) string {
 // If generateTestData = true, it regenerates the reference.
 // For image.Image formats, a base64 encoded png representation is output to
 // the testing log when a difference is identified.
+//
+// CheckPlot compares images exactly.  It is equivalent to
+// Comparison{}.CheckPlot(ExampleFunc, t, filenames...).
 func CheckPlot(ExampleFunc func(), t *testing.T, filenames ...string) {
+	Comparison{}.CheckPlot(ExampleFunc, t, filenames...)
+}
+
+// CheckPlot checks a generated nplot against a previously created reference
+// as the CheckPlot function does, comparing the images within the tolerances
+// of c.  If c.ReportDir is not empty, an HTML report showing the generated
+// and the reference image, and their difference for image.Image formats,
+// is written to the directory for each mismatch.
+func (c Comparison) CheckPlot(ExampleFunc func(), t *testing.T, filenames ...string) {
 	paths := make([]string, len(filenames))
 	for i, fn := range filenames {
 		paths[i] = filepath.Join("testdata", fn)
@@ -66,7 +79,7 @@ func CheckPlot(ExampleFunc func(), t *testing.T, filenames ...string) {
 			continue
 		}
 		typ := filepath.Ext(path)[1:] // remove the dot in e.g. ".pdf"
-		ok, err := Equal(typ, got, want)
+		ok, err := c.Equal(typ, got, want)
 		if err != nil {
 			t.Errorf("failed to compare image for %s: %v", path, err)
 			continue
@@ -74,6 +87,7 @@ func CheckPlot(ExampleFunc func(), t *testing.T, filenames ...string) {
 		if !ok {
 			t.Errorf("image mismatch for %s\n", path)
 
+			var diff []byte
 			switch typ {
 			case "jpeg", "jpg", "png", "tiff", "tif":
 				v1, _, err := image.Decode(bytes.NewReader(got))
@@ -98,7 +112,93 @@ func CheckPlot(ExampleFunc func(), t *testing.T, filenames ...string) {
 					continue
 				}
 				t.Log("IMAGE:" + base64.StdEncoding.EncodeToString(buf.Bytes()))
+				diff = buf.Bytes()
+			}
+
+			if c.ReportDir != "" {
+				report, err := writeReport(c.ReportDir, path, typ, got, want, diff)
+				if err != nil {
+					t.Errorf("failed to write report for %s: %v", path, err)
+					continue
+				}
+				t.Logf("report for %s written to %s", path, report)
 			}
 		}
 	}
 }
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Path}}</title>
+<style>figure{display:inline-block;vertical-align:top;margin:1em}img{border:1px solid #ccc}</style>
+</head>
+<body>
+<h1>Image mismatch for {{.Path}}</h1>
+{{range .Images}}<figure><figcaption>{{.Caption}}</figcaption>
+{{if .Data}}<img src="{{.Data}}">{{else}}<pre>{{.Text}}</pre>{{end}}
+</figure>
+{{end}}</body>
+</html>
+`))
+
+// reportImage is an image shown in a mismatch report, either
+// as a data URI or as text for formats browsers do not display.
+type reportImage struct {
+	Caption string
+	Data    template.URL
+	Text    string
+}
+
+// writeReport writes an HTML report of the mismatch of the
+// generated image got at path and the reference image want
+// to dir, and returns the path of the report.
+func writeReport(dir, path, typ string, got, want, diff []byte) (string, error) {
+	mime := map[string]string{
+		"jpeg": "image/jpeg",
+		"jpg":  "image/jpeg",
+		"png":  "image/png",
+		"svg":  "image/svg+xml",
+	}[typ]
+	img := func(caption string, raw []byte, mime string) reportImage {
+		switch {
+		case typ == "pdf" || typ == "tiff" || typ == "tif":
+			// Browsers do not display these formats inline,
+			// and they are not readable as text.
+			return reportImage{Caption: caption, Text: typ + " image not shown"}
+		case mime == "":
+			return reportImage{Caption: caption, Text: string(raw)}
+		}
+		return reportImage{
+			Caption: caption,
+			Data:    template.URL("data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(raw)),
+		}
+	}
+	images := []reportImage{img("got", got, mime), img("want", want, mime)}
+	if diff != nil {
+		images = append(images, reportImage{
+			Caption: "diff",
+			Data:    template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(diff)),
+		})
+	}
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+	report := filepath.Join(dir, strings.Replace(filepath.ToSlash(path), "/", "_", -1)+".html")
+	f, err := os.Create(report)
+	if err != nil {
+		return "", err
+	}
+	err = reportTemplate.Execute(f, struct {
+		Path   string
+		Images []reportImage
+	}{Path: path, Images: images})
+	if err != nil {
+		f.Close()
+		return "", err
+	}
+	return report, f.Close()
+}
@@ -7,14 +7,12 @@
 package cmpimg // import "github.com/hneemann/nplot/cmpimg"
 
 import (
-	"bytes"
 	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
 	"math"
 	"reflect"
-	"strings"
 
 	"rsc.io/pdf"
 
@@ -28,59 +26,15 @@ import (
 // together with the underlying image type ("eps", "jpeg", "jpg", "pdf", "png", "svg", "tiff"),
 // and returns whether the two images are equal or not.
 //
+// Equal compares the images exactly.  It is equivalent to
+// Comparison{}.Equal(typ, raw1, raw2).
+//
 // Equal may return an error if the decoding of the raw image somehow failed.
 func Equal(typ string, raw1, raw2 []byte) (bool, error) {
-	switch typ {
-	case "svg", "tex":
-		return bytes.Equal(raw1, raw2), nil
-
-	case "eps":
-		lines1, lines2 := strings.Split(string(raw1), "\n"), strings.Split(string(raw2), "\n")
-		if len(lines1) != len(lines2) {
-			return false, nil
-		}
-		for i, line1 := range lines1 {
-			if strings.Contains(line1, "CreationDate") {
-				continue
-			}
-			if line1 != lines2[i] {
-				return false, nil
-			}
-		}
-		return true, nil
-
-	case "pdf":
-		r1 := bytes.NewReader(raw1)
-		pdf1, err := pdf.NewReader(r1, r1.Size())
-		if err != nil {
-			return false, err
-		}
-
-		r2 := bytes.NewReader(raw2)
-		pdf2, err := pdf.NewReader(r2, r2.Size())
-		if err != nil {
-			return false, err
-		}
-
-		return cmpPdf(pdf1, pdf2), nil
-
-	case "jpeg", "jpg", "png", "tiff":
-		v1, _, err := image.Decode(bytes.NewReader(raw1))
-		if err != nil {
-			return false, err
-		}
-		v2, _, err := image.Decode(bytes.NewReader(raw2))
-		if err != nil {
-			return false, err
-		}
-		return reflect.DeepEqual(v1, v2), nil
-
-	default:
-		return false, fmt.Errorf("cmpimg: unknown image type %q", typ)
-	}
+	return Comparison{}.Equal(typ, raw1, raw2)
 }
 
-func cmpPdf(pdf1, pdf2 *pdf.Reader) bool {
+func cmpPdf(pdf1, pdf2 *pdf.Reader, tol float64) bool {
 	n1 := pdf1.NumPage()
 	n2 := pdf2.NumPage()
 	if n1 != n2 {
@@ -90,7 +44,11 @@ func cmpPdf(pdf1, pdf2 *pdf.Reader) bool {
 	for i := 1; i <= n1; i++ {
 		p1 := pdf1.Page(i).Content()
 		p2 := pdf2.Page(i).Content()
-		if !reflect.DeepEqual(p1, p2) {
+		if tol > 0 {
+			if !equalNumbers(fmt.Sprint(p1), fmt.Sprint(p2), tol) {
+				return false
+			}
+		} else if !reflect.DeepEqual(p1, p2) {
 			return false
 		}
 	}
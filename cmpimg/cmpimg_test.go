@@ -10,7 +10,9 @@ import (
 	"image"
 	"image/png"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -51,3 +53,113 @@ func TestDiff(t *testing.T) {
 		t.Errorf("unexpected encoded diff value:\ngot:%s\nwant:%s", gotDiff, wantDiffEncoded)
 	}
 }
+
+func TestComparison(t *testing.T) {
+	svg1 := []byte(`<path d="M10.5 20L30 40.25"/>`)
+	svg2 := []byte(`<path d="M10.5001 20L30 40.2499"/>`)
+	eps1 := []byte("%%CreationDate: today\n10 20 moveto\n")
+	eps2 := []byte("%%CreationDate: yesterday\n10.001 20 moveto\n")
+	eps3 := []byte("10 20 moveto\n")
+	for _, test := range []struct {
+		typ        string
+		raw1, raw2 []byte
+		tol        float64
+		want       bool
+	}{
+		{typ: "svg", raw1: svg1, raw2: svg2, tol: 0, want: false},
+		{typ: "svg", raw1: svg1, raw2: svg2, tol: 1e-3, want: true},
+		{typ: "svg", raw1: svg1, raw2: []byte(`<path d="M10.5 20L30 40.25Z"/>`), tol: 1e-3, want: false},
+		{typ: "svg", raw1: svg1, raw2: []byte(`<path d="M10.5 20L30 40.25 1"/>`), tol: 1e-3, want: false},
+		{typ: "svg", raw1: []byte(`<path fill="#1f77b4"/>`), raw2: []byte(`<path fill="#1f77b5"/>`), tol: 1, want: false},
+		{typ: "svg", raw1: []byte(`<g clip-path="url(#clip12)"/>`), raw2: []byte(`<g clip-path="url(#clip13)"/>`), tol: 1, want: false},
+		{typ: "svg", raw1: []byte(`<g id="clip12" x="1.5px"/>`), raw2: []byte(`<g id="clip12" x="1.5001px"/>`), tol: 1e-3, want: true},
+		{typ: "eps", raw1: eps1, raw2: eps2, tol: 0, want: false},
+		{typ: "eps", raw1: eps1, raw2: eps2, tol: 1e-2, want: true},
+		{typ: "eps", raw1: eps1, raw2: eps3, tol: 0, want: true},
+	} {
+		got, err := Comparison{NumTolerance: test.tol}.Equal(test.typ, test.raw1, test.raw2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != test.want {
+			t.Errorf("unexpected result comparing %q and %q with tolerance %v: got:%t want:%t",
+				test.raw1, test.raw2, test.tol, got, test.want)
+		}
+	}
+
+	a := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for i := range a.Pix {
+		a.Pix[i] = uint8(i * 7)
+	}
+	b := image.NewRGBA(a.Bounds())
+	copy(b.Pix, a.Pix)
+	b.Pix[0] += 2
+	c := image.NewRGBA(a.Bounds())
+	copy(c.Pix, a.Pix)
+	for i := 0; i < 4*16; i += 4 {
+		c.Pix[i] = 255 - c.Pix[i]
+	}
+	for _, test := range []struct {
+		cmp  Comparison
+		b    image.Image
+		want bool
+	}{
+		{cmp: Comparison{}, b: a, want: true},
+		{cmp: Comparison{}, b: b, want: false},
+		{cmp: Comparison{Tolerance: 0.01}, b: b, want: true},
+		{cmp: Comparison{MaxDiffFraction: 0.01}, b: b, want: true},
+		{cmp: Comparison{Tolerance: 0.01, MaxDiffFraction: 0.05}, b: c, want: false},
+		{cmp: Comparison{Tolerance: 0.01, MaxDiffFraction: 0.1}, b: c, want: true},
+		{cmp: Comparison{Metric: DeltaEMetric, Tolerance: 2.3}, b: b, want: true},
+		{cmp: Comparison{Metric: DeltaEMetric, Tolerance: 2.3}, b: c, want: false},
+		{cmp: Comparison{Metric: SSIMMetric, Tolerance: 1e-3}, b: b, want: true},
+		{cmp: Comparison{Metric: SSIMMetric, Tolerance: 1e-3}, b: c, want: false},
+		{cmp: Comparison{Metric: SSIMMetric, Tolerance: 1}, b: image.NewRGBA(image.Rect(0, 0, 1, 1)), want: false},
+	} {
+		if got := test.cmp.EqualImages(a, test.b); got != test.want {
+			t.Errorf("unexpected result for %+v: got:%t want:%t", test.cmp, got, test.want)
+		}
+	}
+	if s := SSIM(a, a); s != 1 {
+		t.Errorf("unexpected SSIM of equal images: got:%v want:1", s)
+	}
+}
+
+func TestReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cmpimg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	got, err := ioutil.ReadFile(filepath.FromSlash("./testdata/failed_input.png"))
+	if err != nil {
+		t.Fatalf("failed to read failed file: %v", err)
+	}
+	report, err := writeReport(dir, filepath.Join("testdata", "failed.png"), "png", got, got, got)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join(dir, "testdata_failed.png.html"); report != want {
+		t.Errorf("unexpected report path: got:%s want:%s", report, want)
+	}
+	html, err := ioutil.ReadFile(report)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if n := strings.Count(string(html), `<img src="data:image/png;base64,`); n != 3 {
+		t.Errorf("unexpected number of images in report: got:%d want:3", n)
+	}
+
+	report, err = writeReport(dir, "plot.svg", "svg", []byte("<svg></svg>"), []byte("<svg/>"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	html, err = ioutil.ReadFile(report)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if n := strings.Count(string(html), `<img src="data:image/svg`); n != 2 {
+		t.Errorf("unexpected number of images in report: got:%d want:2", n)
+	}
+}
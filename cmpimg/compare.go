@@ -0,0 +1,350 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmpimg
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"rsc.io/pdf"
+)
+
+// Metric is the measure used to compare raster images.
+type Metric int
+
+const (
+	// RGBMetric compares pixels by the largest difference
+	// of their color and alpha channels, in the range [0, 1].
+	RGBMetric Metric = iota
+
+	// DeltaEMetric compares pixels by their CIE76 color
+	// difference ΔE in the CIELAB color space.  A ΔE of
+	// about 2.3 is just noticeable.
+	DeltaEMetric
+
+	// SSIMMetric compares images by their mean structural
+	// similarity index, see SSIM.
+	SSIMMetric
+)
+
+// Comparison holds the tolerances used to compare images.
+// The zero value compares images exactly.
+type Comparison struct {
+	// Metric is the measure used to compare raster images.
+	Metric Metric
+
+	// Tolerance is the largest difference of two raster
+	// images considered equal.  For RGBMetric and DeltaEMetric
+	// it is the largest difference of two equal pixels, for
+	// SSIMMetric it is the largest difference of the mean
+	// structural similarity index from 1.
+	Tolerance float64
+
+	// MaxDiffFraction is the largest fraction of pixels that
+	// may differ by more than Tolerance in equal raster images
+	// for RGBMetric and DeltaEMetric.
+	MaxDiffFraction float64
+
+	// NumTolerance is the largest absolute difference of numbers,
+	// such as path coordinates, in equal SVG, EPS, TeX and PDF
+	// images.  If NumTolerance is zero, these images must be
+	// equal byte for byte, apart from EPS creation dates.
+	NumTolerance float64
+
+	// ReportDir is the directory where CheckPlot writes an
+	// HTML report of image mismatches.  If ReportDir is empty,
+	// no report is written.
+	ReportDir string
+}
+
+// Equal takes the raw representation of two images, raw1 and raw2,
+// together with the underlying image type ("eps", "jpeg", "jpg", "pdf", "png", "svg", "tiff"),
+// and returns whether the two images are equal within the tolerances
+// of the comparison.
+//
+// Equal may return an error if the decoding of the raw image somehow failed.
+func (c Comparison) Equal(typ string, raw1, raw2 []byte) (bool, error) {
+	switch typ {
+	case "svg", "tex":
+		if c.NumTolerance > 0 {
+			return equalNumbers(string(raw1), string(raw2), c.NumTolerance), nil
+		}
+		return bytes.Equal(raw1, raw2), nil
+
+	case "eps":
+		lines1, lines2 := epsLines(raw1), epsLines(raw2)
+		if c.NumTolerance > 0 {
+			return equalNumbers(strings.Join(lines1, "\n"), strings.Join(lines2, "\n"), c.NumTolerance), nil
+		}
+		return reflect.DeepEqual(lines1, lines2), nil
+
+	case "pdf":
+		r1 := bytes.NewReader(raw1)
+		pdf1, err := pdf.NewReader(r1, r1.Size())
+		if err != nil {
+			return false, err
+		}
+
+		r2 := bytes.NewReader(raw2)
+		pdf2, err := pdf.NewReader(r2, r2.Size())
+		if err != nil {
+			return false, err
+		}
+
+		return cmpPdf(pdf1, pdf2, c.NumTolerance), nil
+
+	case "jpeg", "jpg", "png", "tiff", "tif":
+		v1, _, err := image.Decode(bytes.NewReader(raw1))
+		if err != nil {
+			return false, err
+		}
+		v2, _, err := image.Decode(bytes.NewReader(raw2))
+		if err != nil {
+			return false, err
+		}
+		return c.EqualImages(v1, v2), nil
+
+	default:
+		return false, fmt.Errorf("cmpimg: unknown image type %q", typ)
+	}
+}
+
+// EqualImages returns whether the images a and b are equal within
+// the raster image tolerances of the comparison.  Images of
+// different size are not equal.
+func (c Comparison) EqualImages(a, b image.Image) bool {
+	if c.Metric == RGBMetric && c.Tolerance == 0 && c.MaxDiffFraction == 0 {
+		return reflect.DeepEqual(a, b)
+	}
+	ra, rb := a.Bounds(), b.Bounds()
+	if ra.Size() != rb.Size() {
+		return false
+	}
+	if c.Metric == SSIMMetric {
+		return 1-SSIM(a, b) <= c.Tolerance
+	}
+
+	var n int
+	for y := 0; y < ra.Dy(); y++ {
+		for x := 0; x < ra.Dx(); x++ {
+			ca, cb := a.At(ra.Min.X+x, ra.Min.Y+y), b.At(rb.Min.X+x, rb.Min.Y+y)
+			var d float64
+			if c.Metric == DeltaEMetric {
+				d = deltaE(ca, cb)
+			} else {
+				d = rgbDiff(ca, cb)
+			}
+			if d > c.Tolerance {
+				n++
+			}
+		}
+	}
+	return float64(n) <= c.MaxDiffFraction*float64(ra.Dx()*ra.Dy())
+}
+
+// epsLines returns the lines of an EPS file
+// without the creation date.
+func epsLines(raw []byte) []string {
+	var lines []string
+	for _, l := range strings.Split(string(raw), "\n") {
+		if !strings.Contains(l, "CreationDate") {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+var number = regexp.MustCompile(`[-+]?(?:[0-9]+\.?[0-9]*|\.[0-9]+)(?:[eE][-+]?[0-9]+)?`)
+
+// numbers returns the index pairs of the numbers in s.  Digits
+// in words starting with '#', '_' or two letters, such as the
+// hexadecimal color "#1f77b4" or the identifier "clip12", are
+// not numbers, while the numbers of words like "M10" or "12px"
+// are.
+func numbers(s string) [][]int {
+	var idx [][]int
+	for _, m := range number.FindAllStringIndex(s, -1) {
+		i := m[0]
+		for i > 0 && isWordByte(s[i-1]) {
+			i--
+		}
+		if w := s[i:m[0]]; w != "" && (w[0] == '#' || w[0] == '_' || len(w) > 1 && isLetter(w[0]) && isLetter(w[1])) {
+			continue
+		}
+		idx = append(idx, m)
+	}
+	return idx
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isWordByte(c byte) bool {
+	return isLetter(c) || '0' <= c && c <= '9' || c == '_' || c == '#'
+}
+
+// equalNumbers returns whether a and b are equal apart
+// from numbers differing by at most tol.
+func equalNumbers(a, b string, tol float64) bool {
+	ia, ib := numbers(a), numbers(b)
+	if len(ia) != len(ib) {
+		return false
+	}
+	var ea, eb int
+	for i := range ia {
+		if a[ea:ia[i][0]] != b[eb:ib[i][0]] {
+			return false
+		}
+		na, errA := strconv.ParseFloat(a[ia[i][0]:ia[i][1]], 64)
+		nb, errB := strconv.ParseFloat(b[ib[i][0]:ib[i][1]], 64)
+		if errA != nil || errB != nil || math.Abs(na-nb) > tol {
+			return false
+		}
+		ea, eb = ia[i][1], ib[i][1]
+	}
+	return a[ea:] == b[eb:]
+}
+
+// rgbDiff returns the largest difference of the
+// channels of a and b in the range [0, 1].
+func rgbDiff(a, b color.Color) float64 {
+	ra, ga, ba, aa := a.RGBA()
+	rb, gb, bb, ab := b.RGBA()
+	d := diff(ra, rb)
+	for _, v := range []uint32{diff(ga, gb), diff(ba, bb), diff(aa, ab)} {
+		if v > d {
+			d = v
+		}
+	}
+	return float64(d) / math.MaxUint16
+}
+
+// deltaE returns the CIE76 color difference of a and b,
+// composed over a white background.
+func deltaE(a, b color.Color) float64 {
+	la, aa, ba := lab(a)
+	lb, ab, bb := lab(b)
+	return math.Sqrt((la-lb)*(la-lb) + (aa-ab)*(aa-ab) + (ba-bb)*(ba-bb))
+}
+
+// lab returns the CIELAB coordinates of c composed over
+// a white background, using the D65 white point.
+func lab(c color.Color) (l, a, b float64) {
+	r, g, bl := over(c)
+	lin := func(v float64) float64 {
+		if v <= 0.04045 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	r, g, bl = lin(r), lin(g), lin(bl)
+	x := (0.4124*r + 0.3576*g + 0.1805*bl) / 0.95047
+	y := 0.2126*r + 0.7152*g + 0.0722*bl
+	z := (0.0193*r + 0.1192*g + 0.9505*bl) / 1.08883
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return 116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)
+}
+
+// over returns the channels of c composed over
+// a white background in the range [0, 1].
+func over(c color.Color) (r, g, b float64) {
+	cr, cg, cb, ca := c.RGBA()
+	bg := float64(math.MaxUint16 - ca)
+	return (float64(cr) + bg) / math.MaxUint16,
+		(float64(cg) + bg) / math.MaxUint16,
+		(float64(cb) + bg) / math.MaxUint16
+}
+
+// ssimWindow is the size of the square windows
+// used to compute the structural similarity.
+const ssimWindow = 8
+
+// SSIM returns the mean structural similarity index of the
+// luminance of images a and b composed over a white background.
+// The index is computed for windows of 8×8 pixels, shifted by
+// 4 pixels.  It is 1 for equal images and decreases with
+// increasing differences in luminance, contrast and structure.
+// Images of different size have an index of 0.
+func SSIM(a, b image.Image) float64 {
+	ra, rb := a.Bounds(), b.Bounds()
+	if ra.Size() != rb.Size() {
+		return 0
+	}
+	w, h := ra.Dx(), ra.Dy()
+	if w == 0 || h == 0 {
+		return 1
+	}
+	luma := func(m image.Image, r image.Rectangle) []float64 {
+		l := make([]float64, w*h)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				cr, cg, cb := over(m.At(r.Min.X+x, r.Min.Y+y))
+				l[y*w+x] = 0.299*cr + 0.587*cg + 0.114*cb
+			}
+		}
+		return l
+	}
+	la, lb := luma(a, ra), luma(b, rb)
+
+	const (
+		c1 = 0.01 * 0.01
+		c2 = 0.03 * 0.03
+	)
+	win := func(n int) (size int, starts []int) {
+		size = ssimWindow
+		if n < size {
+			size = n
+		}
+		for s := 0; s+size < n; s += ssimWindow / 2 {
+			starts = append(starts, s)
+		}
+		return size, append(starts, n-size)
+	}
+	ww, xs := win(w)
+	wh, ys := win(h)
+
+	var sum float64
+	for _, y0 := range ys {
+		for _, x0 := range xs {
+			var ma, mb, va, vb, cov float64
+			n := float64(ww * wh)
+			for y := y0; y < y0+wh; y++ {
+				for x := x0; x < x0+ww; x++ {
+					ma += la[y*w+x]
+					mb += lb[y*w+x]
+				}
+			}
+			ma /= n
+			mb /= n
+			for y := y0; y < y0+wh; y++ {
+				for x := x0; x < x0+ww; x++ {
+					da, db := la[y*w+x]-ma, lb[y*w+x]-mb
+					va += da * da
+					vb += db * db
+					cov += da * db
+				}
+			}
+			va /= n
+			vb /= n
+			cov /= n
+			sum += (2*ma*mb + c1) * (2*cov + c2) / ((ma*ma + mb*mb + c1) * (va + vb + c2))
+		}
+	}
+	return sum / float64(len(xs)*len(ys))
+}
@@ -9,7 +9,10 @@ import (
 var _ Ticker = &DenseTicks{}
 
 // DenseTicks creates tick marks as dense as possible
-type DenseTicks struct {
+type DenseTicks struct{}
+
+// denseTicks is the state of the computation of dense tick marks.
+type denseTicks struct {
 	vks       int
 	delta     float64
 	fineStep  int
@@ -20,8 +23,9 @@ type DenseTicks struct {
 var finer = []float64{1, 0.5, 0.25, 0.2}
 var logCorr = []int{0, 1, 2, 1}
 
-func (mt *DenseTicks) Ticks(min, max float64, stringSizer StringSizer, axisSize vg.Length) []Tick {
-
+// Ticks returns Ticks in the specified range.
+func (*DenseTicks) Ticks(min, max float64, stringSizer StringSizer, axisSize vg.Length) []Tick {
+	var mt denseTicks
 	mt.delta = max - min
 	mt.log = int(math.Log10(mt.delta))
 	mt.stepWidth = exp10(mt.log)
@@ -61,7 +65,7 @@ func (mt *DenseTicks) Ticks(min, max float64, stringSizer StringSizer, axisSize
 
 const ZEROS = "0000000000000000000000000000000000000000000000000000000000000000000000000"
 
-func (mt *DenseTicks) checkTextWidth(size vg.Length, vks, nks int, stringSizer StringSizer) bool {
+func (mt *denseTicks) checkTextWidth(size vg.Length, vks, nks int, stringSizer StringSizer) bool {
 	s := ZEROS[:vks]
 	if nks > 0 {
 		s += "." + ZEROS[:nks]
@@ -70,11 +74,11 @@ func (mt *DenseTicks) checkTextWidth(size vg.Length, vks, nks int, stringSizer S
 	return size > width
 }
 
-func (mt *DenseTicks) getPixels(width vg.Length) vg.Length {
+func (mt *denseTicks) getPixels(width vg.Length) vg.Length {
 	return width * vg.Length(mt.stepWidth*finer[mt.fineStep]/mt.delta)
 }
 
-func (mt *DenseTicks) getNks() int {
+func (mt *denseTicks) getNks() int {
 	nks := logCorr[mt.fineStep] - mt.log
 	if nks < 0 {
 		return 0
@@ -82,7 +86,7 @@ func (mt *DenseTicks) getNks() int {
 	return nks
 }
 
-func (mt *DenseTicks) inc() {
+func (mt *denseTicks) inc() {
 	mt.fineStep++
 	if mt.fineStep == len(finer) {
 		mt.stepWidth /= 10
@@ -91,7 +95,7 @@ func (mt *DenseTicks) inc() {
 	}
 }
 
-func (mt *DenseTicks) dec() {
+func (mt *denseTicks) dec() {
 	mt.fineStep--
 	if mt.fineStep < 0 {
 		mt.stepWidth *= 10
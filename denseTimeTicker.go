@@ -20,8 +20,10 @@ type DenseTimeTicks struct {
 	// Must be the inverse of Time
 	Float func(t time.Time) float64
 
-	// Axis is required to transform the values from
-	// the data coordinate system to the graphic coordinate system
+	// Axis is the axis of the tick marks, whose Scale is
+	// used to transform the values from the data coordinate
+	// system to the graphic coordinate system.
+	// If nil, a linear scale is used.
 	Axis *Axis
 }
 
@@ -119,17 +121,23 @@ func yearly(years int) func(t time.Time) time.Time {
 	}
 }
 
+// Ticks returns Ticks in the specified range.
 func (t *DenseTimeTicks) Ticks(min, max float64, stringSizer StringSizer, axisSize vg.Length) []Tick {
-	if t.Time == nil || t.Float == nil {
-		t.Time = func(t float64) time.Time {
+	toTime, toFloat := t.Time, t.Float
+	if toTime == nil || toFloat == nil {
+		toTime = func(t float64) time.Time {
 			return time.Unix(int64(t), 0).In(time.UTC)
 		}
-		t.Float = func(t time.Time) float64 {
+		toFloat = func(t time.Time) float64 {
 			return float64(t.Unix())
 		}
 	}
+	var scale Normalizer = LinearScale{}
+	if t.Axis != nil && t.Axis.Scale != nil {
+		scale = t.Axis.Scale
+	}
 
-	minTime := t.Time(min)
+	minTime := toTime(min)
 	size := stringSizer(minTime.Format(t.Format))
 
 	index := 0
@@ -137,7 +145,7 @@ func (t *DenseTimeTicks) Ticks(min, max float64, stringSizer StringSizer, axisSi
 		t0 := incrementerList[index].norm(minTime)
 		t1 := incrementerList[index].incr(t0)
 
-		space := vg.Length(t.Axis.Norm(t.Float(t1))-t.Axis.Norm(t.Float(t0))) * axisSize
+		space := vg.Length(scale.Normalize(min, max, toFloat(t1))-scale.Normalize(min, max, toFloat(t0))) * axisSize
 
 		if space > size || index == len(incrementerList)-1 {
 			break
@@ -148,19 +156,19 @@ func (t *DenseTimeTicks) Ticks(min, max float64, stringSizer StringSizer, axisSi
 	incrementer := incrementerList[index]
 	tickTime := incrementer.norm(minTime)
 
-	for t.Float(tickTime) < min {
+	for toFloat(tickTime) < min {
 		tickTime = incrementer.incr(tickTime)
 	}
 
 	var ticker []Tick
 	for {
-		v := t.Float(tickTime)
+		v := toFloat(tickTime)
 		if v > max {
 			break
 		}
 		ticker = append(ticker, Tick{
 			Value: v,
-			Label: t.Time(v).Format(t.Format),
+			Label: toTime(v).Format(t.Format),
 		})
 		tickTime = incrementer.incr(tickTime)
 	}
@@ -0,0 +1,886 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nplot
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// EncodingVersion is the version of the serialized form of plots
+// written by Plot.MarshalJSON and Plot.GobEncode.  Plots written
+// with an older version can be read by later releases.
+const EncodingVersion = 2
+
+var registry = struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+	names map[reflect.Type]string
+}{
+	types: make(map[string]reflect.Type),
+	names: make(map[reflect.Type]string),
+}
+
+// RegisterType registers the concrete type of value for the
+// serialization of interface values, such as plotters, tickers
+// and colors, by EncodeJSON and DecodeJSON.  The type is also
+// registered by gob.Register, unless the pointer or element type
+// of a pointer is already registered, which gob does not allow.
+// The name of the type is the name used by gob.Register.
+//
+// The types of the nplot packages are registered by importing
+// the github.com/hneemann/nplot/gob package.
+func RegisterType(value interface{}) {
+	t := reflect.TypeOf(value)
+	name := typeName(t)
+
+	registry.Lock()
+	defer registry.Unlock()
+	if old, ok := registry.types[name]; ok && old != t {
+		panic(fmt.Sprintf("nplot: registering duplicate types for %q: %s != %s", name, old, t))
+	}
+	_, dup := registry.names[reflect.PtrTo(t)]
+	if t.Kind() == reflect.Ptr {
+		_, ok := registry.names[t.Elem()]
+		dup = dup || ok
+	}
+	registry.types[name] = t
+	registry.names[t] = name
+	if !dup {
+		gob.Register(value)
+	}
+}
+
+// typeName returns the name of t as used by gob.Register.
+func typeName(t reflect.Type) string {
+	name := t.String()
+	star := ""
+	if t.Name() == "" && t.Kind() == reflect.Ptr {
+		star = "*"
+		t = t.Elem()
+	}
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			name = star + t.Name()
+		} else {
+			name = star + t.PkgPath() + "." + t.Name()
+		}
+	}
+	return name
+}
+
+// A JSONProxy is a value that is serialized by EncodeJSON and
+// DecodeJSON in the form of another value, its proxy, such as a
+// struct holding its unexported fields.  Unlike the values of a
+// json.Marshaler, proxies are encoded together with the enclosing
+// value, so that pointers shared with it are preserved.
+type JSONProxy interface {
+	// JSONProxy returns a pointer to the
+	// serialized form of the value.
+	JSONProxy() interface{}
+
+	// SetJSONProxy sets the value from its decoded
+	// serialized form, of the type returned by JSONProxy.
+	SetJSONProxy(proxy interface{}) error
+}
+
+// EncodeJSON returns the JSON encoding of v.
+//
+// Values are encoded as by encoding/json with the following
+// differences:  The exported fields of structs are encoded by
+// their Go names, embedded structs as fields named by their type,
+// unless their type is not exported.  Fields of function or
+// channel type are omitted, and an error is returned if they
+// are not nil.  Infinite and NaN floating point values are
+// encoded as the strings "+Inf", "-Inf" and "NaN".  Non-nil
+// interface values are encoded as objects holding the name of
+// the concrete type, which must be registered by RegisterType,
+// and the value:
+//
+//	{"type":"image/color.RGBA","value":{"R":255,"G":0,"B":0,"A":255}}
+//
+// Values of types that are only registered by gob.Register
+// are encoded by gob, as base64 encoded strings:
+//
+//	{"gob":"..."}
+//
+// Pointers that are referenced more than once are encoded
+// at their first reference together with an id, which later
+// references refer to, so that decoding preserves the sharing
+// of the values and cycles:
+//
+//	{"$id":1,"value":{...}} ... {"$ref":1}
+//
+// Types implementing JSONProxy are encoded as their proxies,
+// and types implementing json.Marshaler or encoding.TextMarshaler
+// by these interfaces.
+//
+// The package encoding/json is not used for the encoding, since
+// it can neither decode interface values, nor represent shared
+// pointers and the non-finite values that are common in plots.
+func EncodeJSON(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return []byte("null"), nil
+	}
+	e := encoder{refs: make(map[pointer]int), ids: make(map[pointer]int)}
+	rv = addressable(rv)
+	e.count(rv)
+	if err := e.encode(rv); err != nil {
+		return nil, err
+	}
+	return e.Bytes(), nil
+}
+
+// DecodeJSON decodes the JSON encoding written by EncodeJSON
+// into the value pointed to by v.  Object fields that do not
+// match a struct field are ignored.
+func DecodeJSON(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("nplot: cannot decode into non-pointer %T", v)
+	}
+	d := decoder{ids: make(map[int]reflect.Value)}
+	return d.decode(data, rv.Elem())
+}
+
+// addressable returns an addressable copy of v, so
+// that methods with pointer receivers are found.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	p := reflect.New(v.Type()).Elem()
+	p.Set(v)
+	return p
+}
+
+// pointer identifies the value a pointer points to.
+type pointer struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+type encoder struct {
+	bytes.Buffer
+
+	// refs counts the references to the pointers
+	// of the encoded value.
+	refs map[pointer]int
+
+	// ids holds the ids of the pointers that are
+	// referenced more than once and have been
+	// encoded.
+	ids map[pointer]int
+}
+
+// gobValue holds the values encoded by gob.
+type gobValue struct {
+	V interface{}
+}
+
+var (
+	proxyType         = reflect.TypeOf((*JSONProxy)(nil)).Elem()
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// proxy returns the addressable proxy of v
+// if v implements JSONProxy.
+func proxy(v reflect.Value) (reflect.Value, bool) {
+	m := method(v, proxyType)
+	if m == nil {
+		return reflect.Value{}, false
+	}
+	p := reflect.ValueOf(m.(JSONProxy).JSONProxy())
+	if p.Kind() != reflect.Ptr || p.IsNil() {
+		return reflect.Value{}, false
+	}
+	return p.Elem(), true
+}
+
+// count counts the references to the pointers in v,
+// following the encoding of v.
+func (e *encoder) count(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		p := pointer{addr: v.Pointer(), typ: v.Type()}
+		e.refs[p]++
+		if e.refs[p] == 1 {
+			e.count(v.Elem())
+		}
+		return
+	case reflect.Interface:
+		if !v.IsNil() {
+			e.count(addressable(v.Elem()))
+		}
+		return
+	}
+	if p, ok := proxy(v); ok {
+		e.count(p)
+		return
+	}
+	if method(v, marshalerType) != nil || method(v, textMarshalerType) != nil {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, f := range fields(v.Type()) {
+			if !f.omitted {
+				e.count(v.FieldByIndex(f.index))
+			}
+		}
+	case reflect.Map:
+		if !pointerFree(v.Type().Elem()) {
+			for _, k := range v.MapKeys() {
+				e.count(addressable(v.MapIndex(k)))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if !pointerFree(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				e.count(addressable(v.Index(i)))
+			}
+		}
+	}
+}
+
+// pointerFree returns whether values of type t
+// contain no pointers or interface values.
+func pointerFree(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Array:
+		return pointerFree(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !pointerFree(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (e *encoder) encode(v reflect.Value) error {
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		e.WriteString("null")
+		return nil
+	}
+	if v.Kind() == reflect.Ptr {
+		p := pointer{addr: v.Pointer(), typ: v.Type()}
+		if id, ok := e.ids[p]; ok {
+			fmt.Fprintf(e, `{"$ref":%d}`, id)
+			return nil
+		}
+		if e.refs[p] < 2 {
+			return e.encode(v.Elem())
+		}
+		id := len(e.ids) + 1
+		e.ids[p] = id
+		fmt.Fprintf(e, `{"$id":%d,"value":`, id)
+		if err := e.encode(v.Elem()); err != nil {
+			return err
+		}
+		e.WriteByte('}')
+		return nil
+	}
+	if p, ok := proxy(v); ok {
+		return e.encode(p)
+	}
+	if m := method(v, marshalerType); m != nil {
+		b, err := m.(json.Marshaler).MarshalJSON()
+		if err != nil {
+			return err
+		}
+		e.Write(b)
+		return nil
+	}
+	if m := method(v, textMarshalerType); m != nil {
+		b, err := m.(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return err
+		}
+		return e.string(string(b))
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		e.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		switch {
+		case math.IsNaN(f):
+			e.WriteString(`"NaN"`)
+		case math.IsInf(f, 1):
+			e.WriteString(`"+Inf"`)
+		case math.IsInf(f, -1):
+			e.WriteString(`"-Inf"`)
+		default:
+			e.WriteString(strconv.FormatFloat(f, 'g', -1, v.Type().Bits()))
+		}
+	case reflect.String:
+		return e.string(v.String())
+	case reflect.Struct:
+		e.WriteByte('{')
+		n := 0
+		for _, f := range fields(v.Type()) {
+			fv := v.FieldByIndex(f.index)
+			if f.omitted {
+				if !fv.IsNil() {
+					return fmt.Errorf("nplot: cannot encode field %s of %s of type %s", f.name, v.Type(), fv.Type())
+				}
+				continue
+			}
+			if n > 0 {
+				e.WriteByte(',')
+			}
+			n++
+			e.string(f.name)
+			e.WriteByte(':')
+			if err := e.encode(fv); err != nil {
+				return err
+			}
+		}
+		e.WriteByte('}')
+	case reflect.Map:
+		if v.IsNil() {
+			e.WriteString("null")
+			return nil
+		}
+		keys := make([]string, 0, v.Len())
+		values := make(map[string]reflect.Value, v.Len())
+		for _, k := range v.MapKeys() {
+			var s string
+			switch k.Kind() {
+			case reflect.String:
+				s = k.String()
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				s = strconv.FormatInt(k.Int(), 10)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				s = strconv.FormatUint(k.Uint(), 10)
+			default:
+				return fmt.Errorf("nplot: unsupported map key type %s", k.Type())
+			}
+			keys = append(keys, s)
+			values[s] = v.MapIndex(k)
+		}
+		sort.Strings(keys)
+		e.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				e.WriteByte(',')
+			}
+			e.string(k)
+			e.WriteByte(':')
+			if err := e.encode(addressable(values[k])); err != nil {
+				return err
+			}
+		}
+		e.WriteByte('}')
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			e.WriteString("null")
+			return nil
+		}
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return e.string(base64.StdEncoding.EncodeToString(v.Bytes()))
+		}
+		e.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				e.WriteByte(',')
+			}
+			if err := e.encode(addressable(v.Index(i))); err != nil {
+				return err
+			}
+		}
+		e.WriteByte(']')
+	case reflect.Interface:
+		t := v.Elem().Type()
+		registry.RLock()
+		name, ok := registry.names[t]
+		registry.RUnlock()
+		if !ok {
+			// Fall back to the types registered by gob.Register.
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(&gobValue{V: v.Elem().Interface()}); err != nil {
+				return fmt.Errorf("nplot: type not registered: %s", typeName(t))
+			}
+			e.WriteString(`{"gob":`)
+			e.string(base64.StdEncoding.EncodeToString(buf.Bytes()))
+			e.WriteByte('}')
+			return nil
+		}
+		e.WriteString(`{"type":`)
+		e.string(name)
+		e.WriteString(`,"value":`)
+		if err := e.encode(addressable(v.Elem())); err != nil {
+			return err
+		}
+		e.WriteByte('}')
+	default:
+		return fmt.Errorf("nplot: unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func (e *encoder) string(s string) error {
+	b, err := json.Marshal(s)
+	e.Write(b)
+	return err
+}
+
+// method returns v, or the pointer to v, as an interface
+// value if it implements the interface type t.
+func method(v reflect.Value, t reflect.Type) interface{} {
+	if v.Type().Implements(t) && v.CanInterface() {
+		return v.Interface()
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() && v.Addr().Type().Implements(t) && v.Addr().CanInterface() {
+		return v.Addr().Interface()
+	}
+	return nil
+}
+
+type field struct {
+	name  string
+	index []int
+
+	// omitted is set for fields of function or
+	// channel type, which are not encoded.
+	omitted bool
+}
+
+// fields returns the fields of the struct type t.
+// The fields of embedded structs of unexported type are
+// encoded as fields of t.
+func fields(t reflect.Type) []field {
+	var fs []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			if !f.Anonymous || f.Type.Kind() != reflect.Struct {
+				continue
+			}
+			for _, ef := range fields(f.Type) {
+				ef.index = append([]int{i}, ef.index...)
+				fs = append(fs, ef)
+			}
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.Func, reflect.Chan:
+			fs = append(fs, field{name: f.Name, index: []int{i}, omitted: true})
+		case reflect.UnsafePointer:
+		default:
+			fs = append(fs, field{name: f.Name, index: []int{i}})
+		}
+	}
+	return fs
+}
+
+var (
+	unmarshalerType     = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+type decoder struct {
+	// ids holds the decoded pointers
+	// by their ids.
+	ids map[int]reflect.Value
+}
+
+// reference returns whether data is an object
+// holding the id of a pointer or a reference to it.
+func reference(data []byte) bool {
+	if len(data) == 0 || data[0] != '{' {
+		return false
+	}
+	data = bytes.TrimSpace(data[1:])
+	return bytes.HasPrefix(data, []byte(`"$id"`)) || bytes.HasPrefix(data, []byte(`"$ref"`))
+}
+
+// decode decodes data into the settable value v.
+func (d *decoder) decode(data []byte, v reflect.Value) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return errors.New("nplot: unexpected end of JSON input")
+	}
+	if bytes.Equal(data, []byte("null")) {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if v.Kind() == reflect.Ptr {
+		if reference(data) {
+			var r struct {
+				ID    *int            `json:"$id"`
+				Ref   *int            `json:"$ref"`
+				Value json.RawMessage `json:"value"`
+			}
+			if err := json.Unmarshal(data, &r); err != nil {
+				return err
+			}
+			if r.Ref != nil {
+				p, ok := d.ids[*r.Ref]
+				if !ok || p.Type() != v.Type() {
+					return fmt.Errorf("nplot: invalid reference %d to %s", *r.Ref, v.Type())
+				}
+				v.Set(p)
+				return nil
+			}
+			if r.ID != nil {
+				p := reflect.New(v.Type().Elem())
+				d.ids[*r.ID] = p
+				v.Set(p)
+				return d.decode(r.Value, p.Elem())
+			}
+		}
+		p := reflect.New(v.Type().Elem())
+		if err := d.decode(data, p.Elem()); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	}
+	if m := method(v, proxyType); m != nil {
+		pr, ok := proxy(v)
+		if !ok {
+			return fmt.Errorf("nplot: invalid proxy of %s", v.Type())
+		}
+		if err := d.decode(data, pr); err != nil {
+			return err
+		}
+		return m.(JSONProxy).SetJSONProxy(pr.Addr().Interface())
+	}
+	if m := method(v, unmarshalerType); m != nil {
+		return m.(json.Unmarshaler).UnmarshalJSON(data)
+	}
+	if m := method(v, textUnmarshalerType); m != nil {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return m.(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		var b bool
+		if err := json.Unmarshal(data, &b); err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if err := json.Unmarshal(data, &i); err != nil {
+			return err
+		}
+		if v.OverflowInt(i) {
+			return fmt.Errorf("nplot: value %d overflows %s", i, v.Type())
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		if err := json.Unmarshal(data, &u); err != nil {
+			return err
+		}
+		if v.OverflowUint(u) {
+			return fmt.Errorf("nplot: value %d overflows %s", u, v.Type())
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if data[0] == '"' {
+			var s string
+			if err := json.Unmarshal(data, &s); err != nil {
+				return err
+			}
+			switch s {
+			case "NaN":
+				f = math.NaN()
+			case "+Inf":
+				f = math.Inf(1)
+			case "-Inf":
+				f = math.Inf(-1)
+			default:
+				return fmt.Errorf("nplot: invalid number %q", s)
+			}
+		} else if err := json.Unmarshal(data, &f); err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.String:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		v.SetString(s)
+	case reflect.Struct:
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(data, &m); err != nil {
+			return err
+		}
+		for _, f := range fields(v.Type()) {
+			if fd, ok := m[f.name]; ok {
+				if err := d.decode(fd, v.FieldByIndex(f.index)); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Map:
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(data, &m); err != nil {
+			return err
+		}
+		t := v.Type()
+		mv := reflect.MakeMapWithSize(t, len(m))
+		for k, ed := range m {
+			kv := reflect.New(t.Key()).Elem()
+			switch kv.Kind() {
+			case reflect.String:
+				kv.SetString(k)
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				i, err := strconv.ParseInt(k, 10, t.Key().Bits())
+				if err != nil {
+					return err
+				}
+				kv.SetInt(i)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				u, err := strconv.ParseUint(k, 10, t.Key().Bits())
+				if err != nil {
+					return err
+				}
+				kv.SetUint(u)
+			default:
+				return fmt.Errorf("nplot: unsupported map key type %s", t.Key())
+			}
+			ev := reflect.New(t.Elem()).Elem()
+			if err := d.decode(ed, ev); err != nil {
+				return err
+			}
+			mv.SetMapIndex(kv, ev)
+		}
+		v.Set(mv)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 && data[0] == '"' {
+			var b []byte
+			if err := json.Unmarshal(data, &b); err != nil {
+				return err
+			}
+			v.SetBytes(b)
+			return nil
+		}
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+		for i, ed := range elems {
+			if err := d.decode(ed, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	case reflect.Array:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return err
+		}
+		if len(elems) != v.Len() {
+			return fmt.Errorf("nplot: invalid length %d of %s", len(elems), v.Type())
+		}
+		for i, ed := range elems {
+			if err := d.decode(ed, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Interface:
+		var iv struct {
+			Type  string          `json:"type"`
+			Value json.RawMessage `json:"value"`
+			Gob   []byte          `json:"gob"`
+		}
+		if err := json.Unmarshal(data, &iv); err != nil {
+			return err
+		}
+		if iv.Gob != nil {
+			var gv gobValue
+			if err := gob.NewDecoder(bytes.NewReader(iv.Gob)).Decode(&gv); err != nil {
+				return err
+			}
+			ev := reflect.ValueOf(gv.V)
+			if !ev.IsValid() || !ev.Type().Implements(v.Type()) {
+				return fmt.Errorf("nplot: type %T does not implement %s", gv.V, v.Type())
+			}
+			v.Set(ev)
+			return nil
+		}
+		registry.RLock()
+		t, ok := registry.types[iv.Type]
+		registry.RUnlock()
+		if !ok {
+			return fmt.Errorf("nplot: type not registered: %s", iv.Type)
+		}
+		if !t.Implements(v.Type()) {
+			return fmt.Errorf("nplot: type %s does not implement %s", iv.Type, v.Type())
+		}
+		ev := reflect.New(t).Elem()
+		if err := d.decode(iv.Value, ev); err != nil {
+			return err
+		}
+		v.Set(ev)
+	default:
+		return fmt.Errorf("nplot: unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// plot has the fields but not the methods of Plot.
+type plot Plot
+
+// plotData is the serialized form of a Plot.
+type plotData struct {
+	Version int
+	plot
+	Plotters []Plotter
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// The plot is encoded by EncodeJSON as an object holding
+// EncodingVersion in the field "Version", the exported
+// fields of the plot and the plotters in the field "Plotters".
+// The types of the plotters, tickers, scales, colors and other
+// interface values of the plot must be registered by RegisterType
+// or gob.Register.  Plots holding functions, such as the F field
+// of a plotter.Function, cannot be encoded.
+func (p *Plot) MarshalJSON() ([]byte, error) {
+	d := plotData{Version: EncodingVersion, plot: plot(*p.clone()), Plotters: p.plotters}
+	d.X.Tick.Marker = detachTicker(d.X.Tick.Marker)
+	d.Y.Tick.Marker = detachTicker(d.Y.Tick.Marker)
+	return EncodeJSON(&d)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It decodes plots encoded by MarshalJSON with an encoding
+// version up to EncodingVersion.
+func (p *Plot) UnmarshalJSON(data []byte) error {
+	var v struct{ Version int }
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Version < 1 || v.Version > EncodingVersion {
+		return fmt.Errorf("nplot: unsupported encoding version %d", v.Version)
+	}
+	var d plotData
+	if err := DecodeJSON(data, &d); err != nil {
+		return err
+	}
+	*p = Plot(d.plot)
+	p.plotters = d.Plotters
+	attachTicker(&p.X)
+	attachTicker(&p.Y)
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+// The plot is encoded in the form written by MarshalJSON.
+func (p *Plot) GobEncode() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (p *Plot) GobDecode(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+// detachTicker returns t without the reference of
+// a DenseTimeTicks to its axis, which would make
+// the axis a cyclic value.
+func detachTicker(t Ticker) Ticker {
+	if dt, ok := t.(*DenseTimeTicks); ok && dt.Axis != nil {
+		c := *dt
+		c.Axis = nil
+		return &c
+	}
+	return t
+}
+
+// attachTicker sets the axis of a DenseTimeTicks
+// ticker of a to a.
+func attachTicker(a *Axis) {
+	if dt, ok := a.Tick.Marker.(*DenseTimeTicks); ok && dt.Axis == nil {
+		dt.Axis = a
+	}
+}
+
+// legend has the fields but not the methods of Legend.
+type legend Legend
+
+// legendData is the serialized form of a Legend.
+type legendData struct {
+	legend
+	Entries []legendEntryData
+}
+
+type legendEntryData struct {
+	Text         string
+	Thumbnailers []Thumbnailer
+}
+
+// JSONProxy implements the JSONProxy interface.
+// The legend is encoded with its entries in the
+// field "Entries".
+func (l *Legend) JSONProxy() interface{} {
+	d := legendData{legend: legend(*l)}
+	for _, e := range l.entries {
+		d.Entries = append(d.Entries, legendEntryData{Text: e.text, Thumbnailers: e.thumbs})
+	}
+	return &d
+}
+
+// SetJSONProxy implements the JSONProxy interface.
+func (l *Legend) SetJSONProxy(proxy interface{}) error {
+	d := proxy.(*legendData)
+	*l = Legend(d.legend)
+	l.entries = nil
+	for _, e := range d.Entries {
+		l.entries = append(l.entries, legendEntry{text: e.Text, thumbs: e.Thumbnailers})
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The legend is encoded by EncodeJSON.
+func (l *Legend) MarshalJSON() ([]byte, error) {
+	return EncodeJSON(l)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *Legend) UnmarshalJSON(data []byte) error {
+	return DecodeJSON(data, l)
+}
@@ -2,45 +2,167 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Package gob registers the types of the nplot packages for the
+// serialization of plots by gob and by nplot.Plot.MarshalJSON.
+//
+// Plots are serialized with their plotters, tickers, scales and
+// palettes.  Types defined outside of the nplot packages, such as
+// custom tickers or grids of heat maps, must be registered by
+// nplot.RegisterType.
 package gob // import "github.com/hneemann/nplot/gob"
 
 import (
-	"encoding/gob"
+	"image"
 	"image/color"
 
 	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/palette"
+	"github.com/hneemann/nplot/palette/brewer"
+	"github.com/hneemann/nplot/palette/moreland"
 	"github.com/hneemann/nplot/plotter"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
 )
 
 func init() {
 	// register types for proper gob-encoding/decoding
-	gob.Register(color.Gray16{})
+
+	// color.Color
+	nplot.RegisterType(color.Alpha{})
+	nplot.RegisterType(color.Alpha16{})
+	nplot.RegisterType(color.CMYK{})
+	nplot.RegisterType(color.Gray{})
+	nplot.RegisterType(color.Gray16{})
+	nplot.RegisterType(color.NRGBA{})
+	nplot.RegisterType(color.NRGBA64{})
+	nplot.RegisterType(color.NYCbCrA{})
+	nplot.RegisterType(color.RGBA{})
+	nplot.RegisterType(color.RGBA64{})
+	nplot.RegisterType(color.YCbCr{})
+	nplot.RegisterType(palette.HSVA{})
+	nplot.RegisterType(brewer.Color{})
+
+	// image.Image
+	nplot.RegisterType(&image.Alpha{})
+	nplot.RegisterType(&image.Alpha16{})
+	nplot.RegisterType(&image.CMYK{})
+	nplot.RegisterType(&image.Gray{})
+	nplot.RegisterType(&image.Gray16{})
+	nplot.RegisterType(&image.NRGBA{})
+	nplot.RegisterType(&image.NRGBA64{})
+	nplot.RegisterType(&image.NYCbCrA{})
+	nplot.RegisterType(&image.Paletted{})
+	nplot.RegisterType(&image.RGBA{})
+	nplot.RegisterType(&image.RGBA64{})
+	nplot.RegisterType(&image.Uniform{})
+	nplot.RegisterType(&image.YCbCr{})
+
+	// vg.FillStyle
+	nplot.RegisterType(vg.LinearGradient{})
+	nplot.RegisterType(vg.RadialGradient{})
+	nplot.RegisterType(vg.HatchPattern{})
+
+	// draw.GlyphDrawer
+	nplot.RegisterType(draw.ArrowGlyph{})
+	nplot.RegisterType(draw.BoxGlyph{})
+	nplot.RegisterType(draw.CircleGlyph{})
+	nplot.RegisterType(draw.CrossGlyph{})
+	nplot.RegisterType(draw.DiamondGlyph{})
+	nplot.RegisterType(draw.HalfFilledGlyph{})
+	nplot.RegisterType(draw.HexagonGlyph{})
+	nplot.RegisterType(draw.ImageGlyph{})
+	nplot.RegisterType(draw.PathGlyph{})
+	nplot.RegisterType(draw.PentagonGlyph{})
+	nplot.RegisterType(draw.PlusGlyph{})
+	nplot.RegisterType(draw.PyramidGlyph{})
+	nplot.RegisterType(draw.RingGlyph{})
+	nplot.RegisterType(draw.SquareGlyph{})
+	nplot.RegisterType(draw.StarGlyph{})
+	nplot.RegisterType(draw.TextGlyph{})
+	nplot.RegisterType(draw.TriangleGlyph{})
 
 	// nplot.Ticker
-	gob.Register(nplot.ConstantTicks{})
-	gob.Register(nplot.DefaultTicks{})
-	gob.Register(nplot.LogTicks{})
+	nplot.RegisterType(nplot.ConstantTicks{})
+	nplot.RegisterType(nplot.DefaultTicks{})
+	nplot.RegisterType(nplot.LogTicks{})
+	nplot.RegisterType(nplot.TimeTicks{})
+	nplot.RegisterType(&nplot.DenseTicks{})
+	nplot.RegisterType(&nplot.DenseTimeTicks{})
 
 	// nplot.Normalizer
-	gob.Register(nplot.LinearScale{})
-	gob.Register(nplot.LogScale{})
-
-	// nplot.Plotter
-	gob.Register(plotter.BarChart{})
-	gob.Register(plotter.Histogram{})
-	gob.Register(plotter.BoxPlot{})
-	gob.Register(plotter.YErrorBars{})
-	gob.Register(plotter.XErrorBars{})
-	gob.Register(plotter.Function{})
-	gob.Register(plotter.GlyphBoxes{})
-	gob.Register(plotter.Grid{})
-	gob.Register(plotter.Labels{})
-	gob.Register(plotter.Line{})
-	gob.Register(plotter.QuartPlot{})
-	gob.Register(plotter.Scatter{})
-
-	// plotter.XYZer
-	gob.Register(plotter.XYZs{})
-	gob.Register(plotter.XYValues{})
+	nplot.RegisterType(nplot.LinearScale{})
+	nplot.RegisterType(nplot.LogScale{})
+	nplot.RegisterType(nplot.InvertedScale{})
+
+	// nplot.Plotter and nplot.Thumbnailer
+	nplot.RegisterType(&plotter.BarChart{})
+	nplot.RegisterType(&plotter.BoxPlot{})
+	nplot.RegisterType(&plotter.Candlestick{})
+	nplot.RegisterType(&plotter.ColorBar{})
+	nplot.RegisterType(&plotter.Contour{})
+	nplot.RegisterType(&plotter.Field{})
+	nplot.RegisterType(plotter.Function{})
+	nplot.RegisterType(&plotter.Function{})
+	nplot.RegisterType(plotter.GlyphBoxes{})
+	nplot.RegisterType(&plotter.GlyphBoxes{})
+	nplot.RegisterType(&plotter.Grid{})
+	nplot.RegisterType(&plotter.HeatMap{})
+	nplot.RegisterType(&plotter.HexBin{})
+	nplot.RegisterType(&plotter.Histogram{})
+	nplot.RegisterType(&plotter.Image{})
+	nplot.RegisterType(&plotter.KDE{})
+	nplot.RegisterType(&plotter.Labels{})
+	nplot.RegisterType(&plotter.Line{})
+	nplot.RegisterType(&plotter.OHLCBars{})
+	nplot.RegisterType(&plotter.Pie{})
+	nplot.RegisterType(&plotter.Polygon{})
+	nplot.RegisterType(&plotter.PP{})
+	nplot.RegisterType(&plotter.QQ{})
+	nplot.RegisterType(&plotter.QuartPlot{})
+	nplot.RegisterType(&plotter.Sankey{})
+	nplot.RegisterType(&plotter.Scatter{})
+	nplot.RegisterType(&plotter.Streamlines{})
+	nplot.RegisterType(&plotter.Violin{})
+	nplot.RegisterType(&plotter.VolumeBars{})
+	nplot.RegisterType(&plotter.XErrorBars{})
+	nplot.RegisterType(&plotter.YErrorBars{})
+	nplot.RegisterType(plotter.PaletteThumbnailers(palette.Heat(1, 1))[0])
+	nplot.RegisterType((&plotter.Contour{Levels: []float64{0}}).ColorMap())
+	if s, err := plotter.NewSankey(plotter.Flow{ReceptorCategory: 1}); err == nil {
+		_, thumbs := s.Thumbnailers()
+		nplot.RegisterType(thumbs[0])
+	}
+	if p, err := plotter.NewPie(plotter.Values{1}, nil); err == nil {
+		_, thumbs := p.Thumbnailers()
+		nplot.RegisterType(thumbs[0])
+	}
+
+	// plotter.Valuer, plotter.XYer and plotter.XYZer
+	nplot.RegisterType(plotter.Values{})
+	nplot.RegisterType(plotter.XYs{})
+	nplot.RegisterType(plotter.XValues{})
+	nplot.RegisterType(plotter.YValues{})
+	nplot.RegisterType(plotter.XYZs{})
+	nplot.RegisterType(plotter.XYValues{})
+	nplot.RegisterType(plotter.WeightedValues{})
+	nplot.RegisterType(plotter.OHLCs{})
+	nplot.RegisterType(&plotter.Bins2D{})
+	nplot.RegisterType(&plotter.Ring{})
+	nplot.RegisterType(&plotter.Series{})
 
+	// palette.Palette and palette.ColorMap
+	nplot.RegisterType(palette.Heat(1, 1))
+	nplot.RegisterType(palette.Radial(2, palette.Red, palette.Blue, 1))
+	nplot.RegisterType(palette.Log(moreland.Kindlmann()))
+	nplot.RegisterType(palette.Reverse(moreland.Kindlmann()))
+	nplot.RegisterType(brewer.DivergingPalette{})
+	nplot.RegisterType(brewer.NonDivergingPalette{})
+	nplot.RegisterType(moreland.Kindlmann())
+	nplot.RegisterType(moreland.Kindlmann().Palette(2))
+	nplot.RegisterType(moreland.SmoothBlueRed())
+	k := moreland.Kindlmann()
+	k.SetMax(1)
+	if c, err := k.At(0); err == nil {
+		nplot.RegisterType(c)
+	}
 }
@@ -7,7 +7,11 @@ package gob_test
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
+	"image"
 	"image/color"
+	"io/ioutil"
+	"math"
 	"os"
 	"testing"
 
@@ -15,13 +19,16 @@ import (
 
 	"github.com/hneemann/nplot"
 	_ "github.com/hneemann/nplot/gob"
+	"github.com/hneemann/nplot/palette/moreland"
 	"github.com/hneemann/nplot/plotter"
 	"github.com/hneemann/nplot/vg"
 	"github.com/hneemann/nplot/vg/draw"
+	"github.com/hneemann/nplot/vg/recorder"
 )
 
 func init() {
 	gob.Register(commaTicks{})
+	nplot.RegisterType(offsetGrid{})
 }
 
 func TestPersistency(t *testing.T) {
@@ -93,24 +100,250 @@ func TestPersistency(t *testing.T) {
 		t.Fatalf("error gob-encoding nplot: %v\n", err)
 	}
 
-	// TODO(sbinet): impl. BinaryMarshal for nplot.Plot and vg.Font
-	// {
-	// 	dec := gob.NewDecoder(buf)
-	// 	var p nplot.Plot
-	// 	err = dec.Decode(&p)
-	// 	if err != nil {
-	// 		t.Fatalf("error gob-decoding nplot: %v\n", err)
-	// 	}
-	// 	// Save the nplot to a PNG file.
-	// 	err = p.Save(4, 4, "test-persistency-readback.png")
-	// 	if err != nil {
-	// 		t.Fatalf("error saving to PNG: %v\n", err)
-	// 	}
-	//  defer os.Remove("test-persistency-readback.png")
-	// }
+	var got nplot.Plot
+	err = gob.NewDecoder(buf).Decode(&got)
+	if err != nil {
+		t.Fatalf("error gob-decoding nplot: %v\n", err)
+	}
+	if d := recorder.Diff(record(p), record(&got), recorder.Tolerance{}); len(d) != 0 {
+		t.Errorf("unexpected differences after gob round trip: %v", d)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	p, err := nplot.New()
+	if err != nil {
+		t.Fatalf("error creating nplot: %v\n", err)
+	}
+	p.Title.Text = "JSON Example"
+	p.X.Tick.Marker = &nplot.DenseTimeTicks{Format: "2006-01-02", Axis: &p.X}
+	p.Y.Scale = nplot.InvertedScale{Normalizer: nplot.LinearScale{}}
+	p.Y.Tick.Marker = &nplot.DenseTicks{}
+
+	b1, err := plotter.NewBarChart(plotter.Values{1, 2, 3}, vg.Points(5))
+	if err != nil {
+		t.Fatalf("error creating bar chart: %v\n", err)
+	}
+	b2, err := plotter.NewBarChart(plotter.Values{2, 1, 0.5}, vg.Points(5))
+	if err != nil {
+		t.Fatalf("error creating bar chart: %v\n", err)
+	}
+	b2.StackOn(b1)
+	b2.Color = color.RGBA{R: 255, A: 255}
 
+	box, err := plotter.NewBoxPlot(vg.Points(10), 5, plotter.Values{1, 2, 3, 4, 10})
+	if err != nil {
+		t.Fatalf("error creating box plot: %v\n", err)
+	}
+
+	pal := moreland.SmoothBlueRed()
+	pal.SetMin(0)
+	pal.SetMax(5)
+	h := plotter.NewHeatMap(offsetGrid{Data: []float64{0, 1, 2, 3, math.NaN(), 5}}, pal.Palette(10))
+
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(1, 1, color.NRGBA{G: 255, A: 255})
+	im := plotter.NewImage(img, 4, 4, 5, 5)
+
+	s, err := plotter.NewSankey(
+		plotter.Flow{SourceCategory: 0, SourceLabel: "a", ReceptorCategory: 1, ReceptorLabel: "b", Value: 2},
+		plotter.Flow{SourceCategory: 0, SourceLabel: "a", ReceptorCategory: 1, ReceptorLabel: "c", Value: 1},
+	)
+	if err != nil {
+		t.Fatalf("error creating sankey: %v\n", err)
+	}
+
+	c := plotter.NewContour(offsetGrid{Data: []float64{0, 1, 2, 3, 4, 5}}, []float64{1, 2.5, 4}, pal.Palette(4))
+	c.Fill = true
+	c.Underflow = color.Gray{Y: 128}
+	c.LabelFormat = "%.1f"
+
+	p.Add(b1, b2, box, h, im, s, c, c.ColorBar())
+	p.Legend.Add("bars", b2)
+	labels, thumbs := c.Thumbnailers()
+	for i, l := range labels {
+		p.Legend.Add(l, thumbs[i])
+	}
+	p.Legend.Add("heat", plotter.PaletteThumbnailers(pal.Palette(3))...)
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("error encoding nplot: %v\n", err)
+	}
+	if !bytes.HasPrefix(data, []byte(`{"Version":2,`)) {
+		t.Errorf("missing version in encoding: %.40s", data)
+	}
+
+	var got nplot.Plot
+	err = json.Unmarshal(data, &got)
+	if err != nil {
+		t.Fatalf("error decoding nplot: %v\n", err)
+	}
+	again, err := json.Marshal(&got)
+	if err != nil {
+		t.Fatalf("error encoding decoded nplot: %v\n", err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Errorf("unexpected encoding after round trip:\ngot:\n%s\nwant:\n%s", again, data)
+	}
+
+	if d := recorder.Diff(record(p), record(&got), recorder.Tolerance{}); len(d) != 0 {
+		t.Errorf("unexpected differences after JSON round trip: %v", d)
+	}
+
+	err = json.Unmarshal([]byte(`{"Version":3}`), &got)
+	if err == nil {
+		t.Errorf("expected error for unsupported version")
+	}
+
+	bars, err := nplot.EncodeJSON([]nplot.Plotter{b2, b1})
+	if err != nil {
+		t.Fatalf("error encoding bar charts: %v\n", err)
+	}
+	var gotBars []nplot.Plotter
+	err = nplot.DecodeJSON(bars, &gotBars)
+	if err != nil {
+		t.Fatalf("error decoding bar charts: %v\n", err)
+	}
+	gb2, gb1 := gotBars[0].(*plotter.BarChart), gotBars[1].(*plotter.BarChart)
+	gb1.Values[0] = 10
+	if h := gb2.BarHeight(0); h != 12 {
+		t.Errorf("decoded bar chart not stacked on decoded bar chart: got height:%v want:12", h)
+	}
 }
 
+func TestJSONVersion1(t *testing.T) {
+	// plot_v1.json was written by MarshalJSON
+	// with encoding version 1 from this plot.
+	p, err := nplot.New()
+	if err != nil {
+		t.Fatalf("error creating nplot: %v\n", err)
+	}
+	p.Title.Text = "Version 1"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+	p.Y.Min, p.Y.Max = 0, 4
+	p.Add(plotter.NewGrid())
+	l, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 1}, {X: 1, Y: 3}, {X: 2, Y: 2}})
+	if err != nil {
+		t.Fatalf("error creating line: %v\n", err)
+	}
+	l.Color = color.RGBA{B: 255, A: 255}
+	s, err := plotter.NewScatter(plotter.XYs{{X: 0.5, Y: 0.5}, {X: 1.5, Y: 2.5}})
+	if err != nil {
+		t.Fatalf("error creating scatter: %v\n", err)
+	}
+	b, err := plotter.NewBarChart(plotter.Values{1, 2, 3}, vg.Points(10))
+	if err != nil {
+		t.Fatalf("error creating bar chart: %v\n", err)
+	}
+	b.Color = color.Gray{Y: 128}
+	p.Add(b, l, s)
+	p.Legend.Add("line", l)
+	p.Legend.Add("points", s)
+
+	data, err := ioutil.ReadFile("testdata/plot_v1.json")
+	if err != nil {
+		t.Fatalf("error reading encoding: %v\n", err)
+	}
+	var got nplot.Plot
+	err = json.Unmarshal(data, &got)
+	if err != nil {
+		t.Fatalf("error decoding nplot: %v\n", err)
+	}
+	if d := recorder.Diff(record(p), record(&got), recorder.Tolerance{}); len(d) != 0 {
+		t.Errorf("unexpected differences of version 1 plot: %v", d)
+	}
+}
+
+func TestJSONGobTypes(t *testing.T) {
+	p, err := nplot.New()
+	if err != nil {
+		t.Fatalf("error creating nplot: %v\n", err)
+	}
+	p.Y.Tick.Marker = commaTicks{}
+	p.Y.Min, p.Y.Max = 0, 1e6
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("error encoding nplot: %v\n", err)
+	}
+	var got nplot.Plot
+	err = json.Unmarshal(data, &got)
+	if err != nil {
+		t.Fatalf("error decoding nplot: %v\n", err)
+	}
+	if _, ok := got.Y.Tick.Marker.(commaTicks); !ok {
+		t.Errorf("unexpected ticker after round trip: %T", got.Y.Tick.Marker)
+	}
+}
+
+func TestJSONSharedPointers(t *testing.T) {
+	type pair struct {
+		A, B *plotter.Values
+		C    *plotter.Values
+	}
+	v := plotter.Values{1, 2}
+	data, err := nplot.EncodeJSON(pair{A: &v, B: &v, C: &plotter.Values{3}})
+	if err != nil {
+		t.Fatalf("error encoding: %v\n", err)
+	}
+	var got pair
+	err = nplot.DecodeJSON(data, &got)
+	if err != nil {
+		t.Fatalf("error decoding: %v\n", err)
+	}
+	if got.A != got.B || got.A == got.C {
+		t.Errorf("unexpected pointers after round trip of %s", data)
+	}
+	if (*got.A)[1] != 2 || (*got.C)[0] != 3 {
+		t.Errorf("unexpected values after round trip of %s", data)
+	}
+}
+
+func TestJSONEmpty(t *testing.T) {
+	for _, data := range []string{"", " ", "\n\t"} {
+		var v plotter.Values
+		err := nplot.DecodeJSON([]byte(data), &v)
+		if err == nil || err.Error() != "nplot: unexpected end of JSON input" {
+			t.Errorf("unexpected error for %q: %v", data, err)
+		}
+		var p *plotter.Values
+		err = nplot.DecodeJSON([]byte(data), &p)
+		if err == nil || err.Error() != "nplot: unexpected end of JSON input" {
+			t.Errorf("unexpected error for %q into pointer: %v", data, err)
+		}
+	}
+}
+
+func TestJSONFunction(t *testing.T) {
+	p, err := nplot.New()
+	if err != nil {
+		t.Fatalf("error creating nplot: %v\n", err)
+	}
+	p.Add(plotter.NewFunction(math.Sin))
+	if _, err := json.Marshal(p); err == nil {
+		t.Errorf("expected error for function")
+	}
+}
+
+// record returns the recording of p drawn on a 10cm×10cm canvas.
+func record(p *nplot.Plot) *recorder.Canvas {
+	var c recorder.Canvas
+	p.Draw(draw.NewCanvas(&c, 10*vg.Centimeter, 10*vg.Centimeter))
+	return &c
+}
+
+// offsetGrid is a 3×2 grid of values.
+type offsetGrid struct {
+	Data []float64
+}
+
+func (g offsetGrid) Dims() (c, r int)   { return 3, 2 }
+func (g offsetGrid) Z(c, r int) float64 { return g.Data[r*3+c] }
+func (g offsetGrid) X(c int) float64    { return float64(c) }
+func (g offsetGrid) Y(r int) float64    { return float64(r) + 0.5 }
+
 // randomPoints returns some random x, y points.
 func randomPoints(n int, rnd *rand.Rand) plotter.XYs {
 	pts := make(plotter.XYs, n)
@@ -0,0 +1,65 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"image/color"
+	"math"
+)
+
+// Log returns a ColorMap that maps values logarithmically
+// onto ColorMap c, so that the colors of c are spread evenly
+// over the orders of magnitude between Min and Max.  The
+// range is shared with c, and Min must be positive.
+func Log(c ColorMap) ColorMap {
+	return logarithmic{ColorMap: c}
+}
+
+// logarithmic is a ColorMap that maps values logarithmically
+// onto the ColorMap it contains.
+type logarithmic struct {
+	ColorMap
+}
+
+// At implements the ColorMap interface for a logarithmic ColorMap.
+func (l logarithmic) At(v float64) (color.Color, error) {
+	min, max := l.Min(), l.Max()
+	switch {
+	case math.IsNaN(v):
+		return nil, ErrNaN
+	case v < min:
+		return nil, ErrUnderflow
+	case v > max:
+		return nil, ErrOverflow
+	}
+	t := math.Log(v/min) / math.Log(max/min)
+	if math.IsNaN(t) || math.IsInf(t, 0) {
+		return nil, ErrNaN
+	}
+	return l.ColorMap.At(min + t*(max-min))
+}
+
+// Palette implements the ColorMap interface for a logarithmic ColorMap.
+// The colors are those at logarithmically spaced values between Min
+// and Max.
+func (l logarithmic) Palette(colors int) Palette {
+	min, max := l.Min(), l.Max()
+	c := make([]color.Color, colors)
+	for i := range c {
+		v := min
+		switch {
+		case i == colors-1:
+			v = max
+		case i > 0:
+			v = min * math.Pow(max/min, float64(i)/float64(colors-1))
+		}
+		var err error
+		c[i], err = l.At(v)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return palette(c)
+}
@@ -0,0 +1,71 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette_test
+
+import (
+	"testing"
+
+	"github.com/hneemann/nplot/palette"
+	"github.com/hneemann/nplot/palette/moreland"
+)
+
+func TestLog(t *testing.T) {
+	lin := moreland.Kindlmann()
+	lin.SetMin(1)
+	lin.SetMax(100)
+	log := palette.Log(lin)
+	for _, test := range []struct {
+		v, want float64
+	}{
+		{v: 1, want: 1},
+		{v: 10, want: 50.5},
+		{v: 100, want: 100},
+	} {
+		got, err := log.At(test.v)
+		if err != nil {
+			t.Fatalf("unexpected error at %v: %v", test.v, err)
+		}
+		want, err := lin.At(test.want)
+		if err != nil {
+			t.Fatalf("unexpected error at %v: %v", test.want, err)
+		}
+		if got != want {
+			t.Errorf("unexpected color at %v: got:%v want:%v", test.v, got, want)
+		}
+	}
+	for _, v := range []float64{0.5, 200} {
+		if _, err := log.At(v); err == nil {
+			t.Errorf("expected error at %v", v)
+		}
+	}
+
+	lin.SetMin(0)
+	if _, err := log.At(1); err != palette.ErrNaN {
+		t.Errorf("unexpected error for non-positive minimum: got:%v want:%v", err, palette.ErrNaN)
+	}
+}
+
+func TestLogPalette(t *testing.T) {
+	lin := moreland.Kindlmann()
+	lin.SetMin(1)
+	lin.SetMax(100)
+	log := palette.Log(lin)
+	got := log.Palette(3).Colors()
+	if len(got) != 3 {
+		t.Fatalf("unexpected number of colors: got:%d want:3", len(got))
+	}
+	for i, v := range []float64{1, 10, 100} {
+		want, err := log.At(v)
+		if err != nil {
+			t.Fatalf("unexpected error at %v: %v", v, err)
+		}
+		if got[i] != want {
+			t.Errorf("unexpected color %d: got:%v want:%v", i, got[i], want)
+		}
+	}
+	if n := len(log.Palette(1).Colors()); n != 1 {
+		t.Errorf("unexpected number of colors: got:%d want:1", n)
+	}
+}
@@ -5,6 +5,7 @@
 package moreland
 
 import (
+	"encoding/json"
 	"fmt"
 	"image/color"
 	"math"
@@ -37,6 +38,38 @@ type luminance struct {
 	min, max float64
 }
 
+// luminanceData is the serialized form of a luminance.
+type luminanceData struct {
+	Colors   []cieLAB
+	Scalars  []float64
+	Alpha    float64
+	Min, Max float64
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (l *luminance) MarshalJSON() ([]byte, error) {
+	return json.Marshal(luminanceData{
+		Colors:  l.colors,
+		Scalars: l.scalars,
+		Alpha:   l.alpha,
+		Min:     l.min,
+		Max:     l.max,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *luminance) UnmarshalJSON(data []byte) error {
+	var d luminanceData
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	if len(d.Colors) != len(d.Scalars) {
+		return fmt.Errorf("moreland: %d colors and %d scalars", len(d.Colors), len(d.Scalars))
+	}
+	*l = luminance{colors: d.Colors, scalars: d.Scalars, alpha: d.Alpha, min: d.Min, max: d.Max}
+	return nil
+}
+
 // NewLuminance creates a new Luminance ColorMap from the given controlColors.
 // luminance is a color palette that interpolates
 // between control colors in a way that ensures a linear relationship
@@ -5,6 +5,7 @@
 package moreland
 
 import (
+	"encoding/json"
 	"fmt"
 	"image/color"
 	"math"
@@ -66,6 +67,55 @@ func newSmoothDiverging(start, end msh, convergeM float64) palette.DivergingColo
 	}
 }
 
+// smoothDivergingData is the serialized form of a smoothDiverging.
+type smoothDivergingData struct {
+	Start, End msh
+	ConvergeM  float64
+	Alpha      float64
+	Min, Max   float64
+
+	// ConvergePoint is nil if the convergence
+	// point is not set.
+	ConvergePoint *float64
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p *smoothDiverging) MarshalJSON() ([]byte, error) {
+	d := smoothDivergingData{
+		Start:     p.start,
+		End:       p.end,
+		ConvergeM: p.convergeM,
+		Alpha:     p.alpha,
+		Min:       p.min,
+		Max:       p.max,
+	}
+	if !math.IsNaN(p.convergePoint) {
+		d.ConvergePoint = &p.convergePoint
+	}
+	return json.Marshal(d)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *smoothDiverging) UnmarshalJSON(data []byte) error {
+	var d smoothDivergingData
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	*p = smoothDiverging{
+		start:         d.Start,
+		end:           d.End,
+		convergeM:     d.ConvergeM,
+		alpha:         d.Alpha,
+		min:           d.Min,
+		max:           d.Max,
+		convergePoint: math.NaN(),
+	}
+	if d.ConvergePoint != nil {
+		p.convergePoint = *d.ConvergePoint
+	}
+	return nil
+}
+
 // At implements the palette.ColorMap interface.
 func (p *smoothDiverging) At(v float64) (color.Color, error) {
 	if err := checkRange(p.min, p.max, v); err != nil {
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 
 	"github.com/hneemann/nplot/vg"
 	"github.com/hneemann/nplot/vg/draw"
@@ -51,6 +52,28 @@ type Plot struct {
 	// plotters are drawn by calling their Plot method
 	// after the axes are drawn.
 	plotters []Plotter
+
+	// layout holds the *layout of the latest
+	// Redraw, reused by the next Redraw.
+	layout atomic.Value
+}
+
+// layout is the placement of the axes, the data and
+// the legend of a plot drawn on a canvas.
+type layout struct {
+	key            layoutKey
+	x, y           vg.Rectangle
+	data, legend   vg.Rectangle
+	xMarks, yMarks []Tick
+}
+
+// layoutKey holds the values a layout depends on
+// that commonly change between drawings of a plot.
+type layoutKey struct {
+	canvas                 vg.Rectangle
+	title                  string
+	xmin, xmax, ymin, ymax float64
+	plotters               int
 }
 
 // Plotter is an interface that wraps the Plot method.
@@ -69,6 +92,14 @@ type DataRanger interface {
 	DataRange() (xmin, xmax, ymin, ymax float64)
 }
 
+// AxesHider wraps the HidesAxes method.
+type AxesHider interface {
+	// HidesAxes returns whether the plotter is drawn
+	// independently of the axes, such as a pie chart,
+	// so that the axes of its plot should be hidden.
+	HidesAxes() bool
+}
+
 const (
 	vertical   = true
 	horizontal = false
@@ -113,7 +144,9 @@ func New() (*Plot, error) {
 // If the plotters implements DataRanger then the
 // minimum and maximum values of the X and Y
 // axes are changed if necessary to fit the range of
-// the data.
+// the data.  If a plotter implements AxesHider and
+// HidesAxes returns true, the axes are hidden by
+// HideAxes.
 //
 // When drawing the nplot, Plotters are drawn in the
 // order in which they were added to the nplot.
@@ -126,6 +159,9 @@ func (p *Plot) Add(ps ...Plotter) {
 			p.Y.Min = math.Min(p.Y.Min, ymin)
 			p.Y.Max = math.Max(p.Y.Max, ymax)
 		}
+		if h, ok := d.(AxesHider); ok && h.HidesAxes() {
+			p.HideAxes()
+		}
 	}
 
 	p.plotters = append(p.plotters, ps...)
@@ -138,34 +174,130 @@ func (p *Plot) Add(ps ...Plotter) {
 // GlyphBoxer interface will have their GlyphBoxes
 // taken into account when padding the nplot so that
 // none of their glyphs are clipped.
+//
+// Draw does not modify the plot.  The plotters are drawn
+// with a copy of the plot holding the ranges of the axes
+// as drawn.  A plot may be drawn by several goroutines at
+// the same time, as long as it is not modified meanwhile.
 func (p *Plot) Draw(c draw.Canvas) {
+	p.draw(c, false)
+}
+
+// Redraw draws a nplot to a draw.Canvas like Draw, but
+// reuses the placement of the axes, their tick marks and
+// the data area of the previous Redraw if the size of the
+// canvas, the title and the ranges of the axes did not
+// change.  Redraw is intended for plots redrawn frequently
+// with changing data, such as plots of a plotter.Ring.
+// Changes of styles, tick markers and glyphs since the
+// previous drawing are not taken into account.
+func (p *Plot) Redraw(c draw.Canvas) {
+	p.draw(c, true)
+}
+
+// draw draws the plot.  If reuse is true, the layout of
+// the previous call with reuse is reused if it is still
+// valid, and the layout is stored for the next call.
+func (p *Plot) draw(c draw.Canvas, reuse bool) {
+	key := layoutKey{canvas: c.Rectangle, title: p.Title.Text}
 	if p.BackgroundColor != nil {
 		c.SetColor(p.BackgroundColor)
 		c.Fill(c.Rectangle.Path())
 	}
 	if p.Title.Text != "" {
 		c.FillText(p.Title.TextStyle, vg.Point{X: c.Center().X, Y: c.Max.Y}, p.Title.Text)
-		c.Max.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
+		fnt := p.Title.SelectedFont()
+		c.Max.Y -= p.Title.Height(p.Title.Text) - fnt.Extents().Descent
 		c.Max.Y -= p.Title.Padding
 	}
 
-	p.X.sanitizeRange()
-	x := horizontalAxis{p.X}
-	p.Y.sanitizeRange()
-	y := verticalAxis{p.Y}
+	s := p.snapshot()
+	x := horizontalAxis{s.X}
+	y := verticalAxis{s.Y}
+
+	key.xmin, key.xmax = s.X.Min, s.X.Max
+	key.ymin, key.ymax = s.Y.Min, s.Y.Max
+	key.plotters = len(s.plotters)
+	var l *layout
+	if reuse {
+		l, _ = p.layout.Load().(*layout)
+	}
+	if l == nil || l.key != key {
+		ywidth := y.size(c)
+		xheight := x.size(c)
+		xc := padX(s, draw.Crop(c, ywidth, 0, 0, 0))
+		yc := padY(s, draw.Crop(c, 0, 0, xheight, 0))
+		l = &layout{
+			key:    key,
+			x:      xc.Rectangle,
+			y:      yc.Rectangle,
+			data:   padY(s, padX(s, draw.Crop(c, ywidth, 0, xheight, 0))).Rectangle,
+			legend: draw.Crop(c, ywidth, 0, xheight, 0).Rectangle,
+			xMarks: x.CreateHorizontalMarks(xc),
+			yMarks: y.CreateVerticalMarks(yc),
+		}
+		if reuse {
+			p.layout.Store(l)
+		}
+	}
+
+	at := func(r vg.Rectangle) draw.Canvas {
+		return draw.Canvas{Canvas: c.Canvas, Rectangle: r}
+	}
+	x.draw(at(l.x), l.xMarks)
+	y.draw(at(l.y), l.yMarks)
 
-	ywidth := y.size(c)
+	dataC := at(l.data)
+	for _, data := range s.plotters {
+		data.Plot(dataC, s)
+	}
 
-	xheight := x.size(c)
-	x.draw(padX(p, draw.Crop(c, ywidth, 0, 0, 0)))
-	y.draw(padY(p, draw.Crop(c, 0, 0, xheight, 0)))
+	s.Legend.Draw(at(l.legend))
+}
 
-	dataC := padY(p, padX(p, draw.Crop(c, ywidth, 0, xheight, 0)))
-	for _, data := range p.plotters {
-		data.Plot(dataC, p)
+// clone returns a copy of the plot
+// without the layout of its drawings.
+func (p *Plot) clone() *Plot {
+	return &Plot{
+		Title:           p.Title,
+		BackgroundColor: p.BackgroundColor,
+		X:               p.X,
+		Y:               p.Y,
+		Legend:          p.Legend,
+		plotters:        p.plotters,
 	}
+}
+
+// snapshot returns a copy of the plot with the
+// ranges of the axes as drawn.
+func (p *Plot) snapshot() *Plot {
+	s := p.clone()
+	s.scroll()
+	s.X.sanitizeRange()
+	s.Y.sanitizeRange()
+	return s
+}
 
-	p.Legend.Draw(draw.Crop(c, ywidth, 0, xheight, 0))
+// scroll moves the axes with a positive Window
+// to the end of the data range of the plotters.
+func (p *Plot) scroll() {
+	if !(p.X.Window > 0) && !(p.Y.Window > 0) {
+		return
+	}
+	xmax, ymax := math.Inf(-1), math.Inf(-1)
+	for _, d := range p.plotters {
+		if r, ok := d.(DataRanger); ok {
+			_, x, _, y := r.DataRange()
+			xmax = math.Max(xmax, x)
+			ymax = math.Max(ymax, y)
+		}
+	}
+	if p.X.Window > 0 && !math.IsInf(xmax, 0) {
+		p.X.Min, p.X.Max = xmax-p.X.Window, xmax
+	}
+	if p.Y.Window > 0 && !math.IsInf(ymax, 0) {
+		p.Y.Min, p.Y.Max = ymax-p.Y.Window, ymax
+	}
 }
 
 // DataCanvas returns a new draw.Canvas that
@@ -173,21 +305,22 @@ func (p *Plot) Draw(c draw.Canvas) {
 // the nplot data will be drawn.
 func (p *Plot) DataCanvas(da draw.Canvas) draw.Canvas {
 	if p.Title.Text != "" {
-		da.Max.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
+		fnt := p.Title.SelectedFont()
+		da.Max.Y -= p.Title.Height(p.Title.Text) - fnt.Extents().Descent
 		da.Max.Y -= p.Title.Padding
 	}
-	p.X.sanitizeRange()
-	x := horizontalAxis{p.X}
-	p.Y.sanitizeRange()
-	y := verticalAxis{p.Y}
-	return padY(p, padX(p, draw.Crop(da, y.size(da), 0, x.size(da), 0)))
+	s := p.snapshot()
+	x := horizontalAxis{s.X}
+	y := verticalAxis{s.Y}
+	return padY(s, padX(s, draw.Crop(da, y.size(da), 0, x.size(da), 0)))
 }
 
 // DrawGlyphBoxes draws red outlines around the nplot's
 // GlyphBoxes.  This is intended for debugging.
 func (p *Plot) DrawGlyphBoxes(c *draw.Canvas) {
 	c.SetColor(color.RGBA{R: 255, A: 255})
-	for _, b := range p.GlyphBoxes(p) {
+	s := p.snapshot()
+	for _, b := range s.GlyphBoxes(s) {
 		b.Rectangle.Min.X += c.X(b.X)
 		b.Rectangle.Min.Y += c.Y(b.Y)
 		c.Stroke(b.Rectangle.Path())
@@ -435,13 +568,19 @@ func (p *Plot) NominalY(names ...string) {
 }
 
 // WriterTo returns an io.WriterTo that will write the nplot as
-// the specified image format.
+// the specified image format using the given options.
+//
+// Supported formats are the formats registered by
+// draw.RegisterFormat, by default:
 //
-// Supported formats are:
+//  eps, html, jpg|jpeg, js, pdf, png, svg, tex and tif|tiff,
+//  and for terminals ansi, sixel and txt.
 //
-//  eps, jpg|jpeg, pdf, png, svg, and tif|tiff.
-func (p *Plot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
-	c, err := draw.NewFormattedCanvas(w, h, format)
+// The title of the plot is used as the title of documents,
+// unless it is set by draw.WithTitle.
+func (p *Plot) WriterTo(w, h vg.Length, format string, opts ...draw.FormatOption) (io.WriterTo, error) {
+	opts = append([]draw.FormatOption{draw.WithTitle(p.Title.Text)}, opts...)
+	c, err := draw.NewFormattedCanvas(w, h, format, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -449,13 +588,15 @@ func (p *Plot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
 	return c, nil
 }
 
-// Save saves the nplot to an image file.  The file format is determined
-// by the extension.
+// Save saves the nplot to an image file using the given options.
+// The file format is determined by the extension.
 //
-// Supported extensions are:
+// Supported extensions are the format names registered by
+// draw.RegisterFormat, by default:
 //
-//  .eps, .jpg, .jpeg, .pdf, .png, .svg, .tif and .tiff.
-func (p *Plot) Save(w, h vg.Length, file string) (err error) {
+//  .eps, .html, .jpg, .jpeg, .js, .pdf, .png, .svg, .tex,
+//  .tif, .tiff, .ansi, .sixel and .txt.
+func (p *Plot) Save(w, h vg.Length, file string, opts ...draw.FormatOption) (err error) {
 	f, err := os.Create(file)
 	if err != nil {
 		return err
@@ -471,7 +612,7 @@ func (p *Plot) Save(w, h vg.Length, file string) (err error) {
 	if len(format) != 0 {
 		format = format[1:]
 	}
-	c, err := p.WriterTo(w, h, format)
+	c, err := p.WriterTo(w, h, format, opts...)
 	if err != nil {
 		return err
 	}
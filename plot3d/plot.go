@@ -0,0 +1,321 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package plot3d provides three-dimensional plots of surfaces,
+// wireframes, points and lines, which are projected onto the
+// two-dimensional canvases of the vg package.
+//
+// Plot is the basic type of a three-dimensional plot, holding the
+// title, the axes and the direction of view.  Types implementing the
+// Plotter interface add their faces, lines and glyphs to the Scene
+// of a plot, which draws them from the back to the front.
+package plot3d // import "github.com/hneemann/nplot/plot3d"
+
+import (
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// Plot is a three-dimensional plot.  The data is drawn
+// inside a box spanned by the ranges of the three axes,
+// which is scaled to a cube and seen from the direction
+// given by Azimuth and Elevation.
+type Plot struct {
+	Title struct {
+		// Text is the text of the plot title.  If
+		// Text is the empty string then the plot
+		// will not have a title.
+		Text string
+
+		// Padding is the amount of padding
+		// between the bottom of the title and
+		// the top of the plot.
+		Padding vg.Length
+
+		draw.TextStyle
+	}
+
+	// BackgroundColor is the background color of the plot.
+	// The default is White.
+	BackgroundColor color.Color
+
+	// X, Y and Z are the axes of the plot.
+	X, Y, Z Axis
+
+	// Azimuth is the angle in degrees by which the
+	// box is turned counter-clockwise around the Z
+	// axis.  At an azimuth of zero the X axis points
+	// to the right and the Y axis away from the viewer.
+	Azimuth float64
+
+	// Elevation is the angle in degrees at which the
+	// box is seen from above the XY plane.
+	Elevation float64
+
+	// BoxStyle is the style of the edges of the box.
+	// The edges are not drawn if its width is zero.
+	BoxStyle draw.LineStyle
+
+	// plotters are the plotters adding
+	// their data to the scene.
+	plotters []Plotter
+}
+
+// Axis is an axis of a three-dimensional plot.
+// The data is scaled linearly along the axis.
+type Axis struct {
+	// Min and Max are the minimum and maximum data
+	// values represented by the axis.
+	Min, Max float64
+
+	Label struct {
+		// Text is the axis label string.
+		Text string
+
+		draw.TextStyle
+	}
+
+	// Padding is the distance between the tick
+	// labels and the axis label.
+	Padding vg.Length
+
+	Tick struct {
+		// Label is the TextStyle on the tick labels.
+		Label draw.TextStyle
+
+		// LineStyle is the LineStyle of the tick lines.
+		draw.LineStyle
+
+		// Length is the length of a major tick mark.
+		// Minor tick marks are half of the length
+		// of the major tick marks.
+		Length vg.Length
+
+		// Marker returns the tick marks.  Any tick
+		// marks returned by the Marker function that
+		// are not in range of the axis are not drawn.
+		Marker nplot.Ticker
+	}
+}
+
+// Plotter is an interface that wraps the Plot method.
+type Plotter interface {
+	// Plot adds the data to a Scene.
+	Plot(*Scene)
+}
+
+// DataRanger wraps the DataRange method.
+type DataRanger interface {
+	// DataRange returns the range of X, Y and Z values.
+	DataRange() (xmin, xmax, ymin, ymax, zmin, zmax float64)
+}
+
+// New returns a new three-dimensional plot with
+// some reasonable default settings.
+func New() (*Plot, error) {
+	titleFont, err := vg.MakeFont(nplot.DefaultFont, 12)
+	if err != nil {
+		return nil, err
+	}
+	var axes [3]Axis
+	for i := range axes {
+		a, err := makeAxis()
+		if err != nil {
+			return nil, err
+		}
+		axes[i] = a
+	}
+	p := &Plot{
+		BackgroundColor: color.White,
+		X:               axes[0],
+		Y:               axes[1],
+		Z:               axes[2],
+		Azimuth:         -30,
+		Elevation:       30,
+		BoxStyle: draw.LineStyle{
+			Color: color.Gray{Y: 128},
+			Width: vg.Points(0.5),
+		},
+	}
+	p.Title.TextStyle = draw.TextStyle{
+		Color:  color.Black,
+		Font:   titleFont,
+		XAlign: draw.XCenter,
+		YAlign: draw.YTop,
+	}
+	return p, nil
+}
+
+// makeAxis returns a default Axis.
+func makeAxis() (Axis, error) {
+	labelFont, err := vg.MakeFont(nplot.DefaultFont, vg.Points(12))
+	if err != nil {
+		return Axis{}, err
+	}
+	tickFont, err := vg.MakeFont(nplot.DefaultFont, vg.Points(10))
+	if err != nil {
+		return Axis{}, err
+	}
+	a := Axis{
+		Min:     math.Inf(1),
+		Max:     math.Inf(-1),
+		Padding: vg.Points(5),
+	}
+	a.Label.TextStyle = draw.TextStyle{
+		Color: color.Black,
+		Font:  labelFont,
+	}
+	a.Tick.Label = draw.TextStyle{
+		Color: color.Black,
+		Font:  tickFont,
+	}
+	a.Tick.LineStyle = draw.LineStyle{
+		Color: color.Black,
+		Width: vg.Points(0.5),
+	}
+	a.Tick.Length = vg.Points(6)
+	a.Tick.Marker = nplot.DefaultTicks{}
+	return a, nil
+}
+
+// sanitizeRange ensures that the range of the
+// axis is not empty, as done by nplot.Axis.
+func (a *Axis) sanitizeRange() {
+	if math.IsInf(a.Min, 0) {
+		a.Min = 0
+	}
+	if math.IsInf(a.Max, 0) {
+		a.Max = 0
+	}
+	if a.Min > a.Max {
+		a.Min, a.Max = a.Max, a.Min
+	}
+	if a.Min == a.Max {
+		a.Min--
+		a.Max++
+	}
+}
+
+// Add adds Plotters to the plot.
+//
+// If the plotters implement DataRanger then the
+// minimum and maximum values of the axes are
+// changed if necessary to fit the range of the data.
+func (p *Plot) Add(ps ...Plotter) {
+	for _, d := range ps {
+		if r, ok := d.(DataRanger); ok {
+			xmin, xmax, ymin, ymax, zmin, zmax := r.DataRange()
+			p.X.Min = math.Min(p.X.Min, xmin)
+			p.X.Max = math.Max(p.X.Max, xmax)
+			p.Y.Min = math.Min(p.Y.Min, ymin)
+			p.Y.Max = math.Max(p.Y.Max, ymax)
+			p.Z.Min = math.Min(p.Z.Min, zmin)
+			p.Z.Max = math.Max(p.Z.Max, zmax)
+		}
+	}
+	p.plotters = append(p.plotters, ps...)
+}
+
+// Draw draws the plot to a draw.Canvas.
+//
+// The edges of the box behind the data, which are all edges
+// but the ones meeting at the corner nearest to the viewer,
+// are drawn first.  The faces, lines and glyphs added by the
+// plotters are drawn on top of them in the order of their
+// depth, from the back to the front.  The axes are drawn
+// last.  Draw does not modify the plot.
+func (p *Plot) Draw(c draw.Canvas) {
+	if p.BackgroundColor != nil {
+		c.SetColor(p.BackgroundColor)
+		c.Fill(c.Rectangle.Path())
+	}
+	if p.Title.Text != "" {
+		c.FillText(p.Title.TextStyle, vg.Point{X: c.Center().X, Y: c.Max.Y}, p.Title.Text)
+		fnt := p.Title.SelectedFont()
+		c.Max.Y -= p.Title.Height(p.Title.Text) - fnt.Extents().Descent
+		c.Max.Y -= p.Title.Padding
+	}
+
+	axes := [3]Axis{p.X, p.Y, p.Z}
+	for i := range axes {
+		axes[i].sanitizeRange()
+	}
+	s := newScene(c, axes, p.Azimuth, p.Elevation, axesMargin(axes))
+	s.box(p.BoxStyle)
+	for _, d := range p.plotters {
+		d.Plot(s)
+	}
+	s.draw()
+	for i := range axes {
+		s.axis(i, axes[i])
+	}
+}
+
+// axesMargin returns the space to leave around
+// the box for the tick marks and the labels.
+func axesMargin(axes [3]Axis) vg.Length {
+	var m vg.Length
+	for _, a := range axes {
+		fnt := a.Tick.Label.SelectedFont()
+		w := a.Tick.Length + fnt.Width("0000")
+		if a.Label.Text != "" {
+			w += a.Padding + a.Label.Height(a.Label.Text)
+		}
+		if w > m {
+			m = w
+		}
+	}
+	return m
+}
+
+// WriterTo returns an io.WriterTo that will write the plot as
+// the specified image format.
+//
+// Supported formats are the format names registered by
+// draw.RegisterFormat.  The title of the plot is used as the
+// title of documents, unless it is set by draw.WithTitle.
+func (p *Plot) WriterTo(w, h vg.Length, format string, opts ...draw.FormatOption) (io.WriterTo, error) {
+	opts = append([]draw.FormatOption{draw.WithTitle(p.Title.Text)}, opts...)
+	c, err := draw.NewFormattedCanvas(w, h, format, opts...)
+	if err != nil {
+		return nil, err
+	}
+	p.Draw(draw.New(c))
+	return c, nil
+}
+
+// Save saves the plot to an image file using the given options.
+// The file format is determined by the extension, as by
+// nplot.Plot.Save.
+func (p *Plot) Save(w, h vg.Length, file string, opts ...draw.FormatOption) (err error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := f.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+
+	format := strings.ToLower(filepath.Ext(file))
+	if len(format) != 0 {
+		format = format[1:]
+	}
+	c, err := p.WriterTo(w, h, format, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = c.WriteTo(f)
+	return err
+}
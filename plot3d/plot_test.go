@@ -0,0 +1,179 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot3d
+
+import (
+	"bytes"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/hneemann/nplot/plotter"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+	"github.com/hneemann/nplot/vg/recorder"
+)
+
+// sincGrid is a grid of a radial sinc function.
+type sincGrid struct{ n int }
+
+func (g sincGrid) Dims() (c, r int)   { return g.n, g.n }
+func (g sincGrid) X(c int) float64    { return 10 * (2*float64(c)/float64(g.n-1) - 1) }
+func (g sincGrid) Y(r int) float64    { return 10 * (2*float64(r)/float64(g.n-1) - 1) }
+func (g sincGrid) Z(c, r int) float64 { return sinc(math.Hypot(g.X(c), g.Y(r))) }
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(x) / x
+}
+
+func newPlot(t *testing.T) *Plot {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestSceneProject(t *testing.T) {
+	p := newPlot(t)
+	axes := [3]Axis{p.X, p.Y, p.Z}
+	for i := range axes {
+		axes[i].Min, axes[i].Max = 0, 2
+	}
+	c := draw.NewCanvas(new(recorder.Canvas), 100, 100)
+
+	// Seen from the front, X points to the right and Z upwards.
+	s := newScene(c, axes, 0, 0, 0)
+	for _, test := range []struct {
+		x, y, z float64
+		want    vg.Point
+	}{
+		{x: 0, y: 0, z: 0, want: vg.Point{X: 0, Y: 0}},
+		{x: 2, y: 0, z: 2, want: vg.Point{X: 100, Y: 100}},
+		{x: 1, y: 2, z: 1, want: vg.Point{X: 50, Y: 50}},
+	} {
+		if got := s.Project(test.x, test.y, test.z); !near(got, test.want) {
+			t.Errorf("unexpected front projection of (%v, %v, %v): got:%v want:%v",
+				test.x, test.y, test.z, got, test.want)
+		}
+	}
+
+	// Seen from above, Y points upwards.
+	s = newScene(c, axes, 0, 90, 0)
+	if got, want := s.Project(0, 2, 0), (vg.Point{X: 0, Y: 100}); !near(got, want) {
+		t.Errorf("unexpected top projection: got:%v want:%v", got, want)
+	}
+	if _, _, d := s.view(s.norm(1, 1, 2)); d >= 0 {
+		t.Errorf("unexpected depth of the top: got:%v want:<0", d)
+	}
+
+	// Turned by 90°, the Y axis points to the left.
+	s = newScene(c, axes, 90, 0, 0)
+	if got, want := s.Project(0, 2, 0), (vg.Point{X: 0, Y: 0}); !near(got, want) {
+		t.Errorf("unexpected turned projection: got:%v want:%v", got, want)
+	}
+}
+
+func near(p, q vg.Point) bool {
+	return math.Abs(float64(p.X-q.X)) < 1e-9 && math.Abs(float64(p.Y-q.Y)) < 1e-9
+}
+
+func TestSceneDepthOrder(t *testing.T) {
+	p := newPlot(t)
+	axes := [3]Axis{p.X, p.Y, p.Z}
+	for i := range axes {
+		axes[i].Min, axes[i].Max = 0, 1
+	}
+	var rec recorder.Canvas
+	s := newScene(draw.NewCanvas(&rec, 100, 100), axes, 0, 0, 0)
+
+	var (
+		red   = color.NRGBA{R: 255, A: 255}
+		green = color.NRGBA{G: 255, A: 255}
+		blue  = color.NRGBA{B: 255, A: 255}
+	)
+	square := func(y float64) []plotter.XYZ {
+		return []plotter.XYZ{{X: 0, Y: y, Z: 0}, {X: 1, Y: y, Z: 0}, {X: 1, Y: y, Z: 1}, {X: 0, Y: y, Z: 1}}
+	}
+	s.Face(square(0.2), red, draw.LineStyle{})
+	s.Glyph(plotter.XYZ{X: 0.5, Y: 0.5, Z: 0.5}, draw.GlyphStyle{Color: green, Radius: 1, Shape: draw.CircleGlyph{}})
+	s.Face(square(1), blue, draw.LineStyle{})
+	s.Face(square(math.NaN()), blue, draw.LineStyle{})
+	s.draw()
+
+	var got []color.Color
+	for _, a := range rec.Actions {
+		if a, ok := a.(*recorder.SetColor); ok {
+			got = append(got, a.Color)
+		}
+	}
+	want := []color.Color{blue, green, red}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected number of colors: got:%v want:%v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("unexpected color %d: got:%v want:%v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSurface(t *testing.T) {
+	g := sincGrid{n: 5}
+	s := NewSurface(g, nil)
+	s.Color = color.White
+	xmin, xmax, ymin, ymax, zmin, zmax := s.DataRange()
+	if xmin != -10 || xmax != 10 || ymin != -10 || ymax != 10 || zmin != sinc(5) || zmax != 1 {
+		t.Errorf("unexpected data range: %v %v %v %v %v %v", xmin, xmax, ymin, ymax, zmin, zmax)
+	}
+
+	p := newPlot(t)
+	p.Add(s)
+	axes := [3]Axis{p.X, p.Y, p.Z}
+	sc := newScene(draw.NewCanvas(new(recorder.Canvas), 100, 100), axes, p.Azimuth, p.Elevation, 0)
+	s.Plot(sc)
+	if len(sc.prims) != 16 {
+		t.Errorf("unexpected number of faces: got:%d want:16", len(sc.prims))
+	}
+
+	sc.prims = nil
+	NewWireframe(g).Plot(sc)
+	if len(sc.prims) != 2*5*4 {
+		t.Errorf("unexpected number of wireframe segments: got:%d want:40", len(sc.prims))
+	}
+}
+
+func TestPlotFormats(t *testing.T) {
+	p := newPlot(t)
+	p.Title.Text = "sinc"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+	p.Z.Label.Text = "Z"
+	p.Add(NewSurface(sincGrid{n: 21}, nil))
+	l, err := NewLine(plotter.XYZs{{X: -10, Y: -10, Z: 0}, {X: 10, Y: 10, Z: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sc, err := NewScatter(plotter.XYZs{{X: 0, Y: 0, Z: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l, sc)
+
+	for _, format := range []string{"eps", "png", "svg", "tex", "txt"} {
+		w, err := p.WriterTo(4*vg.Inch, 3*vg.Inch, format)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", format, err)
+			continue
+		}
+		var buf bytes.Buffer
+		if _, err := w.WriteTo(&buf); err != nil || buf.Len() == 0 {
+			t.Errorf("unexpected output for %s: %d bytes, %v", format, buf.Len(), err)
+		}
+	}
+}
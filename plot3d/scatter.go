@@ -0,0 +1,94 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot3d
+
+import (
+	"math"
+
+	"github.com/hneemann/nplot/plotter"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// Scatter implements the Plotter interface, drawing
+// a glyph for each of a set of points.
+type Scatter struct {
+	// XYZs is a copy of the points for this scatter.
+	plotter.XYZs
+
+	// GlyphStyle is the style of the glyphs.
+	draw.GlyphStyle
+}
+
+// NewScatter returns a Scatter that uses the
+// default glyph style.
+func NewScatter(xyzs plotter.XYZer) (*Scatter, error) {
+	data, err := plotter.CopyXYZs(xyzs)
+	if err != nil {
+		return nil, err
+	}
+	return &Scatter{
+		XYZs:       data,
+		GlyphStyle: plotter.DefaultGlyphStyle,
+	}, nil
+}
+
+// Plot implements the Plot method of the Plotter interface.
+func (pts *Scatter) Plot(sc *Scene) {
+	for _, p := range pts.XYZs {
+		sc.Glyph(p, pts.GlyphStyle)
+	}
+}
+
+// DataRange implements the DataRange method
+// of the DataRanger interface.
+func (pts *Scatter) DataRange() (xmin, xmax, ymin, ymax, zmin, zmax float64) {
+	return xyzRange(pts.XYZs)
+}
+
+// Line implements the Plotter interface,
+// drawing a line through a set of points.
+type Line struct {
+	// XYZs is a copy of the points for this line.
+	plotter.XYZs
+
+	// LineStyle is the style of the line.
+	draw.LineStyle
+}
+
+// NewLine returns a Line that uses the
+// default line style.
+func NewLine(xyzs plotter.XYZer) (*Line, error) {
+	data, err := plotter.CopyXYZs(xyzs)
+	if err != nil {
+		return nil, err
+	}
+	return &Line{
+		XYZs:      data,
+		LineStyle: plotter.DefaultLineStyle,
+	}, nil
+}
+
+// Plot implements the Plot method of the Plotter interface.
+func (pts *Line) Plot(sc *Scene) {
+	sc.Line(pts.XYZs, pts.LineStyle)
+}
+
+// DataRange implements the DataRange method
+// of the DataRanger interface.
+func (pts *Line) DataRange() (xmin, xmax, ymin, ymax, zmin, zmax float64) {
+	return xyzRange(pts.XYZs)
+}
+
+// xyzRange returns the range of the points xyzs.
+func xyzRange(xyzs plotter.XYZs) (xmin, xmax, ymin, ymax, zmin, zmax float64) {
+	xmin, ymin, zmin = math.Inf(1), math.Inf(1), math.Inf(1)
+	xmax, ymax, zmax = math.Inf(-1), math.Inf(-1), math.Inf(-1)
+	for _, p := range xyzs {
+		xmin, xmax = math.Min(xmin, p.X), math.Max(xmax, p.X)
+		ymin, ymax = math.Min(ymin, p.Y), math.Max(ymax, p.Y)
+		zmin, zmax = math.Min(zmin, p.Z), math.Max(zmax, p.Z)
+	}
+	return xmin, xmax, ymin, ymax, zmin, zmax
+}
@@ -0,0 +1,331 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot3d
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/hneemann/nplot/plotter"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// Scene collects the faces, lines and glyphs of the plotters of a
+// Plot and draws them in the order of their depth, from the back to
+// the front, so that nearer faces cover the ones behind them.  The
+// depth of a face or line segment is the mean depth of its points.
+type Scene struct {
+	c    draw.Canvas
+	axes [3]Axis
+
+	cosA, sinA float64
+	cosE, sinE float64
+
+	// scale is the size of the unit cube on the canvas
+	// and origin is the canvas point of its center.
+	scale  vg.Length
+	origin vg.Point
+
+	prims []primitive
+}
+
+// primitive is a face, a line segment or a glyph of a Scene.
+type primitive struct {
+	depth float64
+	pts   []vg.Point
+	fill  color.Color
+	line  draw.LineStyle
+	glyph *draw.GlyphStyle
+}
+
+// newScene returns a Scene showing the ranges of the axes on c, seen
+// from the given azimuth and elevation in degrees.  The box is scaled
+// to fit into c, leaving margin around it.
+func newScene(c draw.Canvas, axes [3]Axis, azimuth, elevation float64, margin vg.Length) *Scene {
+	a := azimuth * math.Pi / 180
+	e := elevation * math.Pi / 180
+	s := &Scene{
+		c:    c,
+		axes: axes,
+		cosA: math.Cos(a),
+		sinA: math.Sin(a),
+		cosE: math.Cos(e),
+		sinE: math.Sin(e),
+	}
+
+	umin, umax := math.Inf(1), math.Inf(-1)
+	vmin, vmax := math.Inf(1), math.Inf(-1)
+	for _, n := range corners() {
+		u, v, _ := s.view(n)
+		umin, umax = math.Min(umin, u), math.Max(umax, u)
+		vmin, vmax = math.Min(vmin, v), math.Max(vmax, v)
+	}
+	r := draw.Crop(c, margin, -margin, margin, -margin)
+	s.scale = vg.Length(math.Min(
+		float64(r.Size().X)/(umax-umin),
+		float64(r.Size().Y)/(vmax-vmin),
+	))
+	if s.scale < 0 {
+		s.scale = 0
+	}
+	center := r.Center()
+	s.origin = vg.Point{
+		X: center.X - vg.Length((umin+umax)/2)*s.scale,
+		Y: center.Y - vg.Length((vmin+vmax)/2)*s.scale,
+	}
+	return s
+}
+
+// corners returns the corners of the unit cube
+// centered at the origin.
+func corners() [8][3]float64 {
+	var cs [8][3]float64
+	for i := range cs {
+		for d := 0; d < 3; d++ {
+			cs[i][d] = float64(i>>uint(d)&1) - 0.5
+		}
+	}
+	return cs
+}
+
+// norm returns the data point (x, y, z) in the coordinates of
+// the unit cube centered at the origin, spanning the axes.
+func (s *Scene) norm(x, y, z float64) [3]float64 {
+	var n [3]float64
+	for d, v := range [3]float64{x, y, z} {
+		a := s.axes[d]
+		n[d] = (v-a.Min)/(a.Max-a.Min) - 0.5
+	}
+	return n
+}
+
+// view returns the horizontal and vertical view coordinates of
+// the normalized point n, and its depth, which increases away
+// from the viewer.
+func (s *Scene) view(n [3]float64) (u, v, depth float64) {
+	u = n[0]*s.cosA - n[1]*s.sinA
+	w := n[0]*s.sinA + n[1]*s.cosA
+	v = n[2]*s.cosE + w*s.sinE
+	depth = w*s.cosE - n[2]*s.sinE
+	return u, v, depth
+}
+
+// point returns the canvas point of the normalized point n
+// and its depth.
+func (s *Scene) point(n [3]float64) (vg.Point, float64) {
+	u, v, depth := s.view(n)
+	return vg.Point{
+		X: s.origin.X + vg.Length(u)*s.scale,
+		Y: s.origin.Y + vg.Length(v)*s.scale,
+	}, depth
+}
+
+// Project returns the point of the canvas at which
+// the data point (x, y, z) is drawn.
+func (s *Scene) Project(x, y, z float64) vg.Point {
+	pt, _ := s.point(s.norm(x, y, z))
+	return pt
+}
+
+// project returns the canvas points of the data points
+// pts and their mean depth.  It returns false if any of
+// the points has a NaN coordinate.
+func (s *Scene) project(pts []plotter.XYZ) ([]vg.Point, float64, bool) {
+	cpts := make([]vg.Point, len(pts))
+	var depth float64
+	for i, p := range pts {
+		if math.IsNaN(p.X) || math.IsNaN(p.Y) || math.IsNaN(p.Z) {
+			return nil, 0, false
+		}
+		pt, d := s.point(s.norm(p.X, p.Y, p.Z))
+		cpts[i] = pt
+		depth += d / float64(len(pts))
+	}
+	return cpts, depth, true
+}
+
+// Face adds the polygon with the corners pts to the scene.
+// The polygon is filled with the color fill unless it is nil
+// and is outlined with the line style sty unless its width
+// is zero.  Faces with a NaN coordinate are ignored.
+func (s *Scene) Face(pts []plotter.XYZ, fill color.Color, sty draw.LineStyle) {
+	if len(pts) < 3 || (fill == nil && sty.Width == 0) {
+		return
+	}
+	cpts, depth, ok := s.project(pts)
+	if !ok {
+		return
+	}
+	s.prims = append(s.prims, primitive{depth: depth, pts: cpts, fill: fill, line: sty})
+}
+
+// Line adds the line through pts to the scene.  Each segment
+// of the line is sorted by its own depth.  Segments with
+// a NaN coordinate are left out.
+func (s *Scene) Line(pts []plotter.XYZ, sty draw.LineStyle) {
+	if sty.Width == 0 {
+		return
+	}
+	for i := 1; i < len(pts); i++ {
+		cpts, depth, ok := s.project(pts[i-1 : i+1])
+		if !ok {
+			continue
+		}
+		s.prims = append(s.prims, primitive{depth: depth, pts: cpts, line: sty})
+	}
+}
+
+// Glyph adds a glyph of the style sty at the point pt to the
+// scene.  Glyphs with a NaN coordinate are ignored.
+func (s *Scene) Glyph(pt plotter.XYZ, sty draw.GlyphStyle) {
+	cpts, depth, ok := s.project([]plotter.XYZ{pt})
+	if !ok {
+		return
+	}
+	s.prims = append(s.prims, primitive{depth: depth, pts: cpts, glyph: &sty})
+}
+
+// draw draws the primitives of the scene from the back to the front.
+func (s *Scene) draw() {
+	sort.SliceStable(s.prims, func(i, j int) bool {
+		return s.prims[i].depth > s.prims[j].depth
+	})
+	for _, p := range s.prims {
+		switch {
+		case p.glyph != nil:
+			s.c.DrawGlyph(*p.glyph, p.pts[0])
+		case len(p.pts) == 2:
+			s.c.StrokeLines(p.line, p.pts)
+		default:
+			if p.fill != nil {
+				s.c.FillPolygon(p.fill, p.pts)
+			}
+			if p.line.Width != 0 {
+				s.c.StrokeLines(p.line, append(p.pts, p.pts[0]))
+			}
+		}
+	}
+}
+
+// box draws the edges of the box behind the data, which are
+// all edges but the ones meeting at the corner nearest to the
+// viewer.
+func (s *Scene) box(sty draw.LineStyle) {
+	if sty.Width == 0 {
+		return
+	}
+	cs := corners()
+	near, nearest := 0, math.Inf(1)
+	for i, n := range cs {
+		if _, _, d := s.view(n); d < nearest {
+			near, nearest = i, d
+		}
+	}
+	for i := range cs {
+		for d := 0; d < 3; d++ {
+			j := i | 1<<uint(d)
+			if j == i || i == near || j == near {
+				continue
+			}
+			p, _ := s.point(cs[i])
+			q, _ := s.point(cs[j])
+			s.c.StrokeLines(sty, []vg.Point{p, q})
+		}
+	}
+}
+
+// axis draws the axis of the dimension d along the lowest edge of
+// the box in its direction, with tick marks and labels pointing
+// away from the box.
+func (s *Scene) axis(d int, a Axis) {
+	// The other two dimensions choose one of
+	// the four edges along the dimension d.
+	o1, o2 := (d+1)%3, (d+2)%3
+	var edge [3]float64
+	lowest := math.Inf(1)
+	for _, e1 := range []float64{-0.5, 0.5} {
+		for _, e2 := range []float64{-0.5, 0.5} {
+			var n [3]float64
+			n[o1], n[o2] = e1, e2
+			u, v, depth := s.view(n)
+			if d == 2 {
+				// The Z axis is drawn on the left.
+				v = u
+			}
+			if v < lowest-1e-9 || (v < lowest+1e-9 && depth < 0) {
+				lowest = v
+				edge = n
+			}
+		}
+	}
+
+	// out is the direction of the ticks in the normalized
+	// coordinates, away from the box but parallel to the
+	// bottom of the box for the X and Y axes.
+	out := edge
+	if d != 2 {
+		out[2] = 0
+	}
+	out[d] = 0
+	at := func(f float64) (vg.Point, vg.Point) {
+		n := edge
+		n[d] = f - 0.5
+		p, _ := s.point(n)
+		for i := range n {
+			n[i] += out[i]
+		}
+		q, _ := s.point(n)
+		dir := q.Sub(p)
+		l := math.Hypot(float64(dir.X), float64(dir.Y))
+		if l < 1e-9 {
+			return p, vg.Point{Y: -1}
+		}
+		return p, dir.Scale(vg.Length(1 / l))
+	}
+
+	start, dir := at(0)
+	end, _ := at(1)
+	s.c.StrokeLines(a.Tick.LineStyle, []vg.Point{start, end})
+
+	align := func(sty draw.TextStyle) draw.TextStyle {
+		sty.XAlign = draw.XAlignment(-0.5 * (1 - float64(dir.X)))
+		sty.YAlign = draw.YAlignment(-0.5 * (1 - float64(dir.Y)))
+		return sty
+	}
+	tickSty := align(a.Tick.Label)
+	edgeLen := vg.Length(math.Hypot(float64(end.X-start.X), float64(end.Y-start.Y)))
+	fnt := a.Tick.Label.SelectedFont()
+	sizer := func(str string) vg.Length { return fnt.Width(str) }
+	var extent vg.Length
+	for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max, sizer, edgeLen) {
+		f := (t.Value - a.Min) / (a.Max - a.Min)
+		if f < -1e-9 || f > 1+1e-9 {
+			continue
+		}
+		p, dir := at(f)
+		l := a.Tick.Length
+		if t.IsMinor() {
+			l /= 2
+		}
+		s.c.StrokeLines(a.Tick.LineStyle, []vg.Point{p, p.Add(dir.Scale(l))})
+		if t.IsMinor() {
+			continue
+		}
+		s.c.FillText(tickSty, p.Add(dir.Scale(a.Tick.Length)), t.Label)
+		w := tickSty.Width(t.Label)
+		h := tickSty.Height(t.Label)
+		if e := vg.Length(math.Abs(float64(dir.X)))*w + vg.Length(math.Abs(float64(dir.Y)))*h; e > extent {
+			extent = e
+		}
+	}
+
+	if a.Label.Text != "" {
+		p, dir := at(0.5)
+		off := a.Tick.Length + extent + a.Padding
+		s.c.FillText(align(a.Label.TextStyle), p.Add(dir.Scale(off)), a.Label.Text)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot3d
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hneemann/nplot/palette"
+	"github.com/hneemann/nplot/plotter"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// Surface implements the Plotter interface, drawing the values of
+// a GridXYZ as a surface of faces spanned by the grid points.  Each
+// face is colored by the mean height of its corners.
+type Surface struct {
+	GridXYZ plotter.GridXYZ
+
+	// Palette is the color palette used to color
+	// the faces.  If Palette is nil, faces are
+	// filled with the color Color.
+	Palette palette.Palette
+
+	// Color is the color of the faces
+	// if Palette is nil.
+	Color color.Color
+
+	// Underflow and Overflow are the colors of the
+	// faces with heights outside the range of Min and
+	// Max.  If they are nil, these faces are not filled.
+	Underflow color.Color
+	Overflow  color.Color
+
+	// Min and Max define the height range
+	// mapped to the palette.
+	Min, Max float64
+
+	// LineStyle is the style of the edges of the
+	// faces.  The edges are not drawn if its
+	// width is zero.
+	LineStyle draw.LineStyle
+}
+
+// NewSurface creates a new surface of the values of g colored by the
+// palette p.  The edges of the faces are drawn by thin black lines.
+func NewSurface(g plotter.GridXYZ, p palette.Palette) *Surface {
+	min, max := zRange(g)
+	return &Surface{
+		GridXYZ: g,
+		Palette: p,
+		Min:     min,
+		Max:     max,
+		LineStyle: draw.LineStyle{
+			Color: color.Black,
+			Width: vg.Points(0.25),
+		},
+	}
+}
+
+// Plot implements the Plot method of the Plotter interface.
+// Faces with a NaN corner are left out.
+func (s *Surface) Plot(sc *Scene) {
+	if s.Min > s.Max {
+		panic("surface: invalid Z range: min greater than max")
+	}
+	var pal []color.Color
+	var ps float64
+	if s.Palette != nil {
+		pal = s.Palette.Colors()
+		if len(pal) == 0 {
+			panic("surface: empty palette")
+		}
+		// ps scales the palette uniformly
+		// across the range of heights.
+		ps = float64(len(pal)-1) / (s.Max - s.Min)
+		if s.Max == s.Min {
+			ps = 0
+		}
+	}
+
+	g := s.GridXYZ
+	c, r := g.Dims()
+	for i := 0; i < c-1; i++ {
+		for j := 0; j < r-1; j++ {
+			face := []plotter.XYZ{
+				{X: g.X(i), Y: g.Y(j), Z: g.Z(i, j)},
+				{X: g.X(i + 1), Y: g.Y(j), Z: g.Z(i+1, j)},
+				{X: g.X(i + 1), Y: g.Y(j + 1), Z: g.Z(i+1, j+1)},
+				{X: g.X(i), Y: g.Y(j + 1), Z: g.Z(i, j+1)},
+			}
+			var z float64
+			for _, p := range face {
+				z += p.Z / 4
+			}
+			col := s.Color
+			switch {
+			case math.IsNaN(z):
+				continue
+			case z < s.Min:
+				col = s.Underflow
+			case z > s.Max:
+				col = s.Overflow
+			case pal != nil:
+				col = pal[int((z-s.Min)*ps+0.5)] // Apply palette scaling.
+			}
+			sc.Face(face, col, s.LineStyle)
+		}
+	}
+}
+
+// DataRange implements the DataRange method
+// of the DataRanger interface.
+func (s *Surface) DataRange() (xmin, xmax, ymin, ymax, zmin, zmax float64) {
+	xmin, xmax, ymin, ymax = gridRange(s.GridXYZ)
+	zmin, zmax = zRange(s.GridXYZ)
+	return xmin, xmax, ymin, ymax, zmin, zmax
+}
+
+// Wireframe implements the Plotter interface, drawing the values
+// of a GridXYZ as lines along the rows and columns of the grid.
+// The lines do not hide each other.  A Surface with transparent
+// faces drawn in the background color hides the lines behind it.
+type Wireframe struct {
+	GridXYZ plotter.GridXYZ
+
+	// LineStyle is the style of the lines.
+	draw.LineStyle
+}
+
+// NewWireframe creates a new wireframe of the values of g.
+func NewWireframe(g plotter.GridXYZ) *Wireframe {
+	return &Wireframe{
+		GridXYZ:   g,
+		LineStyle: plotter.DefaultLineStyle,
+	}
+}
+
+// Plot implements the Plot method of the Plotter interface.
+// The lines are interrupted at NaN values.
+func (w *Wireframe) Plot(sc *Scene) {
+	g := w.GridXYZ
+	c, r := g.Dims()
+	for i := 0; i < c; i++ {
+		line := make([]plotter.XYZ, r)
+		for j := range line {
+			line[j] = plotter.XYZ{X: g.X(i), Y: g.Y(j), Z: g.Z(i, j)}
+		}
+		sc.Line(line, w.LineStyle)
+	}
+	for j := 0; j < r; j++ {
+		line := make([]plotter.XYZ, c)
+		for i := range line {
+			line[i] = plotter.XYZ{X: g.X(i), Y: g.Y(j), Z: g.Z(i, j)}
+		}
+		sc.Line(line, w.LineStyle)
+	}
+}
+
+// DataRange implements the DataRange method
+// of the DataRanger interface.
+func (w *Wireframe) DataRange() (xmin, xmax, ymin, ymax, zmin, zmax float64) {
+	xmin, xmax, ymin, ymax = gridRange(w.GridXYZ)
+	zmin, zmax = zRange(w.GridXYZ)
+	return xmin, xmax, ymin, ymax, zmin, zmax
+}
+
+// gridRange returns the range of the grid points of g.
+func gridRange(g plotter.GridXYZ) (xmin, xmax, ymin, ymax float64) {
+	c, r := g.Dims()
+	xmin, xmax = math.Min(g.X(0), g.X(c-1)), math.Max(g.X(0), g.X(c-1))
+	ymin, ymax = math.Min(g.Y(0), g.Y(r-1)), math.Max(g.Y(0), g.Y(r-1))
+	return xmin, xmax, ymin, ymax
+}
+
+// zRange returns the range of the values of g, ignoring NaN.
+func zRange(g plotter.GridXYZ) (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	c, r := g.Dims()
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			v := g.Z(i, j)
+			if math.IsNaN(v) {
+				continue
+			}
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+	}
+	return min, max
+}
@@ -285,3 +285,93 @@ func TestIssue514(t *testing.T) {
 		})
 	}
 }
+
+func TestWindowRedraw(t *testing.T) {
+	r := plotter.NewRing(100)
+	for i := 0; i < 50; i++ {
+		r.Append(float64(i), math.Sin(float64(i)))
+	}
+	p, err := nplot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l, err := plotter.NewLine(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ranges xRange
+	p.Add(l, &ranges)
+	p.X.Window = 20
+	p.Y.Min, p.Y.Max = -1, 1
+
+	draw1 := func(redraw bool) *recorder.Canvas {
+		var rec recorder.Canvas
+		c := draw.NewCanvas(&rec, 100, 100)
+		if redraw {
+			p.Redraw(c)
+		} else {
+			p.Draw(c)
+		}
+		return &rec
+	}
+
+	draw1(false)
+	if ranges.min != 29 || ranges.max != 49 {
+		t.Errorf("unexpected x range: got:[%v, %v] want:[29, 49]", ranges.min, ranges.max)
+	}
+
+	r.Append(50, 0)
+	draw1(true)
+	if ranges.min != 30 || ranges.max != 50 {
+		t.Errorf("unexpected x range: got:[%v, %v] want:[30, 50]", ranges.min, ranges.max)
+	}
+	if p.X.Min != 0 || p.X.Max != 49 {
+		t.Errorf("unexpected modification of x range: got:[%v, %v] want:[0, 49]", p.X.Min, p.X.Max)
+	}
+
+	// With unchanged ranges, a redrawing with reused
+	// layout is equal to a full drawing.
+	p.X.Window = 0
+	got := draw1(true)
+	want := draw1(false)
+	if d := recorder.Diff(want, got, recorder.Tolerance{}); len(d) != 0 {
+		t.Errorf("unexpected differences of redrawing: %v", d)
+	}
+}
+
+// xRange is a plotter recording the range
+// of the x axis it is drawn with.
+type xRange struct {
+	min, max float64
+}
+
+func (r *xRange) Plot(c draw.Canvas, plt *nplot.Plot) {
+	r.min, r.max = plt.X.Min, plt.X.Max
+}
+
+func TestWriterToTitle(t *testing.T) {
+	p, err := nplot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Plot"
+	for _, test := range []struct {
+		opts []draw.FormatOption
+		want string
+	}{
+		{want: "/Title (\xfe\xff\x00P\x00l\x00o\x00t)"},
+		{opts: []draw.FormatOption{draw.WithTitle("Doc")}, want: "/Title (\xfe\xff\x00D\x00o\x00c)"},
+	} {
+		wt, err := p.WriterTo(100, 100, "pdf", test.opts...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var buf bytes.Buffer
+		if _, err := wt.WriteTo(&buf); err != nil {
+			t.Fatalf("could not write plot: %v", err)
+		}
+		if !bytes.Contains(buf.Bytes(), []byte(test.want)) {
+			t.Errorf("missing %q in PDF output", test.want)
+		}
+	}
+}
@@ -25,6 +25,10 @@ type BarChart struct {
 	// Color is the fill color of the bars.
 	Color color.Color
 
+	// FillStyle, if not nil, is used to fill
+	// the bars instead of Color.
+	FillStyle vg.FillStyle
+
 	// LineStyle is the style of the outline of the bars.
 	draw.LineStyle
 
@@ -95,6 +99,41 @@ func (b *BarChart) StackOn(on *BarChart) {
 	b.stackedOn = on
 }
 
+// barChart has the fields but not the methods of BarChart.
+type barChart BarChart
+
+// barChartData is the serialized form of a BarChart.
+type barChartData struct {
+	barChart
+	StackedOn *BarChart
+}
+
+// JSONProxy implements the nplot.JSONProxy interface.
+// The bar chart is encoded with the bar chart it is
+// stacked on in the field "StackedOn".
+func (b *BarChart) JSONProxy() interface{} {
+	return &barChartData{barChart: barChart(*b), StackedOn: b.stackedOn}
+}
+
+// SetJSONProxy implements the nplot.JSONProxy interface.
+func (b *BarChart) SetJSONProxy(proxy interface{}) error {
+	d := proxy.(*barChartData)
+	*b = BarChart(d.barChart)
+	b.stackedOn = d.StackedOn
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The bar chart is encoded by nplot.EncodeJSON.
+func (b *BarChart) MarshalJSON() ([]byte, error) {
+	return nplot.EncodeJSON(b)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *BarChart) UnmarshalJSON(data []byte) error {
+	return nplot.DecodeJSON(data, b)
+}
+
 // Plot implements the nplot.Plotter interface.
 func (b *BarChart) Plot(c draw.Canvas, plt *nplot.Plot) {
 	trCat, trVal := plt.Transforms(&c)
@@ -139,7 +178,7 @@ func (b *BarChart) Plot(c draw.Canvas, plt *nplot.Plot) {
 			}
 			poly = c.ClipPolygonX(pts)
 		}
-		c.FillPolygon(b.Color, poly)
+		c.FillPolygonStyle(b.Color, b.FillStyle, poly)
 
 		var outline [][]vg.Point
 		if !b.Horizontal {
@@ -203,7 +242,7 @@ func (b *BarChart) Thumbnail(c *draw.Canvas) {
 		{c.Max.X, c.Min.Y},
 	}
 	poly := c.ClipPolygonY(pts)
-	c.FillPolygon(b.Color, poly)
+	c.FillPolygonStyle(b.Color, b.FillStyle, poly)
 
 	pts = append(pts, vg.Point{X: c.Min.X, Y: c.Min.Y})
 	outline := c.ClipLinesY(pts)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hneemann/nplot"
 	"github.com/hneemann/nplot/palette"
+	"github.com/hneemann/nplot/vg"
 	"github.com/hneemann/nplot/vg/draw"
 )
 
@@ -25,6 +26,13 @@ type ColorBar struct {
 	// shown in the legend. If Colors is not specified,
 	// a default will be used.
 	Colors int
+
+	// Levels, if not empty, are the ascending boundaries
+	// of discrete bands shown instead of the continuous
+	// colors, such as the levels of a filled Contour.
+	// Each band has the color of the ColorMap at its
+	// middle, and Colors is ignored.
+	Levels []float64
 }
 
 // colors returns the number of colors to be shown
@@ -49,11 +57,34 @@ func (l *ColorBar) check() {
 	if l.ColorMap.Max() == l.ColorMap.Min() {
 		panic("plotter: ColorMap Max==Min")
 	}
+	if len(l.Levels) == 1 {
+		panic("plotter: single level in ColorBar")
+	}
+}
+
+// min and max return the range of the values shown
+// by the ColorBar.
+func (l *ColorBar) min() float64 {
+	if len(l.Levels) != 0 {
+		return l.Levels[0]
+	}
+	return l.ColorMap.Min()
+}
+
+func (l *ColorBar) max() float64 {
+	if len(l.Levels) != 0 {
+		return l.Levels[len(l.Levels)-1]
+	}
+	return l.ColorMap.Max()
 }
 
 // Plot implements the Plot method of the nplot.Plotter interface.
 func (l *ColorBar) Plot(c draw.Canvas, p *nplot.Plot) {
 	l.check()
+	if len(l.Levels) != 0 {
+		l.plotLevels(c, p)
+		return
+	}
 	colors := l.colors(c)
 	var pImg *Image
 	delta := (l.ColorMap.Max() - l.ColorMap.Min()) / float64(colors)
@@ -87,12 +118,35 @@ func (l *ColorBar) Plot(c draw.Canvas, p *nplot.Plot) {
 	pImg.Plot(c, p)
 }
 
+// plotLevels draws the bands between the levels.
+func (l *ColorBar) plotLevels(c draw.Canvas, p *nplot.Plot) {
+	trX, trY := p.Transforms(&c)
+	for i := 1; i < len(l.Levels); i++ {
+		lo, hi := l.Levels[i-1], l.Levels[i]
+		col, err := l.ColorMap.At((lo + hi) / 2)
+		if err != nil {
+			panic(err)
+		}
+		x0, x1, y0, y1 := lo, hi, 0.0, 1.0
+		if l.Vertical {
+			x0, x1, y0, y1 = y0, y1, x0, x1
+		}
+		pts := []vg.Point{
+			{X: trX(x0), Y: trY(y0)},
+			{X: trX(x1), Y: trY(y0)},
+			{X: trX(x1), Y: trY(y1)},
+			{X: trX(x0), Y: trY(y1)},
+		}
+		c.FillPolygon(col, c.ClipPolygonXY(pts))
+	}
+}
+
 // DataRange implements the DataRange method
 // of the nplot.DataRanger interface.
 func (l *ColorBar) DataRange() (xmin, xmax, ymin, ymax float64) {
 	l.check()
 	if l.Vertical {
-		return 0, 1, l.ColorMap.Min(), l.ColorMap.Max()
+		return 0, 1, l.min(), l.max()
 	}
-	return l.ColorMap.Min(), l.ColorMap.Max(), 0, 1
+	return l.min(), l.max(), 0, 1
 }
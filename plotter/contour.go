@@ -5,6 +5,7 @@
 package plotter
 
 import (
+	"fmt"
 	"image/color"
 	"math"
 	"sort"
@@ -43,6 +44,35 @@ type Contour struct {
 	// Min and Max define the dynamic range of the
 	// heat map.
 	Min, Max float64
+
+	// Fill specifies whether the regions between
+	// consecutive levels are filled with the colors of
+	// Palette, scaled uniformly across the middles of
+	// the regions.  The region below the lowest level
+	// is filled with Underflow and the region above
+	// the highest level with Overflow, if they are
+	// not nil.  Grid cells with a NaN corner are not
+	// filled.
+	Fill bool
+
+	// LabelFormat is the fmt format of the labels of
+	// the levels drawn along the contour lines, such
+	// as "%g".  The lines are broken under the labels.
+	// If LabelFormat is empty, no labels are drawn.
+	LabelFormat string
+
+	// LabelStyle is the style of the labels.  If its
+	// color is nil, the color of the line is used,
+	// and if its font is the zero font, DefaultFont
+	// of DefaultFontSize is used.  The alignment and
+	// rotation of LabelStyle are ignored.
+	LabelStyle draw.TextStyle
+
+	// LabelSpacing is the distance along a contour
+	// line between its labels.  If LabelSpacing is
+	// zero, a label is drawn at the middle of each
+	// line long enough to hold it.
+	LabelSpacing vg.Length
 }
 
 // NewContour creates as new contour plotter for the given data, using
@@ -145,45 +175,81 @@ func (h *Contour) Plot(c draw.Canvas, plt *nplot.Plot) {
 	// The alternative naive approach is to draw each line segment as
 	// conrec returns it. The integrated path approach allows graphical
 	// optimisations and is necessary for contour fill shading.
-	cp := contourPaths(h.GridXYZ, h.Levels, trX, trY)
+	levels := h.levels()
+	cp := contourPaths(h.GridXYZ, levels, trX, trY)
 
-	// ps is a palette scaling factor to scale the palette uniformly
-	// across the given levels. This enables a discordance between the
-	// number of colours and the number of levels. Sorting is not
-	// necessary since contourPaths sorts the levels as a side effect.
-	ps := float64(len(pal)-1) / (h.Levels[len(h.Levels)-1] - h.Levels[0])
-	if len(h.Levels) == 1 {
-		ps = 0
+	if h.Fill {
+		h.fill(c, trX, trY)
+	}
+	var lbl *labeller
+	if h.LabelFormat != "" {
+		lbl = newLabeller(h.LabelStyle, h.LabelSpacing)
 	}
 
-	for i, z := range h.Levels {
-		if math.IsNaN(z) {
+	for _, i := range h.levelOrder() {
+		z := h.Levels[i]
+		style, col := h.lineStyle(i, levels, pal)
+		if col == nil {
 			continue
 		}
 		for _, pa := range cp[z] {
-			if isLoop(pa) {
+			pas := []vg.Path{pa}
+			if lbl != nil {
+				pas = lbl.label(c, pa, fmt.Sprintf(h.LabelFormat, z), col)
+			} else if isLoop(pa) {
 				pa.Close()
 			}
-
-			style := h.LineStyles[i%len(h.LineStyles)]
-			var col color.Color
-			switch {
-			case z < h.Min:
-				col = h.Underflow
-			case z > h.Max:
-				col = h.Overflow
-			case len(pal) == 0:
-				col = style.Color
-			default:
-				col = pal[int((z-h.Levels[0])*ps+0.5)] // Apply palette scaling.
-			}
-			if col != nil && style.Width != 0 {
+			if style.Width != 0 {
 				c.SetLineStyle(style)
 				c.SetColor(col)
-				c.Stroke(pa)
+				for _, pa := range pas {
+					c.Stroke(pa)
+				}
 			}
 		}
 	}
+	if lbl != nil {
+		lbl.draw(c)
+	}
+}
+
+// levelOrder returns the indices of the levels
+// without NaN, ordered by ascending level.
+func (h *Contour) levelOrder() []int {
+	idx := make([]int, 0, len(h.Levels))
+	for i, z := range h.Levels {
+		if !math.IsNaN(z) {
+			idx = append(idx, i)
+		}
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return h.Levels[idx[a]] < h.Levels[idx[b]] })
+	return idx
+}
+
+// lineStyle returns the style and the color of the contour
+// line of the i-th level, using the colors of the palette
+// pal spread over the sorted levels.
+func (h *Contour) lineStyle(i int, levels []float64, pal []color.Color) (draw.LineStyle, color.Color) {
+	// ps is a palette scaling factor to scale the palette uniformly
+	// across the given levels. This enables a discordance between the
+	// number of colours and the number of levels.
+	ps := float64(len(pal)-1) / (levels[len(levels)-1] - levels[0])
+	if len(levels) == 1 {
+		ps = 0
+	}
+
+	z := h.Levels[i]
+	style := h.LineStyles[i%len(h.LineStyles)]
+	switch {
+	case z < h.Min:
+		return style, h.Underflow
+	case z > h.Max:
+		return style, h.Overflow
+	case len(pal) == 0:
+		return style, style.Color
+	default:
+		return style, pal[int((z-levels[0])*ps+0.5)] // Apply palette scaling.
+	}
 }
 
 // naivePlot implements the a naive rendering approach for contours.
@@ -297,6 +363,18 @@ func isLoop(p vg.Path) bool {
 func contourPaths(m GridXYZ, levels []float64, trX, trY func(float64) vg.Length) map[float64][]vg.Path {
 	sort.Float64s(levels)
 
+	// Build vg.Paths.
+	paths := make(map[float64][]vg.Path)
+	for c := range traceContours(m, levels) {
+		paths[c.z] = append(paths[c.z], c.path(trX, trY))
+	}
+
+	return paths
+}
+
+// traceContours returns the contours of the data in m cut at the
+// given sorted levels.
+func traceContours(m GridXYZ, levels []float64) contourSet {
 	ends := make(map[float64]endMap)
 	conts := make(contourSet)
 	conrec(m, levels, func(_, _ int, l line, z float64) {
@@ -321,13 +399,7 @@ func contourPaths(m GridXYZ, levels []float64, trX, trY func(float64) vg.Length)
 		c.exciseLoops(conts, true)
 	}
 
-	// Build vg.Paths.
-	paths := make(map[float64][]vg.Path)
-	for c := range conts {
-		paths[c.z] = append(paths[c.z], c.path(trX, trY))
-	}
-
-	return paths
+	return conts
 }
 
 // contourSet hold a working collection of contours.
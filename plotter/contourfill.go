@@ -0,0 +1,350 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/palette"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// zPoint is a point of a grid with its height.
+type zPoint struct {
+	X, Y, Z float64
+}
+
+// fill fills the regions between consecutive levels. Each grid cell
+// is divided into four triangles meeting at the mean of its corners,
+// as done by conrec, so that the filled regions match the contour
+// lines. The parts of the triangles in the same region are filled
+// as a single path to avoid seams between them.
+func (h *Contour) fill(c draw.Canvas, trX, trY func(float64) vg.Length) {
+	levels := h.levels()
+	cols := h.regionColors(levels)
+	paths := make([]vg.Path, len(cols))
+	add := func(r int, poly []zPoint) {
+		pts := make([]vg.Point, len(poly))
+		for i, p := range poly {
+			pts[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+		}
+		pts = c.ClipPolygonXY(pts)
+		if len(pts) < 3 {
+			return
+		}
+		pa := &paths[r]
+		pa.Move(pts[0])
+		for _, p := range pts[1:] {
+			pa.Line(p)
+		}
+		pa.Close()
+	}
+
+	g := h.GridXYZ
+	nc, nr := g.Dims()
+	for i := 0; i < nc-1; i++ {
+		for j := 0; j < nr-1; j++ {
+			q := [4]zPoint{
+				{X: g.X(i), Y: g.Y(j), Z: g.Z(i, j)},
+				{X: g.X(i + 1), Y: g.Y(j), Z: g.Z(i+1, j)},
+				{X: g.X(i + 1), Y: g.Y(j + 1), Z: g.Z(i+1, j+1)},
+				{X: g.X(i), Y: g.Y(j + 1), Z: g.Z(i, j+1)},
+			}
+			var m zPoint
+			min, max := math.Inf(1), math.Inf(-1)
+			for _, p := range q {
+				m.X += p.X / 4
+				m.Y += p.Y / 4
+				m.Z += p.Z / 4
+				min = math.Min(min, p.Z)
+				max = math.Max(max, p.Z)
+			}
+			if math.IsNaN(m.Z) {
+				// A NaN corner leaves a hole.
+				continue
+			}
+
+			lo, hi := region(levels, min), region(levels, max)
+			if lo == hi {
+				if cols[lo] != nil {
+					add(lo, q[:])
+				}
+				continue
+			}
+			for k := range q {
+				tri := []zPoint{q[k], q[(k+1)%4], m}
+				for r := lo; r <= hi; r++ {
+					if cols[r] == nil {
+						continue
+					}
+					poly := tri
+					if r > 0 {
+						poly = clipZ(poly, levels[r-1], true)
+					}
+					if r < len(levels) {
+						poly = clipZ(poly, levels[r], false)
+					}
+					if len(poly) >= 3 {
+						add(r, poly)
+					}
+				}
+			}
+		}
+	}
+
+	for r, pa := range paths {
+		if len(pa) != 0 {
+			c.SetColor(cols[r])
+			c.Fill(pa)
+		}
+	}
+}
+
+// levels returns the levels of the contour without
+// NaN in ascending order.
+func (h *Contour) levels() []float64 {
+	levels := make([]float64, 0, len(h.Levels))
+	for _, z := range h.Levels {
+		if !math.IsNaN(z) {
+			levels = append(levels, z)
+		}
+	}
+	sort.Float64s(levels)
+	return levels
+}
+
+// region returns the index of the region of the height z
+// between the sorted levels.  Region r holds the heights
+// from levels[r-1] up to, excluding, levels[r].
+func region(levels []float64, z float64) int {
+	return sort.Search(len(levels), func(i int) bool { return levels[i] > z })
+}
+
+// regionColors returns the fill colors of the regions between
+// the sorted levels, as described by the Fill field.  A nil
+// color denotes a region that is not filled.
+func (h *Contour) regionColors(levels []float64) []color.Color {
+	cols := make([]color.Color, len(levels)+1)
+	if len(levels) == 0 {
+		return cols
+	}
+	cols[0] = h.Underflow
+	cols[len(levels)] = h.Overflow
+
+	var pal []color.Color
+	if h.Palette != nil {
+		pal = h.Palette.Colors()
+	}
+	if len(pal) == 0 || len(levels) < 2 {
+		return cols
+	}
+	first := (levels[0] + levels[1]) / 2
+	last := (levels[len(levels)-2] + levels[len(levels)-1]) / 2
+	// ps scales the palette uniformly across
+	// the middles of the regions.
+	ps := float64(len(pal)-1) / (last - first)
+	if last == first {
+		ps = 0
+	}
+	for r := 1; r < len(levels); r++ {
+		mid := (levels[r-1] + levels[r]) / 2
+		cols[r] = pal[int((mid-first)*ps+0.5)]
+	}
+	return cols
+}
+
+// clipZ returns the part of the polygon poly with heights above
+// or equal to z if above is true, or below or equal to z if not.
+// The heights are interpolated linearly along the edges.
+func clipZ(poly []zPoint, z float64, above bool) []zPoint {
+	in := func(p zPoint) bool {
+		if above {
+			return p.Z >= z
+		}
+		return p.Z <= z
+	}
+	var clipped []zPoint
+	for i, p := range poly {
+		q := poly[(i+1)%len(poly)]
+		if in(p) {
+			clipped = append(clipped, p)
+		}
+		if in(p) != in(q) {
+			t := (z - p.Z) / (q.Z - p.Z)
+			clipped = append(clipped, zPoint{
+				X: p.X + t*(q.X-p.X),
+				Y: p.Y + t*(q.Y-p.Y),
+				Z: z,
+			})
+		}
+	}
+	return clipped
+}
+
+// Thumbnailers returns legend entries for the contour plot and
+// their labels, formatted by LabelFormat or by "%g" if LabelFormat
+// is empty.  If Fill is true, there is an entry for each filled
+// region, from the lowest to the highest, and otherwise there is
+// an entry for each level drawn by a line, ordered by ascending
+// level like Plot.  Neither modifies Levels.
+func (h *Contour) Thumbnailers() (legendLabels []string, thumbnailers []nplot.Thumbnailer) {
+	format := h.LabelFormat
+	if format == "" {
+		format = "%g"
+	}
+
+	if h.Fill {
+		levels := h.levels()
+		for r, col := range h.regionColors(levels) {
+			if col == nil {
+				continue
+			}
+			var label string
+			switch r {
+			case 0:
+				label = "< " + fmt.Sprintf(format, levels[0])
+			case len(levels):
+				label = "> " + fmt.Sprintf(format, levels[r-1])
+			default:
+				label = fmt.Sprintf(format, levels[r-1]) + " – " + fmt.Sprintf(format, levels[r])
+			}
+			legendLabels = append(legendLabels, label)
+			thumbnailers = append(thumbnailers, paletteThumbnailer{Color: col})
+		}
+		return legendLabels, thumbnailers
+	}
+
+	var pal []color.Color
+	if h.Palette != nil {
+		pal = h.Palette.Colors()
+	}
+	levels := h.levels()
+	for _, i := range h.levelOrder() {
+		z := h.Levels[i]
+		style, col := h.lineStyle(i, levels, pal)
+		if col == nil || style.Width == 0 {
+			continue
+		}
+		style.Color = col
+		legendLabels = append(legendLabels, fmt.Sprintf(format, z))
+		thumbnailers = append(thumbnailers, &Line{LineStyle: style})
+	}
+	return legendLabels, thumbnailers
+}
+
+// ColorMap returns a color map of the colors of the regions
+// between the levels, as filled by Plot if Fill is true.  Its
+// range is from the lowest to the highest level.  Values in
+// regions that are not filled have a transparent color.
+func (h *Contour) ColorMap() palette.ColorMap {
+	levels := h.levels()
+	if len(levels) == 0 {
+		panic("contour: no levels")
+	}
+	return &levelColorMap{
+		Levels: levels,
+		Colors: h.regionColors(levels),
+		Range:  [2]float64{levels[0], levels[len(levels)-1]},
+		A:      1,
+	}
+}
+
+// ColorBar returns a ColorBar showing the regions between
+// the levels, as filled by Plot if Fill is true.
+func (h *Contour) ColorBar() *ColorBar {
+	return &ColorBar{ColorMap: h.ColorMap(), Levels: h.levels()}
+}
+
+// levelColorMap is a palette.ColorMap of the colors of the
+// regions between the levels of a Contour.
+type levelColorMap struct {
+	// Levels are the sorted levels.
+	Levels []float64
+
+	// Colors are the colors of the regions
+	// between the levels, with nil for the
+	// regions that are not filled.
+	Colors []color.Color
+
+	// Range is the range of the color map.
+	Range [2]float64
+
+	// A is the opacity of the color map.
+	A float64
+}
+
+// At implements the At method of the palette.ColorMap interface.
+func (m *levelColorMap) At(v float64) (color.Color, error) {
+	switch {
+	case math.IsNaN(v):
+		return nil, palette.ErrNaN
+	case v < m.Range[0]:
+		return nil, palette.ErrUnderflow
+	case v > m.Range[1]:
+		return nil, palette.ErrOverflow
+	}
+	col := m.Colors[region(m.Levels, v)]
+	if col == nil {
+		return color.Transparent, nil
+	}
+	if m.A == 1 {
+		return col, nil
+	}
+	c := color.NRGBAModel.Convert(col).(color.NRGBA)
+	c.A = uint8(float64(c.A)*m.A + 0.5)
+	return c, nil
+}
+
+// Max implements the Max method of the palette.ColorMap interface.
+func (m *levelColorMap) Max() float64 { return m.Range[1] }
+
+// SetMax implements the SetMax method of the palette.ColorMap interface.
+func (m *levelColorMap) SetMax(v float64) { m.Range[1] = v }
+
+// Min implements the Min method of the palette.ColorMap interface.
+func (m *levelColorMap) Min() float64 { return m.Range[0] }
+
+// SetMin implements the SetMin method of the palette.ColorMap interface.
+func (m *levelColorMap) SetMin(v float64) { m.Range[0] = v }
+
+// Alpha implements the Alpha method of the palette.ColorMap interface.
+func (m *levelColorMap) Alpha() float64 { return m.A }
+
+// SetAlpha implements the SetAlpha method of the palette.ColorMap interface.
+func (m *levelColorMap) SetAlpha(alpha float64) {
+	if alpha < 0 || alpha > 1 {
+		panic("contour: invalid alpha")
+	}
+	m.A = alpha
+}
+
+// Palette implements the Palette method of the palette.ColorMap
+// interface, returning the colors at evenly spaced values.
+func (m *levelColorMap) Palette(colors int) palette.Palette {
+	p := make(levelPalette, colors)
+	for i := range p {
+		v := (m.Range[0] + m.Range[1]) / 2
+		if colors > 1 {
+			v = m.Range[0] + float64(i)*(m.Range[1]-m.Range[0])/float64(colors-1)
+		}
+		c, err := m.At(v)
+		if err != nil {
+			panic(err)
+		}
+		p[i] = c
+	}
+	return p
+}
+
+// levelPalette is the palette.Palette of a levelColorMap.
+type levelPalette []color.Color
+
+// Colors implements the palette.Palette interface.
+func (p levelPalette) Colors() []color.Color { return p }
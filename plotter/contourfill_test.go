@@ -0,0 +1,183 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/palette"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+	"github.com/hneemann/nplot/vg/recorder"
+	"github.com/hneemann/nplot/vg/vgimg"
+)
+
+// rampGrid returns a grid of 4 columns and 3 rows
+// with the x coordinate as height.
+func rampGrid() unitGrid {
+	return unitGrid{mat.NewDense(3, 4, []float64{
+		0, 1, 2, 3,
+		0, 1, 2, 3,
+		0, 1, 2, 3,
+	})}
+}
+
+// contourPlot returns a plot showing exactly the range of rampGrid.
+func contourPlot(t *testing.T) *nplot.Plot {
+	p, err := nplot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 3
+	p.Y.Min, p.Y.Max = 0, 2
+	return p
+}
+
+func TestContourFill(t *testing.T) {
+	var (
+		red   = color.NRGBA{R: 255, A: 255}
+		green = color.NRGBA{G: 255, A: 255}
+		blue  = color.NRGBA{B: 255, A: 255}
+		white = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	)
+	g := rampGrid()
+	g.Matrix.(*mat.Dense).Set(2, 3, math.NaN())
+	h := NewContour(g, []float64{0.5, 2.5}, levelPalette{red})
+	h.Fill = true
+	h.Underflow = blue
+	h.Overflow = green
+	h.LineStyles = []draw.LineStyle{{}}
+
+	// The canvas has 100 pixels per unit of the grid.
+	c := vgimg.NewWith(vgimg.UseWH(300, 200), vgimg.UseDPI(72))
+	h.Plot(draw.New(c), contourPlot(t))
+	img := c.Image()
+	for _, test := range []struct {
+		x, y float64
+		want color.Color
+	}{
+		{x: 0.2, y: 0.5, want: blue},
+		{x: 0.45, y: 1.5, want: blue},
+		{x: 0.55, y: 1.5, want: red},
+		{x: 1.5, y: 0.5, want: red},
+		{x: 2.45, y: 0.5, want: red},
+		{x: 2.55, y: 0.5, want: green},
+		{x: 2.8, y: 0.2, want: green},
+		{x: 2.8, y: 1.8, want: white},
+	} {
+		got := color.NRGBAModel.Convert(img.At(int(test.x*100), int(200-test.y*100)))
+		if got != test.want {
+			t.Errorf("unexpected color at (%v, %v): got:%v want:%v", test.x, test.y, got, test.want)
+		}
+	}
+}
+
+func TestClipZ(t *testing.T) {
+	tri := []zPoint{{X: 0, Y: 0, Z: 0}, {X: 2, Y: 0, Z: 2}, {X: 0, Y: 2, Z: 0}}
+	got := clipZ(tri, 1, true)
+	want := []zPoint{{X: 1, Y: 0, Z: 1}, {X: 2, Y: 0, Z: 2}, {X: 1, Y: 1, Z: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected polygon above: got:%v want:%v", got, want)
+	}
+	got = clipZ(tri, 1, false)
+	want = []zPoint{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 1}, {X: 1, Y: 1, Z: 1}, {X: 0, Y: 2, Z: 0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected polygon below: got:%v want:%v", got, want)
+	}
+	if got := clipZ(tri, 3, true); len(got) != 0 {
+		t.Errorf("unexpected polygon above maximum: %v", got)
+	}
+}
+
+func TestContourLabels(t *testing.T) {
+	h := NewContour(rampGrid(), []float64{1.5}, nil)
+	h.LabelFormat = "%.1f"
+
+	draws := func() (strokes int, labels []string) {
+		var rec recorder.Canvas
+		h.Plot(draw.NewCanvas(&rec, 3*vg.Inch, 2*vg.Inch), contourPlot(t))
+		for _, a := range rec.Actions {
+			switch a := a.(type) {
+			case *recorder.Stroke:
+				strokes++
+			case *recorder.FillString:
+				labels = append(labels, a.String)
+			}
+		}
+		return strokes, labels
+	}
+
+	strokes, labels := draws()
+	if strokes != 2 || !reflect.DeepEqual(labels, []string{"1.5"}) {
+		t.Errorf("unexpected drawing of a label: got %d strokes and labels %q", strokes, labels)
+	}
+	h.LabelSpacing = vg.Inch * 2 / 3
+	strokes, labels = draws()
+	if strokes != 4 || len(labels) != 3 {
+		t.Errorf("unexpected drawing of spaced labels: got %d strokes and labels %q", strokes, labels)
+	}
+	h.LabelFormat = ""
+	strokes, labels = draws()
+	if strokes != 1 || len(labels) != 0 {
+		t.Errorf("unexpected drawing without labels: got %d strokes and labels %q", strokes, labels)
+	}
+}
+
+func TestContourThumbnailers(t *testing.T) {
+	h := NewContour(rampGrid(), []float64{2, 1, 0}, palette.Heat(2, 1))
+	h.LineStyles = []draw.LineStyle{{Width: 1}, {Width: 2}, {Width: 3}}
+	labels, thumbs := h.Thumbnailers()
+	if want := []string{"0", "1", "2"}; !reflect.DeepEqual(labels, want) || len(thumbs) != len(want) {
+		t.Errorf("unexpected line legend: got:%q want:%q", labels, want)
+	}
+	if want := []float64{2, 1, 0}; !reflect.DeepEqual(h.Levels, want) {
+		t.Errorf("unexpected levels after Thumbnailers: got:%v want:%v", h.Levels, want)
+	}
+	for i, want := range []vg.Length{3, 2, 1} {
+		if w := thumbs[i].(*Line).Width; w != want {
+			t.Errorf("unexpected width of thumbnail %d: got:%v want:%v", i, w, want)
+		}
+	}
+	h.LineStyles = []draw.LineStyle{DefaultLineStyle}
+
+	h.Fill = true
+	h.Overflow = color.Black
+	labels, thumbs = h.Thumbnailers()
+	if want := []string{"0 – 1", "1 – 2", "> 2"}; !reflect.DeepEqual(labels, want) || len(thumbs) != len(want) {
+		t.Errorf("unexpected fill legend: got:%q want:%q", labels, want)
+	}
+
+	cm := h.ColorMap()
+	pal := h.Palette.Colors()
+	for _, test := range []struct {
+		v    float64
+		want color.Color
+	}{
+		{v: 0, want: pal[0]},
+		{v: 0.9, want: pal[0]},
+		{v: 1.5, want: pal[1]},
+		{v: 2, want: color.Black},
+	} {
+		got, err := cm.At(test.v)
+		if err != nil || got != test.want {
+			t.Errorf("unexpected color at %v: got:%v, %v want:%v", test.v, got, err, test.want)
+		}
+	}
+	if _, err := cm.At(-1); err != palette.ErrUnderflow {
+		t.Errorf("unexpected error below the levels: %v", err)
+	}
+
+	cb := h.ColorBar()
+	cb.Vertical = true
+	if xmin, xmax, ymin, ymax := cb.DataRange(); xmin != 0 || xmax != 1 || ymin != 0 || ymax != 2 {
+		t.Errorf("unexpected color bar range: %v %v %v %v", xmin, xmax, ymin, ymax)
+	}
+}
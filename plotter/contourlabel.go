@@ -0,0 +1,151 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// labeller places labels along contour lines.
+type labeller struct {
+	sty     draw.TextStyle
+	spacing vg.Length
+	labels  []contourLabel
+}
+
+// contourLabel is a label placed by a labeller.
+type contourLabel struct {
+	txt  string
+	pt   vg.Point
+	half vg.Length
+	sty  draw.TextStyle
+}
+
+// newLabeller returns a labeller placing labels of the
+// style sty at the given spacing, as described by the
+// LabelStyle and LabelSpacing fields of Contour.
+func newLabeller(sty draw.TextStyle, spacing vg.Length) *labeller {
+	if sty.Font.Size == 0 {
+		fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+		if err != nil {
+			panic(err)
+		}
+		sty.Font = fnt
+	}
+	sty.XAlign = draw.XCenter
+	sty.YAlign = draw.YCenter
+	return &labeller{sty: sty, spacing: spacing}
+}
+
+// label places the label txt along the path pa drawn in the
+// color col and returns the parts of pa to be stroked, which
+// leave gaps under the labels.  Labels are only placed where
+// the text fits on the path inside the canvas c and does not
+// overlap the labels placed before.
+func (l *labeller) label(c draw.Canvas, pa vg.Path, txt string, col color.Color) []vg.Path {
+	pts := make([]vg.Point, len(pa))
+	for i, pc := range pa {
+		pts[i] = pc.Pos
+	}
+	// d holds the distance along the path of each point.
+	d := make([]vg.Length, len(pts))
+	for i := 1; i < len(pts); i++ {
+		p := pts[i].Sub(pts[i-1])
+		d[i] = d[i-1] + vg.Length(math.Hypot(float64(p.X), float64(p.Y)))
+	}
+	at := func(s vg.Length) vg.Point {
+		i := sort.Search(len(d), func(i int) bool { return d[i] >= s })
+		switch {
+		case i == 0:
+			return pts[0]
+		case i == len(d):
+			return pts[len(pts)-1]
+		}
+		t := (s - d[i-1]) / (d[i] - d[i-1])
+		return pts[i].Sub(pts[i-1]).Scale(t).Add(pts[i-1])
+	}
+
+	length := d[len(d)-1]
+	half := l.sty.Width(txt)/2 + l.sty.Font.Size/4
+	spacing := l.spacing
+	if spacing == 0 {
+		spacing = length
+	}
+	if spacing < 4*half {
+		spacing = 4 * half
+	}
+	n := int(length / spacing)
+	offset := (length - vg.Length(n-1)*spacing) / 2
+
+	var gaps []vg.Length
+	for i := 0; i < n; i++ {
+		s := offset + vg.Length(i)*spacing
+		pt, p0, p1 := at(s), at(s-half), at(s+half)
+		if !c.Contains(pt) || !c.Contains(p0) || !c.Contains(p1) || l.overlaps(pt, half) {
+			continue
+		}
+		sty := l.sty
+		if sty.Color == nil {
+			sty.Color = col
+		}
+		sty.Rotation = math.Atan2(float64(p1.Y-p0.Y), float64(p1.X-p0.X))
+		switch {
+		case sty.Rotation > math.Pi/2:
+			sty.Rotation -= math.Pi
+		case sty.Rotation < -math.Pi/2:
+			sty.Rotation += math.Pi
+		}
+		l.labels = append(l.labels, contourLabel{txt: txt, pt: pt, half: half, sty: sty})
+		gaps = append(gaps, s-half, s+half)
+	}
+	if len(gaps) == 0 {
+		if isLoop(pa) {
+			pa.Close()
+		}
+		return []vg.Path{pa}
+	}
+
+	// Keep the parts of the path between the gaps.
+	gaps = append(append([]vg.Length{0}, gaps...), length)
+	pas := make([]vg.Path, 0, len(gaps)/2)
+	for i := 0; i < len(gaps); i += 2 {
+		from, to := gaps[i], gaps[i+1]
+		var part vg.Path
+		part.Move(at(from))
+		for j, s := range d {
+			if s > from && s < to {
+				part.Line(pts[j])
+			}
+		}
+		part.Line(at(to))
+		pas = append(pas, part)
+	}
+	return pas
+}
+
+// overlaps returns whether a label at pt, extending half
+// along its path, overlaps a placed label.  The labels are
+// approximated by circles.
+func (l *labeller) overlaps(pt vg.Point, half vg.Length) bool {
+	for _, lb := range l.labels {
+		d := lb.pt.Sub(pt)
+		if vg.Length(math.Hypot(float64(d.X), float64(d.Y))) < half+lb.half {
+			return true
+		}
+	}
+	return false
+}
+
+// draw draws the placed labels.
+func (l *labeller) draw(c draw.Canvas) {
+	for _, lb := range l.labels {
+		c.FillText(lb.sty, lb.pt, lb.txt)
+	}
+}
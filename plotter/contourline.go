@@ -0,0 +1,173 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"sort"
+)
+
+// ContourLine is a contour line of the values of a GridXYZ
+// in data coordinates, as returned by ContourLines.
+type ContourLine struct {
+	// Level is the height of the line.
+	Level float64
+
+	// XYs are the points of the line.  A closed
+	// line ends with its first point.
+	XYs XYs
+
+	// Closed specifies whether the line is a closed
+	// loop.  Lines that are not closed end at the
+	// border of the grid or at a NaN value.
+	Closed bool
+
+	// Parent is the index of the innermost closed
+	// line of the same level enclosing the line,
+	// or -1 if there is none.
+	Parent int
+
+	// Depth is the number of closed lines of the
+	// same level enclosing the line.  Closed lines
+	// of even depth are outer boundaries, which run
+	// counter-clockwise, and closed lines of odd
+	// depth are holes in their parents, which run
+	// clockwise.
+	Depth int
+}
+
+// Area returns the signed area enclosed by a closed line,
+// which is positive for outer boundaries and negative for
+// holes, so that the area of a region between a boundary
+// and its holes is the sum of their areas.  Area returns
+// zero for lines that are not closed.
+func (l ContourLine) Area() float64 {
+	if !l.Closed {
+		return 0
+	}
+	var a float64
+	for i := 1; i < len(l.XYs); i++ {
+		p, q := l.XYs[i-1], l.XYs[i]
+		a += p.X*q.Y - q.X*p.Y
+	}
+	return a / 2
+}
+
+// contains returns whether the point (x, y) lies inside
+// the closed line l.
+func (l ContourLine) contains(x, y float64) bool {
+	var in bool
+	for i := 1; i < len(l.XYs); i++ {
+		p, q := l.XYs[i-1], l.XYs[i]
+		if (p.Y > y) != (q.Y > y) && x < p.X+(y-p.Y)*(q.X-p.X)/(q.Y-p.Y) {
+			in = !in
+		}
+	}
+	return in
+}
+
+// ContourLines returns the contour lines of the values in g cut
+// at the given levels, keyed on the level.  The lines are traced
+// as by Contour and are returned in a deterministic order, with
+// closed lines starting at their lowest point with the lowest X.
+// NaN levels are ignored and levels is not modified.
+func ContourLines(g GridXYZ, levels []float64) map[float64][]ContourLine {
+	sorted := make([]float64, 0, len(levels))
+	for _, z := range levels {
+		if !math.IsNaN(z) {
+			sorted = append(sorted, z)
+		}
+	}
+	sort.Float64s(sorted)
+
+	lines := make(map[float64][]ContourLine)
+	for c := range traceContours(g, sorted) {
+		xys := c.xys()
+		closed := len(xys) > 2 && xys[0] == xys[len(xys)-1]
+		if closed {
+			xys = rotateLoop(xys)
+		}
+		lines[c.z] = append(lines[c.z], ContourLine{
+			Level:  c.z,
+			XYs:    xys,
+			Closed: closed,
+			Parent: -1,
+		})
+	}
+	for _, ls := range lines {
+		sort.Slice(ls, func(i, j int) bool {
+			return before(ls[i].XYs[0], ls[j].XYs[0])
+		})
+		nestLines(ls)
+	}
+	return lines
+}
+
+// nestLines sets the parents and the depths of the lines of a level
+// and orients the closed lines by their depths.
+func nestLines(ls []ContourLine) {
+	areas := make([]float64, len(ls))
+	for i, l := range ls {
+		areas[i] = math.Abs(l.Area())
+	}
+	for i := range ls {
+		p := ls[i].XYs[0]
+		for j, o := range ls {
+			if i == j || !o.Closed || areas[j] <= areas[i] || !o.contains(p.X, p.Y) {
+				continue
+			}
+			ls[i].Depth++
+			if ls[i].Parent < 0 || areas[j] < areas[ls[i].Parent] {
+				ls[i].Parent = j
+			}
+		}
+	}
+	for i, l := range ls {
+		if l.Closed && (l.Area() > 0) != (l.Depth%2 == 0) {
+			xys := ls[i].XYs
+			for i, j := 0, len(xys)-1; i < j; i, j = i+1, j-1 {
+				xys[i], xys[j] = xys[j], xys[i]
+			}
+		}
+	}
+}
+
+// rotateLoop returns the points of the closed loop xys,
+// starting and ending at its least point.
+func rotateLoop(xys XYs) XYs {
+	n := len(xys) - 1
+	min := 0
+	for i, p := range xys[:n] {
+		if before(p, xys[min]) {
+			min = i
+		}
+	}
+	rotated := make(XYs, 0, len(xys))
+	rotated = append(rotated, xys[min:n]...)
+	rotated = append(rotated, xys[:min+1]...)
+	return rotated
+}
+
+// before returns whether p is lower than q, or, if they are
+// at the same height, whether p is left of q.
+func before(p, q XY) bool {
+	if p.Y != q.Y {
+		return p.Y < q.Y
+	}
+	return p.X < q.X
+}
+
+// xys returns the points of the contour.
+func (c *contour) xys() XYs {
+	xys := make(XYs, 0, len(c.backward)+len(c.forward))
+	for i := len(c.backward) - 1; i >= 0; i-- {
+		p := c.backward[i]
+		xys = append(xys, XY{X: p.X, Y: p.Y})
+	}
+	for _, p := range c.forward {
+		xys = append(xys, XY{X: p.X, Y: p.Y})
+	}
+	return xys
+}
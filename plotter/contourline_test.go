@@ -0,0 +1,84 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestContourLines(t *testing.T) {
+	// A ring of height 1 at a distance of 2 from the center
+	// of a 101×101 grid with a cell size of 0.1.
+	const n = 101
+	data := make([]float64, n*n)
+	for i := range data {
+		x, y := float64(i%n-n/2)/10, float64(i/n-n/2)/10
+		data[i] = math.Exp(-math.Pow(math.Hypot(x, y)-2, 2))
+	}
+	g := unitGrid{mat.NewDense(n, n, data)}
+	levels := []float64{0.5, math.NaN(), 1e-4}
+
+	lines := ContourLines(g, levels)
+	if levels[0] != 0.5 || !math.IsNaN(levels[1]) || levels[2] != 1e-4 {
+		t.Errorf("unexpected modification of levels: %v", levels)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("unexpected number of levels: %d", len(lines))
+	}
+
+	// The circles in grid units have the radii 10(2±√ln2) at level 0.5.
+	ls := lines[0.5]
+	if len(ls) != 2 {
+		t.Fatalf("unexpected number of lines: %d", len(ls))
+	}
+	for i, want := range []struct {
+		r      float64
+		parent int
+		depth  int
+	}{
+		{r: 10 * (2 + math.Sqrt(math.Ln2)), parent: -1, depth: 0},
+		{r: 10 * (2 - math.Sqrt(math.Ln2)), parent: 0, depth: 1},
+	} {
+		l := ls[i]
+		if !l.Closed || l.XYs[0] != l.XYs[len(l.XYs)-1] {
+			t.Errorf("unexpected open line %d", i)
+		}
+		if l.Level != 0.5 || l.Parent != want.parent || l.Depth != want.depth {
+			t.Errorf("unexpected line %d: level:%v parent:%d depth:%d", i, l.Level, l.Parent, l.Depth)
+		}
+		for _, p := range l.XYs[1:] {
+			if before(p, l.XYs[0]) {
+				t.Errorf("unexpected start of line %d: %v before %v", i, p, l.XYs[0])
+				break
+			}
+		}
+		area := math.Pi * want.r * want.r
+		if want.depth%2 != 0 {
+			area = -area
+		}
+		if got := l.Area(); math.Abs(got-area) > 0.01*math.Abs(area) {
+			t.Errorf("unexpected area of line %d: got:%v want:%v", i, got, area)
+		}
+	}
+
+	// The circle at level 1e-4 is cut by the border of the grid.
+	var open, closed int
+	for _, l := range lines[1e-4] {
+		if l.Closed {
+			closed++
+		} else {
+			open++
+			if l.Area() != 0 || l.Parent != -1 {
+				t.Errorf("unexpected open line: area:%v parent:%d", l.Area(), l.Parent)
+			}
+		}
+	}
+	if open != 4 || closed != 0 {
+		t.Errorf("unexpected lines at level 1e-4: got %d open and %d closed", open, closed)
+	}
+}
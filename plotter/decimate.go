@@ -0,0 +1,254 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// Decimation specifies a method to reduce the number
+// of points drawn by a plotter.
+type Decimation int
+
+const (
+	// NoDecimation draws all points.
+	NoDecimation Decimation = iota
+
+	// MinMaxDecimation draws, for lines, the first, the
+	// last, the lowest and the highest point of each run
+	// of consecutive points in the same pixel column, and,
+	// for glyphs, the last point in each pixel.  Lines
+	// without steps and opaque glyphs of equal style look
+	// the same as without decimation, apart from slight
+	// differences of anti-aliasing.
+	MinMaxDecimation
+
+	// LTTBDecimation draws one point per pixel column,
+	// selected by the Largest-Triangle-Three-Buckets
+	// algorithm, which keeps the shape of the data but
+	// not every peak.  Unlike MinMaxDecimation, it drops
+	// glyphs that would be visible, so scatters decimated
+	// by it do not look the same as without decimation.
+	LTTBDecimation
+)
+
+// DecimationDPI is the smallest resolution, in dots per inch,
+// of the pixels used to decimate points.  Canvases with a larger
+// resolution, as reported by a DPI method, use their resolution.
+var DecimationDPI = 300.0
+
+// Decimated is an XYer holding a subset of the points of an XYer.
+type Decimated struct {
+	// XYer holds all points.
+	XYer XYer
+
+	// Index holds the indices in XYer of the
+	// points of the subset in ascending order.
+	Index []int
+}
+
+// Len implements the XYer interface.
+func (d Decimated) Len() int {
+	return len(d.Index)
+}
+
+// XY implements the XYer interface.
+func (d Decimated) XY(i int) (x, y float64) {
+	return d.XYer.XY(d.Index[i])
+}
+
+// DecimateLine returns the points of xys to draw, connected by
+// lines, on the canvas c with the axes of plt, decimated by d.
+func DecimateLine(xys XYer, d Decimation, c draw.Canvas, plt *nplot.Plot) Decimated {
+	switch d {
+	case MinMaxDecimation:
+		trX, _ := plt.Transforms(&c)
+		px := pixelSize(c)
+		return minMax(xys, func(x float64) int {
+			return int(math.Floor(float64(trX(x) / px)))
+		})
+	case LTTBDecimation:
+		return DecimateLTTB(xys, columns(c))
+	default:
+		return all(xys)
+	}
+}
+
+// DecimateGlyphs returns the points of xys to draw as glyphs on
+// the canvas c with the axes of plt, decimated by d.  Only
+// MinMaxDecimation keeps the appearance of the glyphs.
+func DecimateGlyphs(xys XYer, d Decimation, c draw.Canvas, plt *nplot.Plot) Decimated {
+	switch d {
+	case MinMaxDecimation:
+		trX, trY := plt.Transforms(&c)
+		px := pixelSize(c)
+		return cells(xys, func(x, y float64) [2]int {
+			return [2]int{
+				int(math.Floor(float64(trX(x) / px))),
+				int(math.Floor(float64(trY(y) / px))),
+			}
+		})
+	case LTTBDecimation:
+		return DecimateLTTB(xys, columns(c))
+	default:
+		return all(xys)
+	}
+}
+
+// DecimateLTTB returns n points of xys selected by the
+// Largest-Triangle-Three-Buckets algorithm: The first and the
+// last point are kept, the other points are split into n-2
+// buckets of consecutive points, and of each bucket the point
+// is kept that forms the largest triangle with the point kept
+// of the previous bucket and the mean of the next bucket.
+// If xys has at most n points, all points are returned.
+func DecimateLTTB(xys XYer, n int) Decimated {
+	l := xys.Len()
+	if n >= l || n < 3 {
+		if n < l && n > 0 {
+			idx := []int{0, l - 1}
+			return Decimated{XYer: xys, Index: idx[:n]}
+		}
+		return all(xys)
+	}
+
+	idx := make([]int, 0, n)
+	idx = append(idx, 0)
+	size := float64(l-2) / float64(n-2)
+	a := 0
+	for b := 0; b < n-2; b++ {
+		start := int(float64(b)*size) + 1
+		end := int(float64(b+1)*size) + 1
+
+		// Mean of the next bucket.
+		nstart, nend := end, int(float64(b+2)*size)+1
+		if nend > l {
+			nend = l
+		}
+		var mx, my float64
+		for i := nstart; i < nend; i++ {
+			x, y := xys.XY(i)
+			mx += x
+			my += y
+		}
+		mx /= float64(nend - nstart)
+		my /= float64(nend - nstart)
+
+		ax, ay := xys.XY(a)
+		best, area := start, -1.0
+		for i := start; i < end; i++ {
+			x, y := xys.XY(i)
+			ar := math.Abs((ax-mx)*(y-ay) - (ax-x)*(my-ay))
+			if ar > area {
+				best, area = i, ar
+			}
+		}
+		idx = append(idx, best)
+		a = best
+	}
+	idx = append(idx, l-1)
+	return Decimated{XYer: xys, Index: idx}
+}
+
+// minMax returns the first, the last, the lowest and the highest
+// point of each run of consecutive points of xys in the same column.
+func minMax(xys XYer, column func(x float64) int) Decimated {
+	l := xys.Len()
+	idx := make([]int, 0, l)
+	for start := 0; start < l; {
+		x, y := xys.XY(start)
+		col := column(x)
+		lo, hi := start, start
+		ylo, yhi := y, y
+		end := start + 1
+		for ; end < l; end++ {
+			x, y := xys.XY(end)
+			if column(x) != col {
+				break
+			}
+			if y < ylo {
+				lo, ylo = end, y
+			}
+			if y > yhi {
+				hi, yhi = end, y
+			}
+		}
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		for _, i := range []int{start, lo, hi, end - 1} {
+			if len(idx) == 0 || idx[len(idx)-1] < i {
+				idx = append(idx, i)
+			}
+		}
+		start = end
+	}
+	return Decimated{XYer: xys, Index: idx}
+}
+
+// cells returns the last point of xys in each cell.
+func cells(xys XYer, cell func(x, y float64) [2]int) Decimated {
+	l := xys.Len()
+	keys := make([][2]int, l)
+	last := make(map[[2]int]int)
+	for i := range keys {
+		keys[i] = cell(xys.XY(i))
+		last[keys[i]] = i
+	}
+	idx := make([]int, 0, len(last))
+	for i, k := range keys {
+		if last[k] == i {
+			idx = append(idx, i)
+		}
+	}
+	return Decimated{XYer: xys, Index: idx}
+}
+
+// decimatedStyle returns the style of a line connecting the points
+// of d.  If points were removed, miter joins are replaced by round
+// joins, which look the same at the flat corners of dense points
+// but avoid spikes at the sharp corners left by the decimation.
+func decimatedStyle(sty draw.LineStyle, d Decimated) draw.LineStyle {
+	if d.Len() < d.XYer.Len() && sty.Join == vg.MiterJoin {
+		sty.Join = vg.RoundJoin
+	}
+	return sty
+}
+
+// all returns all points of xys.
+func all(xys XYer) Decimated {
+	idx := make([]int, xys.Len())
+	for i := range idx {
+		idx[i] = i
+	}
+	return Decimated{XYer: xys, Index: idx}
+}
+
+// pixelSize returns the size of the pixels
+// used to decimate points drawn on c.
+func pixelSize(c draw.Canvas) vg.Length {
+	dpi := DecimationDPI
+	var vc vg.Canvas = c.Canvas
+	for {
+		dc, ok := vc.(draw.Canvas)
+		if !ok {
+			break
+		}
+		vc = dc.Canvas
+	}
+	if r, ok := vc.(interface{ DPI() float64 }); ok && r.DPI() > dpi {
+		dpi = r.DPI()
+	}
+	return vg.Inch / vg.Length(dpi)
+}
+
+// columns returns the number of pixel columns of c.
+func columns(c draw.Canvas) int {
+	return int(math.Ceil(float64(c.Size().X / pixelSize(c))))
+}
@@ -0,0 +1,150 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/cmpimg"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+	"github.com/hneemann/nplot/vg/vgimg"
+)
+
+func TestDecimateLTTB(t *testing.T) {
+	xys := make(XYs, 101)
+	for i := range xys {
+		xys[i] = XY{X: float64(i)}
+	}
+	xys[37].Y = 10
+	xys[62].Y = -10
+
+	d := DecimateLTTB(xys, 12)
+	if d.Len() != 12 || d.Index[0] != 0 || d.Index[11] != 100 {
+		t.Errorf("unexpected indices: %v", d.Index)
+	}
+	var peaks int
+	for _, i := range d.Index {
+		if i == 37 || i == 62 {
+			peaks++
+		}
+	}
+	if peaks != 2 {
+		t.Errorf("missing peaks in indices: %v", d.Index)
+	}
+
+	for _, test := range []struct {
+		n    int
+		want []int
+	}{
+		{n: 0, want: []int{0, 1, 2, 3}},
+		{n: 1, want: []int{0}},
+		{n: 2, want: []int{0, 3}},
+		{n: 4, want: []int{0, 1, 2, 3}},
+		{n: 10, want: []int{0, 1, 2, 3}},
+	} {
+		if got := DecimateLTTB(xys[:4], test.n).Index; !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected indices for n=%d: got:%v want:%v", test.n, got, test.want)
+		}
+	}
+}
+
+func TestDecimateMinMax(t *testing.T) {
+	xys := XYs{{0, 0}, {0.1, 5}, {0.2, -1}, {0.3, 2}, {1, 1}, {2, 0}, {2.5, 3}}
+	d := minMax(xys, func(x float64) int { return int(x) })
+	if want := []int{0, 1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(d.Index, want) {
+		t.Errorf("unexpected indices: got:%v want:%v", d.Index, want)
+	}
+	xys = append(xys[:1], XYs{{0.1, 5}, {0.15, 4}, {0.2, -1}, {0.25, 0}, {0.3, 2}}...)
+	d = minMax(xys, func(x float64) int { return int(x) })
+	if want := []int{0, 1, 3, 5}; !reflect.DeepEqual(d.Index, want) {
+		t.Errorf("unexpected indices: got:%v want:%v", d.Index, want)
+	}
+
+	d = cells(XYs{{0, 0}, {0.5, 0.5}, {1, 1}, {0.2, 0.2}}, func(x, y float64) [2]int {
+		return [2]int{int(x), int(y)}
+	})
+	if want := []int{2, 3}; !reflect.DeepEqual(d.Index, want) {
+		t.Errorf("unexpected cell indices: got:%v want:%v", d.Index, want)
+	}
+}
+
+func TestDecimatedLine(t *testing.T) {
+	const n = 100000
+	xys := make(XYs, n)
+	for i := range xys {
+		x := float64(i) / n
+		xys[i] = XY{X: x, Y: math.Sin(200*x) + math.Sin(3000*x)/4}
+	}
+
+	render := func(d Decimation) *vgimg.Canvas {
+		p, err := nplot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		l, err := NewLine(xys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		l.Decimation = d
+		p.Add(l)
+		c := vgimg.NewWith(vgimg.UseWH(4*vg.Inch, 3*vg.Inch), vgimg.UseDPI(96))
+		dc := draw.New(c)
+		p.Draw(dc)
+		if d != NoDecimation {
+			if got := DecimateLine(xys, d, p.DataCanvas(dc), p).Len(); got > n/20 {
+				t.Errorf("unexpected number of points decimated by %d: got:%d want:<=%d", d, got, n/20)
+			}
+		}
+		return c
+	}
+
+	want := render(NoDecimation)
+	got := render(MinMaxDecimation)
+	cmp := cmpimg.Comparison{Tolerance: 0.5, MaxDiffFraction: 0.002}
+	if !cmp.EqualImages(got.Image(), want.Image()) {
+		t.Errorf("unexpected image of line decimated by min-max")
+	}
+	if ssim := cmpimg.SSIM(render(LTTBDecimation).Image(), want.Image()); ssim < 0.9 {
+		t.Errorf("unexpected structural similarity of line decimated by LTTB: %v", ssim)
+	}
+}
+
+func TestDecimatedScatterGlyphBoxes(t *testing.T) {
+	const n = 10000
+	xys := make(XYs, n)
+	for i := range xys {
+		x := float64(i) / n
+		xys[i] = XY{X: x, Y: math.Sin(20 * x)}
+	}
+	s, err := NewScatter(xys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The glyphs are larger than the tick labels.
+	s.Radius = vg.Points(20)
+	for _, xmax := range []float64{1, 0.5} {
+		p, err := nplot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(s)
+		p.X.Max = xmax
+		c := draw.New(vgimg.New(4*vg.Inch, 3*vg.Inch))
+
+		s.Decimation = NoDecimation
+		want := p.DataCanvas(c).Rectangle
+		s.Decimation = MinMaxDecimation
+		if got := len(s.GlyphBoxes(p)); got > 4 {
+			t.Errorf("unexpected number of glyph boxes of decimated scatter: got:%d want:<=4", got)
+		}
+		if got := p.DataCanvas(c).Rectangle; got != want {
+			t.Errorf("unexpected data area of decimated scatter for x max %v: got:%v want:%v", xmax, got, want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Weighter wraps the Weight method.  The plotters of
+// distributions, such as KDE, ECDF, QQ and PP, weight
+// the values of a Valuer that is also a Weighter.
+type Weighter interface {
+	// Weight returns the weight of value i.
+	Weight(int) float64
+}
+
+// WeightedValues implements both the Valuer
+// and Weighter interfaces.
+type WeightedValues []struct {
+	Value  float64
+	Weight float64
+}
+
+// Len returns the number of items.
+func (vs WeightedValues) Len() int {
+	return len(vs)
+}
+
+// Value returns the value of item i.
+func (vs WeightedValues) Value(i int) float64 {
+	return vs[i].Value
+}
+
+// Weight returns the weight of item i.
+func (vs WeightedValues) Weight(i int) float64 {
+	return vs[i].Weight
+}
+
+// Distribution is a reference distribution of QQ and PP plots.
+// It is implemented by the distributions of the package
+// gonum.org/v1/gonum/stat/distuv.
+type Distribution interface {
+	// CDF returns the cumulative distribution function at x.
+	CDF(x float64) float64
+
+	// Quantile returns the inverse of the
+	// cumulative distribution function at p.
+	Quantile(p float64) float64
+}
+
+// sample is a sorted sample of weighted values.
+type sample struct {
+	// vs are the sorted values and ws their weights.
+	vs Values
+	ws []float64
+
+	// cum holds the cumulative weights of the
+	// values and total is the sum of the weights.
+	cum   []float64
+	total float64
+}
+
+// newSample returns the sample of the values of vs, weighted if vs
+// is a Weighter.  Values without weight are left out.  An error is
+// returned if a value or a weight is not finite, a weight is
+// negative or the total weight is zero.
+func newSample(vs Valuer) (sample, error) {
+	wr, _ := vs.(Weighter)
+	type entry struct{ v, w float64 }
+	es := make([]entry, 0, vs.Len())
+	for i := 0; i < vs.Len(); i++ {
+		e := entry{v: vs.Value(i), w: 1}
+		if wr != nil {
+			e.w = wr.Weight(i)
+		}
+		if err := CheckFloats(e.v, e.w); err != nil {
+			return sample{}, err
+		}
+		if e.w < 0 {
+			return sample{}, errors.New("plotter: negative weight")
+		}
+		if e.w > 0 {
+			es = append(es, e)
+		}
+	}
+	if len(es) == 0 {
+		return sample{}, errors.New("plotter: no weighted values")
+	}
+	sort.SliceStable(es, func(i, j int) bool { return es[i].v < es[j].v })
+
+	s := sample{
+		vs:  make(Values, len(es)),
+		ws:  make([]float64, len(es)),
+		cum: make([]float64, len(es)),
+	}
+	for i, e := range es {
+		s.vs[i], s.ws[i] = e.v, e.w
+		s.total += e.w
+		s.cum[i] = s.total
+	}
+	return s, nil
+}
+
+// position returns the plotting position of value i, which is the
+// center of its share of the cumulative weight, (i+0.5)/n for equal
+// weights.
+func (s sample) position(i int) float64 {
+	return (s.cum[i] - s.ws[i]/2) / s.total
+}
+
+// quantile returns the quantile of the sample at p, interpolating
+// linearly between the plotting positions of the values.
+func (s sample) quantile(p float64) float64 {
+	i := sort.Search(len(s.vs), func(i int) bool { return s.position(i) >= p })
+	switch {
+	case i == 0:
+		return s.vs[0]
+	case i == len(s.vs):
+		return s.vs[len(s.vs)-1]
+	}
+	p0, p1 := s.position(i-1), s.position(i)
+	return s.vs[i-1] + (p-p0)/(p1-p0)*(s.vs[i]-s.vs[i-1])
+}
+
+// cdf returns the fraction of the weight of the
+// values less than or equal to x.
+func (s sample) cdf(x float64) float64 {
+	i := sort.Search(len(s.vs), func(i int) bool { return s.vs[i] > x })
+	if i == 0 {
+		return 0
+	}
+	return s.cum[i-1] / s.total
+}
+
+// sd returns the weighted standard deviation of the sample,
+// corrected by the effective number of values.
+func (s sample) sd() float64 {
+	var mean float64
+	for i, v := range s.vs {
+		mean += s.ws[i] * v / s.total
+	}
+	var v float64
+	for i, x := range s.vs {
+		d := x - mean
+		v += s.ws[i] * d * d / s.total
+	}
+	n := s.n()
+	if n <= 1 {
+		return 0
+	}
+	return math.Sqrt(v * n / (n - 1))
+}
+
+// n returns the effective number of values of the sample,
+// which is their number for equal weights.
+func (s sample) n() float64 {
+	var sq float64
+	for _, w := range s.ws {
+		sq += w * w
+	}
+	return s.total * s.total / sq
+}
+
+// NewECDF returns a Line drawing the empirical cumulative
+// distribution function of the values vs as steps, weighted if
+// vs is a Weighter.  The line starts at zero at the smallest
+// value and has a step at each distinct value, up to one at
+// the largest value.
+func NewECDF(vs Valuer) (*Line, error) {
+	s, err := newSample(vs)
+	if err != nil {
+		return nil, err
+	}
+	xys := XYs{{X: s.vs[0], Y: 0}}
+	for i, v := range s.vs {
+		if i+1 < len(s.vs) && s.vs[i+1] == v {
+			continue
+		}
+		xys = append(xys, XY{X: v, Y: s.cum[i] / s.total})
+	}
+	l, err := NewLine(xys)
+	if err != nil {
+		return nil, err
+	}
+	l.StepStyle = PostStep
+	return l, nil
+}
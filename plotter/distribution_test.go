@@ -0,0 +1,151 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+func TestSample(t *testing.T) {
+	s, err := newSample(WeightedValues{
+		{Value: 3, Weight: 1},
+		{Value: 1, Weight: 2},
+		{Value: 2, Weight: 0},
+		{Value: 4, Weight: 1},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (Values{1, 3, 4}); !reflect.DeepEqual(s.vs, want) {
+		t.Errorf("unexpected values: got:%v want:%v", s.vs, want)
+	}
+	for _, test := range []struct {
+		x, want float64
+	}{
+		{x: 0, want: 0},
+		{x: 1, want: 0.5},
+		{x: 2, want: 0.5},
+		{x: 3.5, want: 0.75},
+		{x: 4, want: 1},
+	} {
+		if got := s.cdf(test.x); got != test.want {
+			t.Errorf("unexpected cdf at %v: got:%v want:%v", test.x, got, test.want)
+		}
+	}
+	for _, test := range []struct {
+		p, want float64
+	}{
+		{p: 0.1, want: 1},
+		{p: 0.25, want: 1},
+		{p: 0.5, want: 7.0 / 3},
+		{p: 0.625, want: 3},
+		{p: 0.99, want: 4},
+	} {
+		if got := s.quantile(test.p); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected quantile at %v: got:%v want:%v", test.p, got, test.want)
+		}
+	}
+	if got, want := s.n(), 16.0/6; math.Abs(got-want) > 1e-12 {
+		t.Errorf("unexpected effective number: got:%v want:%v", got, want)
+	}
+
+	for _, vs := range []Valuer{
+		Values{},
+		WeightedValues{{Value: 1, Weight: 0}},
+		WeightedValues{{Value: 1, Weight: -1}},
+		Values{math.NaN()},
+	} {
+		if _, err := newSample(vs); err == nil {
+			t.Errorf("expected error for %v", vs)
+		}
+	}
+}
+
+func TestECDF(t *testing.T) {
+	l, err := NewECDF(Values{2, 1, 2, 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := XYs{{X: 1, Y: 0}, {X: 1, Y: 0.25}, {X: 2, Y: 0.75}, {X: 4, Y: 1}}
+	if !reflect.DeepEqual(l.XYs, want) || l.StepStyle != PostStep {
+		t.Errorf("unexpected ECDF: got:%v %v want:%v", l.XYs, l.StepStyle, want)
+	}
+}
+
+func TestKDEWeights(t *testing.T) {
+	// Weights are equivalent to repeated values.
+	weighted, err := NewKDE(WeightedValues{{Value: 1, Weight: 2}, {Value: 3, Weight: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	repeated, err := NewKDE(Values{1, 1, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	weighted.Bandwidth, repeated.Bandwidth = 0.5, 0.5
+	got, want := weighted.Density(), repeated.Density()
+	for i := range want {
+		if math.Abs(got[i].X-want[i].X) > 1e-12 || math.Abs(got[i].Y-want[i].Y) > 1e-12 {
+			t.Fatalf("unexpected density at %d: got:%v want:%v", i, got[i], want[i])
+		}
+	}
+	if xmin, xmax, ymin, _ := weighted.DataRange(); xmin != -0.5 || xmax != 4.5 || ymin != 0 {
+		t.Errorf("unexpected data range: %v %v %v", xmin, xmax, ymin)
+	}
+}
+
+func TestQQ(t *testing.T) {
+	vs := make(Values, 99)
+	for i := range vs {
+		vs[i] = 2*distuv.UnitNormal.Quantile((float64(i)+0.5)/99) + 1
+	}
+	qq, err := NewQQ(vs, distuv.UnitNormal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(qq.Slope-2) > 1e-3 || math.Abs(qq.Intercept-1) > 1e-3 {
+		t.Errorf("unexpected reference line: got:%v + %v x want:1 + 2 x", qq.Intercept, qq.Slope)
+	}
+	for _, p := range qq.XYs {
+		if math.Abs(p.Y-(2*p.X+1)) > 1e-9 {
+			t.Errorf("unexpected point off the reference line: %v", p)
+		}
+	}
+
+	qq, err = NewQQSamples(Values{1, 2, 3, 4}, Values{10, 20, 30, 40, 50, 60, 70, 80})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := XYs{{X: 1, Y: 15}, {X: 2, Y: 35}, {X: 3, Y: 55}, {X: 4, Y: 75}}
+	if !reflect.DeepEqual(qq.XYs, want) {
+		t.Errorf("unexpected sample quantiles: got:%v want:%v", qq.XYs, want)
+	}
+	if qq.Slope != 20 || qq.Intercept != -5 {
+		t.Errorf("unexpected reference line: got:%v + %v x want:-5 + 20 x", qq.Intercept, qq.Slope)
+	}
+}
+
+func TestPP(t *testing.T) {
+	pp, err := NewPP(Values{0}, distuv.UnitNormal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (XYs{{X: 0.5, Y: 0.5}}); !reflect.DeepEqual(pp.XYs, want) {
+		t.Errorf("unexpected PP plot: got:%v want:%v", pp.XYs, want)
+	}
+
+	pp, err = NewPPSamples(Values{1, 2, 2, 3}, Values{2, 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := XYs{{X: 0.25, Y: 0}, {X: 0.75, Y: 0.5}, {X: 1, Y: 0.5}, {X: 1, Y: 1}}
+	if !reflect.DeepEqual(pp.XYs, want) {
+		t.Errorf("unexpected sample PP plot: got:%v want:%v", pp.XYs, want)
+	}
+}
@@ -21,6 +21,11 @@ type Function struct {
 
 	Samples int
 
+	// Decimation is the method used to reduce the
+	// number of samples drawn.  The default is
+	// NoDecimation.
+	Decimation Decimation
+
 	draw.LineStyle
 }
 
@@ -45,13 +50,25 @@ func (f *Function) Plot(c draw.Canvas, p *nplot.Plot) {
 		max = p.X.Max
 	}
 	d := (max - min) / float64(f.Samples-1)
-	line := make([]vg.Point, f.Samples)
+	xys := make(XYs, f.Samples)
+	for i := range xys {
+		xys[i].X = min + float64(i)*d
+		xys[i].Y = f.F(xys[i].X)
+	}
+	var pts XYer = xys
+	sty := f.LineStyle
+	if f.Decimation != NoDecimation {
+		d := DecimateLine(xys, f.Decimation, c, p)
+		sty = decimatedStyle(sty, d)
+		pts = d
+	}
+	line := make([]vg.Point, pts.Len())
 	for i := range line {
-		x := min + float64(i)*d
+		x, y := pts.XY(i)
 		line[i].X = trX(x)
-		line[i].Y = trY(f.F(x))
+		line[i].Y = trY(y)
 	}
-	c.StrokeLines(f.LineStyle, c.ClipLinesXY(line)...)
+	c.StrokeLines(sty, c.ClipLinesXY(line)...)
 }
 
 // Thumbnail draws a line in the given style down the
@@ -0,0 +1,245 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/palette"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// HexagonBin is a hexagon of a HexBin with the
+// total weight of the points within it.
+type HexagonBin struct {
+	// X and Y are the center of the hexagon.
+	X, Y float64
+
+	// Weight is the total weight of the points.
+	Weight float64
+}
+
+// HexBin implements the Plotter interface, drawing the number
+// of points in the hexagons of a honeycomb as the colors of
+// the hexagons.  The hexagons have a vertex at their top and
+// bottom, and only the hexagons containing points are drawn.
+type HexBin struct {
+	// Bins are the hexagons containing points.
+	Bins []HexagonBin
+
+	// Width is the width of the hexagons, which is
+	// the distance of their centers within a row,
+	// and Height is the distance of their top and
+	// bottom vertices, both in data coordinates.
+	Width, Height float64
+
+	// ColorMap maps the weights of the hexagons
+	// to colors.
+	ColorMap palette.ColorMap
+
+	// Log selects a logarithmic mapping of the
+	// weights onto the ColorMap, which requires
+	// its Min to be positive.
+	Log bool
+
+	// LineStyle is the style of the outlines of
+	// the hexagons.  The outlines are not drawn
+	// if the width is zero.
+	draw.LineStyle
+}
+
+// NewHexBin returns a HexBin of the points of xy, weighted if
+// xy is a Weighter, in a honeycomb with n hexagons across the
+// range of the X values.  The height of the hexagons is chosen
+// so that they look regular if the ranges of the points span
+// equal lengths of the axes.  The range of the ColorMap cm is
+// set to the range of the weights of the hexagons.
+//
+// An error is returned if there are no points of positive
+// weight, n is not positive, or a coordinate or weight is
+// not finite.
+func NewHexBin(xy XYer, n int, cm palette.ColorMap) (*HexBin, error) {
+	if n <= 0 {
+		return nil, errors.New("hexbin: non-positive number of hexagons")
+	}
+	wr, _ := xy.(Weighter)
+	for i := 0; i < xy.Len(); i++ {
+		x, y := xy.XY(i)
+		if err := CheckFloats(x, y); err != nil {
+			return nil, err
+		}
+		if wr != nil {
+			if err := CheckFloats(wr.Weight(i)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if xy.Len() == 0 {
+		return nil, ErrNoData
+	}
+
+	xmin, xmax := Range(XValues{xy})
+	ymin, ymax := Range(YValues{xy})
+	w := (xmax - xmin) / float64(n)
+	if w == 0 {
+		w = 1
+	}
+	h := 2 * (ymax - ymin) / (float64(n) * math.Sqrt(3))
+	if h == 0 {
+		h = 2 * w / math.Sqrt(3)
+	}
+
+	type cell struct{ col, row int }
+	weights := make(map[cell]float64)
+	for i := 0; i < xy.Len(); i++ {
+		x, y := xy.XY(i)
+		col, row := hexagon((x-xmin)/w, (y-ymin)/h)
+		wt := 1.0
+		if wr != nil {
+			wt = wr.Weight(i)
+		}
+		weights[cell{col: col, row: row}] += wt
+	}
+	cells := make([]cell, 0, len(weights))
+	for c, wt := range weights {
+		if wt != 0 {
+			cells = append(cells, c)
+		}
+	}
+	if len(cells) == 0 {
+		return nil, ErrNoData
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i].row != cells[j].row {
+			return cells[i].row < cells[j].row
+		}
+		return cells[i].col < cells[j].col
+	})
+
+	hb := &HexBin{
+		Bins:     make([]HexagonBin, len(cells)),
+		Width:    w,
+		Height:   h,
+		ColorMap: cm,
+	}
+	min, max := math.Inf(1), math.Inf(-1)
+	for i, c := range cells {
+		wt := weights[c]
+		hb.Bins[i] = HexagonBin{
+			X:      xmin + (float64(c.col)+float64(c.row&1)/2)*w,
+			Y:      ymin + float64(c.row)*0.75*h,
+			Weight: wt,
+		}
+		min = math.Min(min, wt)
+		max = math.Max(max, wt)
+	}
+	if max == min {
+		max = min + 1
+	}
+	cm.SetMax(max)
+	cm.SetMin(min)
+	return hb, nil
+}
+
+// hexagon returns the column and row of the hexagon containing
+// the point at u widths and v heights from the center of the
+// hexagon in column and row zero.  Odd rows are shifted right by
+// half a width.
+func hexagon(u, v float64) (col, row int) {
+	// Scale the coordinates so that the hexagons are regular
+	// with unit circumradius.  Then the even rows form one
+	// rectangular lattice of centers and the odd rows another,
+	// and the nearest center of both lattices is the one of
+	// the hexagon.
+	dx, dy := math.Sqrt(3), 3.0
+	x, y := u*dx, v*2
+	ex, ey := math.Round(x/dx), math.Round(y/dy)
+	ox, oy := math.Round(x/dx-0.5), math.Round(y/dy-0.5)
+	de := math.Hypot(x-ex*dx, y-ey*dy)
+	do := math.Hypot(x-(ox+0.5)*dx, y-(oy+0.5)*dy)
+	if de <= do {
+		return int(ex), 2 * int(ey)
+	}
+	return int(ox), 2*int(oy) + 1
+}
+
+// colorMap returns the ColorMap with the scaling of the weights.
+func (h *HexBin) colorMap() palette.ColorMap {
+	if h.Log {
+		return palette.Log(h.ColorMap)
+	}
+	return h.ColorMap
+}
+
+// vertices returns the corners of the hexagon centered at x, y.
+func (h *HexBin) vertices(x, y float64) [6]XY {
+	w, v := h.Width/2, h.Height/4
+	return [6]XY{
+		{X: x, Y: y - 2*v},
+		{X: x + w, Y: y - v},
+		{X: x + w, Y: y + v},
+		{X: x, Y: y + 2*v},
+		{X: x - w, Y: y + v},
+		{X: x - w, Y: y - v},
+	}
+}
+
+// Plot implements the Plot method of the nplot.Plotter interface.
+// Hexagons with weights outside the range of the ColorMap are not
+// drawn.
+func (h *HexBin) Plot(c draw.Canvas, plt *nplot.Plot) {
+	cm := h.colorMap()
+	trX, trY := plt.Transforms(&c)
+	pts := make([]vg.Point, 6)
+	for _, b := range h.Bins {
+		col, err := cm.At(b.Weight)
+		if err != nil {
+			continue
+		}
+		for i, p := range h.vertices(b.X, b.Y) {
+			pts[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+		}
+		c.FillPolygon(col, c.ClipPolygonXY(pts))
+		if h.LineStyle.Width != 0 {
+			c.StrokeLines(h.LineStyle, c.ClipLinesXY(append(pts, pts[0]))...)
+		}
+	}
+}
+
+// DataRange implements the DataRange method
+// of the nplot.DataRanger interface.
+func (h *HexBin) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for _, b := range h.Bins {
+		xmin = math.Min(xmin, b.X-h.Width/2)
+		xmax = math.Max(xmax, b.X+h.Width/2)
+		ymin = math.Min(ymin, b.Y-h.Height/2)
+		ymax = math.Max(ymax, b.Y+h.Height/2)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// ColorBar returns a ColorBar showing the colors of the weights.
+// If Log is true, the ColorBar shows the colors in bands between
+// logarithmically spaced levels, and the axis of the ColorBar's
+// plot along the weights should have a nplot.LogScale.
+func (h *HexBin) ColorBar() *ColorBar {
+	cb := &ColorBar{ColorMap: h.colorMap()}
+	if h.Log {
+		const n = 256
+		min, max := h.ColorMap.Min(), h.ColorMap.Max()
+		cb.Levels = make([]float64, n+1)
+		for i := range cb.Levels {
+			cb.Levels[i] = min * math.Pow(max/min, float64(i)/n)
+		}
+		cb.Levels[n] = max
+	}
+	return cb
+}
@@ -0,0 +1,103 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/hneemann/nplot/palette/moreland"
+)
+
+func TestHist2D(t *testing.T) {
+	h, err := NewHist2D(XYs{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1.9, Y: 0.9}}, 2, 2, moreland.Kindlmann().Palette(8))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b := h.GridXYZ.(*Bins2D)
+	if want := []float64{1, 2, 0, 2}; !reflect.DeepEqual(b.Weights, want) {
+		t.Errorf("unexpected weights: got:%v want:%v", b.Weights, want)
+	}
+	if b.X(1) != 1.5 || b.Y(0) != 0.25 || b.Z(1, 0) != 2 {
+		t.Errorf("unexpected grid: X(1)=%v Y(0)=%v Z(1, 0)=%v", b.X(1), b.Y(0), b.Z(1, 0))
+	}
+	if h.Min != 0 || h.Max != 2 {
+		t.Errorf("unexpected range: got:%v %v want:0 2", h.Min, h.Max)
+	}
+	if xmin, xmax, ymin, ymax := h.DataRange(); xmin != 0 || xmax != 2 || ymin != 0 || ymax != 1 {
+		t.Errorf("unexpected data range: %v %v %v %v", xmin, xmax, ymin, ymax)
+	}
+
+	for _, xy := range []XYs{{}, {{X: math.NaN(), Y: 0}}} {
+		if _, err := NewHist2D(xy, 2, 2, moreland.Kindlmann().Palette(8)); err == nil {
+			t.Errorf("expected error for %v", xy)
+		}
+	}
+}
+
+func TestHexagon(t *testing.T) {
+	for _, test := range []struct {
+		u, v     float64
+		col, row int
+	}{
+		{u: 0, v: 0, col: 0, row: 0},
+		{u: 0.4, v: 0.2, col: 0, row: 0},
+		{u: 0.6, v: 0, col: 1, row: 0},
+		{u: 0.5, v: 0.75, col: 0, row: 1},
+		{u: -0.5, v: 0.75, col: -1, row: 1},
+		{u: 0.5, v: 0.45, col: 0, row: 1},
+		{u: 0.1, v: 0.4, col: 0, row: 0},
+		{u: 0, v: 1.5, col: 0, row: 2},
+		{u: 1.5, v: -0.75, col: 1, row: -1},
+	} {
+		if col, row := hexagon(test.u, test.v); col != test.col || row != test.row {
+			t.Errorf("unexpected hexagon at (%v, %v): got:(%d, %d) want:(%d, %d)",
+				test.u, test.v, col, row, test.col, test.row)
+		}
+	}
+}
+
+func TestHexBin(t *testing.T) {
+	xys := XYs{{X: 0, Y: 0}, {X: 0.1, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 3.95, Y: 4}, {X: 4, Y: 3.9}}
+	cm := moreland.Kindlmann()
+	h, err := NewHexBin(xys, 4, cm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h.Width != 1 || math.Abs(h.Height-2/math.Sqrt(3)) > 1e-12 {
+		t.Errorf("unexpected size: got:%v %v", h.Width, h.Height)
+	}
+	if len(h.Bins) != 3 {
+		t.Fatalf("unexpected number of hexagons: got:%d want:3", len(h.Bins))
+	}
+	var weights []float64
+	for _, b := range h.Bins {
+		weights = append(weights, b.Weight)
+	}
+	if want := []float64{2, 1, 3}; !reflect.DeepEqual(weights, want) {
+		t.Errorf("unexpected weights: got:%v want:%v", weights, want)
+	}
+	if cm.Min() != 1 || cm.Max() != 3 {
+		t.Errorf("unexpected color map range: got:%v %v want:1 3", cm.Min(), cm.Max())
+	}
+
+	cb := h.ColorBar()
+	if cb.Levels != nil || cb.ColorMap != cm {
+		t.Errorf("unexpected linear color bar")
+	}
+	h.Log = true
+	cb = h.ColorBar()
+	if n := len(cb.Levels); n < 2 || cb.Levels[0] != 1 || cb.Levels[n-1] != 3 {
+		t.Errorf("unexpected logarithmic color bar levels: %v", cb.Levels)
+	}
+	if math.Abs(cb.Levels[128]-math.Sqrt(3)) > 1e-12 {
+		t.Errorf("unexpected middle level: got:%v want:%v", cb.Levels[128], math.Sqrt(3))
+	}
+
+	if _, err := NewHexBin(XYs{}, 4, cm); err == nil {
+		t.Errorf("expected error for no points")
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+
+	"github.com/hneemann/nplot/palette"
+)
+
+// Bins2D implements the GridXYZ interface for the weights of
+// points in the rectangular bins of a two-dimensional histogram.
+// The X and Y coordinates of the grid are the centers of the bins.
+type Bins2D struct {
+	// Origin is the lower left corner of the first bin.
+	Origin XY
+
+	// Width and Height are the dimensions of the bins.
+	Width, Height float64
+
+	// Cols and Rows are the numbers of bins
+	// along the X and Y axes.
+	Cols, Rows int
+
+	// Weights are the total weights of the points in
+	// the bins, row by row from the bottom, so that
+	// Weights[r*Cols+c] is the weight of bin (c, r).
+	Weights []float64
+}
+
+// NewBins2D returns the bins of the points of xy, divided into
+// cols by rows bins of equal size spanning the range of the points.
+// The points are counted with their weights if xy is a Weighter.
+//
+// An error is returned if there are no points, the numbers of
+// bins are not positive, or a coordinate or weight is not finite.
+func NewBins2D(xy XYer, cols, rows int) (*Bins2D, error) {
+	if cols <= 0 || rows <= 0 {
+		return nil, errors.New("hist2d: non-positive number of bins")
+	}
+	if xy.Len() == 0 {
+		return nil, ErrNoData
+	}
+	wr, _ := xy.(Weighter)
+	for i := 0; i < xy.Len(); i++ {
+		x, y := xy.XY(i)
+		if err := CheckFloats(x, y); err != nil {
+			return nil, err
+		}
+		if wr != nil {
+			if err := CheckFloats(wr.Weight(i)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	xmin, xmax := Range(XValues{xy})
+	ymin, ymax := Range(YValues{xy})
+	b := &Bins2D{
+		Origin:  XY{X: xmin, Y: ymin},
+		Width:   (xmax - xmin) / float64(cols),
+		Height:  (ymax - ymin) / float64(rows),
+		Cols:    cols,
+		Rows:    rows,
+		Weights: make([]float64, cols*rows),
+	}
+	if b.Width == 0 {
+		b.Width = 1
+	}
+	if b.Height == 0 {
+		b.Height = 1
+	}
+	for i := 0; i < xy.Len(); i++ {
+		x, y := xy.XY(i)
+		c := binIndex(x, xmin, b.Width, cols)
+		r := binIndex(y, ymin, b.Height, rows)
+		w := 1.0
+		if wr != nil {
+			w = wr.Weight(i)
+		}
+		b.Weights[r*cols+c] += w
+	}
+	return b, nil
+}
+
+// binIndex returns the index of the bin of width w holding v,
+// counting values at the upper end into the last of n bins.
+func binIndex(v, min, w float64, n int) int {
+	i := int((v - min) / w)
+	if i >= n {
+		i = n - 1
+	}
+	return i
+}
+
+// Dims implements the Dims method of the GridXYZ interface.
+func (b *Bins2D) Dims() (c, r int) { return b.Cols, b.Rows }
+
+// Z implements the Z method of the GridXYZ interface.
+func (b *Bins2D) Z(c, r int) float64 {
+	if c < 0 || c >= b.Cols || r < 0 || r >= b.Rows {
+		panic("hist2d: index out of range")
+	}
+	return b.Weights[r*b.Cols+c]
+}
+
+// X implements the X method of the GridXYZ interface.
+func (b *Bins2D) X(c int) float64 {
+	if c < 0 || c >= b.Cols {
+		panic("hist2d: index out of range")
+	}
+	return b.Origin.X + (float64(c)+0.5)*b.Width
+}
+
+// Y implements the Y method of the GridXYZ interface.
+func (b *Bins2D) Y(r int) float64 {
+	if r < 0 || r >= b.Rows {
+		panic("hist2d: index out of range")
+	}
+	return b.Origin.Y + (float64(r)+0.5)*b.Height
+}
+
+// NewHist2D returns a HeatMap drawing a two-dimensional histogram
+// of the points of xy, weighted if xy is a Weighter, in cols by
+// rows bins as returned by NewBins2D.  The dynamic range of the
+// heat map is from zero to the largest weight of a bin.  Empty
+// bins are left unfilled if Min is raised above zero and
+// Underflow is nil.
+//
+// A ColorBar of the ColorMap from which the palette p was made,
+// with its range set to Min and Max of the heat map, shows the
+// weights of the colors.
+func NewHist2D(xy XYer, cols, rows int, p palette.Palette) (*HeatMap, error) {
+	b, err := NewBins2D(xy, cols, rows)
+	if err != nil {
+		return nil, err
+	}
+	max := 0.0
+	for _, w := range b.Weights {
+		max = math.Max(max, w)
+	}
+	return &HeatMap{
+		GridXYZ: b,
+		Palette: p,
+		Min:     0,
+		Max:     max,
+	}, nil
+}
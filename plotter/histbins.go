@@ -0,0 +1,206 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// BinRule is a rule choosing the number of
+// bins of a histogram of a sample.
+type BinRule int
+
+const (
+	// SqrtBins uses the square root of
+	// the number of values as count.
+	SqrtBins BinRule = iota
+
+	// SturgesBins uses log₂(n)+1 bins, which
+	// is suited to normally distributed values.
+	SturgesBins
+
+	// ScottBins uses bins of the width 3.49 σ n^(-1/3).
+	ScottBins
+
+	// FreedmanDiaconisBins uses bins of the width
+	// 2 IQR n^(-1/3), which is robust against
+	// outliers.
+	FreedmanDiaconisBins
+)
+
+// Bins returns the number of bins chosen by the rule for the
+// values vs, which are counted with their weights if vs is a
+// Weighter, where n is the total weight, σ the standard deviation
+// and IQR the interquartile range of the values.  The result can
+// be passed to NewHist.  Bins returns one if vs has no values or
+// the rule yields no positive width.
+func (r BinRule) Bins(vs Valuer) int {
+	s, err := newSample(vs)
+	if err != nil {
+		return 1
+	}
+	n := s.total
+	span := s.vs[len(s.vs)-1] - s.vs[0]
+
+	var bins float64
+	switch r {
+	case SqrtBins:
+		bins = math.Sqrt(n)
+	case SturgesBins:
+		bins = math.Log2(n) + 1
+	case ScottBins:
+		bins = span / (3.49 * s.sd() * math.Cbrt(1/n))
+	case FreedmanDiaconisBins:
+		iqr := s.quantile(0.75) - s.quantile(0.25)
+		bins = span / (2 * iqr * math.Cbrt(1/n))
+	default:
+		panic("histogram: unknown bin rule")
+	}
+	if math.IsNaN(bins) || math.IsInf(bins, 0) || bins < 1 {
+		return 1
+	}
+	return int(math.Ceil(bins))
+}
+
+// widthTolerance is the relative tolerance of the
+// widths of bins considered equal by NewHistogramEdges.
+const widthTolerance = 1e-9
+
+// NewHistogramEdges returns a new histogram of the values
+// of xy, as in NewHistogram, with the bins between the
+// given edges.  A value equal to an edge belongs to the bin
+// above it, and a value equal to the last edge to the last
+// bin.  The weights of the values outside the edges are
+// recorded as Underflow and Overflow.
+//
+// An error is returned if there are less than two edges, the
+// edges are not finite and strictly increasing, or a value or
+// weight is not finite.
+func NewHistogramEdges(xy XYer, edges []float64) (*Histogram, error) {
+	if len(edges) < 2 {
+		return nil, errors.New("histogram: less than two edges")
+	}
+	for i, e := range edges {
+		if err := CheckFloats(e); err != nil {
+			return nil, err
+		}
+		if i > 0 && e <= edges[i-1] {
+			return nil, errors.New("histogram: edges not increasing")
+		}
+	}
+	n := len(edges) - 1
+	h := &Histogram{
+		Bins:      make([]HistogramBin, n),
+		FillColor: color.Gray{128},
+		LineStyle: DefaultLineStyle,
+	}
+	for i := range h.Bins {
+		h.Bins[i].Min, h.Bins[i].Max = edges[i], edges[i+1]
+	}
+	// Equally spaced edges computed in floating point,
+	// like 0.1, 0.2 and 0.3, differ slightly in width.
+	h.Width = (edges[n] - edges[0]) / float64(n)
+	for _, b := range h.Bins {
+		if math.Abs(b.Max-b.Min-h.Width) > widthTolerance*h.Width {
+			h.Width = 0
+			break
+		}
+	}
+
+	for i := 0; i < xy.Len(); i++ {
+		x, y := xy.XY(i)
+		if err := CheckFloats(x, y); err != nil {
+			return nil, err
+		}
+		switch {
+		case x < edges[0]:
+			h.Underflow += y
+		case x > edges[n]:
+			h.Overflow += y
+		case x == edges[n]:
+			h.Bins[n-1].Weight += y
+		default:
+			bin := sort.SearchFloat64s(edges, x)
+			if edges[bin] != x {
+				bin--
+			}
+			h.Bins[bin].Weight += y
+		}
+	}
+	return h, nil
+}
+
+// NewHistEdges returns a new histogram, as in
+// NewHistogramEdges, except that it accepts a Valuer
+// instead of an XYer.  If vs is a Weighter, the values
+// are counted with their weights.
+func NewHistEdges(vs Valuer, edges []float64) (*Histogram, error) {
+	return NewHistogramEdges(unitYs{vs}, edges)
+}
+
+// Cumulative replaces the weight of each bin by the sum of
+// the Underflow and the weights of the bin and the bins
+// before it, and scales the weights so that the sum of all
+// weights, including the Overflow, is the given value.  If
+// sum is zero, the weights are not scaled.
+func (h *Histogram) Cumulative(sum float64) {
+	acc := h.Underflow
+	for i := range h.Bins {
+		acc += h.Bins[i].Weight
+		h.Bins[i].Weight = acc
+	}
+	total := acc + h.Overflow
+	if sum == 0 || total == 0 {
+		return
+	}
+	for i := range h.Bins {
+		h.Bins[i].Weight *= sum / total
+	}
+	h.Underflow *= sum / total
+	h.Overflow *= sum / total
+}
+
+// plotOutline draws the histogram as a step line along
+// the tops of the bins, dropping to the bottom of the
+// canvas at its ends and at gaps between the bins.
+func (h *Histogram) plotOutline(c draw.Canvas, trX, trY func(float64) vg.Length) {
+	y := func(w float64) vg.Length {
+		if w == 0 {
+			return c.Min.Y
+		}
+		return trY(w)
+	}
+	var outlines [][]vg.Point
+	var pts []vg.Point
+	for i, bin := range h.Bins {
+		xmin, xmax := trX(bin.Min), trX(bin.Max)
+		if i > 0 && bin.Min != h.Bins[i-1].Max {
+			pts = append(pts, vg.Point{X: trX(h.Bins[i-1].Max), Y: c.Min.Y})
+			outlines = append(outlines, pts)
+			pts = nil
+		}
+		if len(pts) == 0 {
+			pts = append(pts, vg.Point{X: xmin, Y: c.Min.Y})
+		}
+		pts = append(pts, vg.Point{X: xmin, Y: y(bin.Weight)}, vg.Point{X: xmax, Y: y(bin.Weight)})
+	}
+	if len(pts) != 0 {
+		pts = append(pts, vg.Point{X: trX(h.Bins[len(h.Bins)-1].Max), Y: c.Min.Y})
+		outlines = append(outlines, pts)
+	}
+
+	for _, pts := range outlines {
+		if h.FillColor != nil || h.FillStyle != nil {
+			c.FillPolygonStyle(h.FillColor, h.FillStyle, c.ClipPolygonXY(pts))
+		}
+		c.StrokeLines(h.LineStyle, c.ClipLinesXY(pts)...)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/vg/draw"
+	"github.com/hneemann/nplot/vg/recorder"
+)
+
+func TestBinRules(t *testing.T) {
+	vs := make(Values, 100)
+	for i := range vs {
+		vs[i] = float64(i)
+	}
+	sd := math.Sqrt(100 * 101 / 12.0)
+	for _, test := range []struct {
+		rule BinRule
+		vs   Valuer
+		want int
+	}{
+		{rule: SqrtBins, vs: vs, want: 10},
+		{rule: SturgesBins, vs: vs, want: 8},
+		{rule: ScottBins, vs: vs, want: int(math.Ceil(99 / (3.49 * sd / math.Cbrt(100))))},
+		{rule: FreedmanDiaconisBins, vs: vs, want: int(math.Ceil(99 / (2 * 50 / math.Cbrt(100))))},
+		{rule: SqrtBins, vs: WeightedValues{{Value: 0, Weight: 10}, {Value: 1, Weight: 6}}, want: 4},
+		{rule: FreedmanDiaconisBins, vs: Values{1, 1, 1}, want: 1},
+		{rule: SturgesBins, vs: Values{}, want: 1},
+	} {
+		if got := test.rule.Bins(test.vs); got != test.want {
+			t.Errorf("unexpected number of bins of rule %d: got:%d want:%d", test.rule, got, test.want)
+		}
+	}
+}
+
+func TestHistogramEdges(t *testing.T) {
+	vs := WeightedValues{
+		{Value: -1, Weight: 1},
+		{Value: 0, Weight: 2},
+		{Value: 0.5, Weight: 1},
+		{Value: 1, Weight: 1},
+		{Value: 3, Weight: 4},
+		{Value: 3.5, Weight: 8},
+	}
+	h, err := NewHistEdges(vs, []float64{0, 1, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []HistogramBin{{Min: 0, Max: 1, Weight: 3}, {Min: 1, Max: 3, Weight: 5}}
+	if !reflect.DeepEqual(h.Bins, want) || h.Underflow != 1 || h.Overflow != 8 || h.Width != 0 {
+		t.Errorf("unexpected histogram: got:%v %v %v %v want:%v 1 8 0", h.Bins, h.Underflow, h.Overflow, h.Width, want)
+	}
+
+	h.Normalize(1)
+	if h.Bins[0].Weight != 3.0/8 || h.Bins[1].Weight != 5.0/16 {
+		t.Errorf("unexpected density: got:%v", h.Bins)
+	}
+
+	h, err = NewHistEdges(vs, []float64{0, 1, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h.Cumulative(1)
+	if h.Bins[0].Weight != 4.0/17 || h.Bins[1].Weight != 9.0/17 || h.Overflow != 8.0/17 {
+		t.Errorf("unexpected cumulative histogram: got:%v %v", h.Bins, h.Overflow)
+	}
+
+	// The widths of these bins differ by rounding.
+	h, err = NewHistEdges(vs, []float64{0.1, 0.2, 0.3, 0.4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(h.Width-0.1) > 1e-15 {
+		t.Errorf("unexpected width of equal bins: got:%v want:0.1", h.Width)
+	}
+
+	for _, edges := range [][]float64{{0}, {0, 0}, {1, 0}, {0, math.NaN()}} {
+		if _, err := NewHistEdges(vs, edges); err == nil {
+			t.Errorf("expected error for edges %v", edges)
+		}
+	}
+	for _, vs := range []Valuer{Values{1, math.NaN()}, Values{math.Inf(-1)}, WeightedValues{{Value: 1, Weight: math.NaN()}}} {
+		if _, err := NewHistEdges(vs, []float64{0, 1, 2}); err == nil {
+			t.Errorf("expected error for values %v", vs)
+		}
+	}
+}
+
+func TestHistogramOutline(t *testing.T) {
+	h, err := NewHist(Values{0, 1, 1, 3}, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h.Outline = true
+	h.FillColor = nil
+	p, err := nplot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 3
+	p.Y.Min, p.Y.Max = 0, 3
+
+	var rec recorder.Canvas
+	h.Plot(draw.NewCanvas(&rec, 30, 30), p)
+	var strokes [][]float64
+	for _, a := range rec.Actions {
+		if a, ok := a.(*recorder.Stroke); ok {
+			var ys []float64
+			for _, c := range a.Path {
+				ys = append(ys, float64(c.Pos.Y))
+			}
+			strokes = append(strokes, ys)
+		}
+	}
+	want := [][]float64{{0, 10, 10, 20, 20, 0, 0, 10, 10, 0}}
+	if !reflect.DeepEqual(strokes, want) {
+		t.Errorf("unexpected outline: got:%v want:%v", strokes, want)
+	}
+}
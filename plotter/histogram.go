@@ -21,18 +21,36 @@ type Histogram struct {
 	// Bins is the set of bins for this histogram.
 	Bins []HistogramBin
 
-	// Width is the width of each bin.
+	// Width is the width of each bin, or zero
+	// if the bins differ in width.
 	Width float64
 
+	// Underflow and Overflow are the weights of
+	// the values below the first and above the
+	// last of the explicit edges of the bins.
+	// They are not drawn.
+	Underflow, Overflow float64
+
 	// FillColor is the color used to fill each
 	// bar of the histogram.  If the color is nil
 	// then the bars are not filled.
 	FillColor color.Color
 
+	// FillStyle, if not nil, is used to fill
+	// each bar of the histogram instead of FillColor.
+	FillStyle vg.FillStyle
+
 	// LineStyle is the style of the outline of each
 	// bar of the histogram.
 	draw.LineStyle
 
+	// Outline draws the histogram as a single step
+	// line along the tops of the bins instead of
+	// separate bars, which allows to overlay several
+	// histograms.  The area below the line is filled
+	// as the bars are.
+	Outline bool
+
 	// LogY allows rendering with a log-scaled Y axis.
 	// When enabled, histogram bins with no entries will be discarded from
 	// the histogram's DataRange.
@@ -66,7 +84,8 @@ func NewHistogram(xy XYer, n int) (*Histogram, error) {
 
 // NewHist returns a new histogram, as in
 // NewHistogram, except that it accepts a Valuer
-// instead of an XYer.
+// instead of an XYer.  If vs is a Weighter, the
+// values are counted with their weights.
 func NewHist(vs Valuer, n int) (*Histogram, error) {
 	return NewHistogram(unitYs{vs}, n)
 }
@@ -76,6 +95,9 @@ type unitYs struct {
 }
 
 func (u unitYs) XY(i int) (float64, float64) {
+	if w, ok := u.Valuer.(Weighter); ok {
+		return u.Value(i), w.Weight(i)
+	}
 	return u.Value(i), 1.0
 }
 
@@ -83,6 +105,10 @@ func (u unitYs) XY(i int) (float64, float64) {
 // that connects each point in the Line.
 func (h *Histogram) Plot(c draw.Canvas, p *nplot.Plot) {
 	trX, trY := p.Transforms(&c)
+	if h.Outline {
+		h.plotOutline(c, trX, trY)
+		return
+	}
 
 	for _, bin := range h.Bins {
 		ymin := c.Min.Y
@@ -98,8 +124,8 @@ func (h *Histogram) Plot(c draw.Canvas, p *nplot.Plot) {
 			{xmax, ymax},
 			{xmin, ymax},
 		}
-		if h.FillColor != nil {
-			c.FillPolygon(h.FillColor, c.ClipPolygonXY(pts))
+		if h.FillColor != nil || h.FillStyle != nil {
+			c.FillPolygonStyle(h.FillColor, h.FillStyle, c.ClipPolygonXY(pts))
 		}
 		pts = append(pts, vg.Point{X: xmin, Y: ymin})
 		c.StrokeLines(h.LineStyle, c.ClipLinesXY(pts)...)
@@ -143,14 +169,16 @@ func (h *Histogram) DataRange() (xmin, xmax, ymin, ymax float64) {
 }
 
 // Normalize normalizes the histogram so that the
-// total area beneath it sums to a given value.
+// total area beneath it sums to a given value.  The
+// weight of each bin is divided by its width, so that
+// Normalize(1) yields a density for bins of any width.
 func (h *Histogram) Normalize(sum float64) {
 	mass := 0.0
 	for _, b := range h.Bins {
 		mass += b.Weight
 	}
-	for i := range h.Bins {
-		h.Bins[i].Weight *= sum / (h.Width * mass)
+	for i, b := range h.Bins {
+		h.Bins[i].Weight *= sum / ((b.Max - b.Min) * mass)
 	}
 }
 
@@ -167,8 +195,8 @@ func (h *Histogram) Thumbnail(c *draw.Canvas) {
 		{xmax, ymax},
 		{xmin, ymax},
 	}
-	if h.FillColor != nil {
-		c.FillPolygon(h.FillColor, c.ClipPolygonXY(pts))
+	if h.FillColor != nil || h.FillStyle != nil {
+		c.FillPolygonStyle(h.FillColor, h.FillStyle, c.ClipPolygonXY(pts))
 	}
 	pts = append(pts, vg.Point{X: xmin, Y: ymin})
 	c.StrokeLines(h.LineStyle, c.ClipLinesXY(pts)...)
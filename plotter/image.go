@@ -5,6 +5,7 @@
 package plotter
 
 import (
+	"errors"
 	"image"
 	"math"
 
@@ -65,6 +66,45 @@ func (img *Image) DataRange() (xmin, xmax, ymin, ymax float64) {
 	return img.xmin, img.xmax, img.ymin, img.ymax
 }
 
+// imageData is the serialized form of an Image.
+type imageData struct {
+	Image                  image.Image
+	XMin, YMin, XMax, YMax float64
+}
+
+// JSONProxy implements the nplot.JSONProxy interface.
+// The image plotter is encoded as the arguments of NewImage.
+func (img *Image) JSONProxy() interface{} {
+	return &imageData{
+		Image: img.img,
+		XMin:  img.xmin,
+		YMin:  img.ymin,
+		XMax:  img.xmax,
+		YMax:  img.ymax,
+	}
+}
+
+// SetJSONProxy implements the nplot.JSONProxy interface.
+func (img *Image) SetJSONProxy(proxy interface{}) error {
+	d := proxy.(*imageData)
+	if d.Image == nil {
+		return errors.New("plotter: missing image")
+	}
+	*img = *NewImage(d.Image, d.XMin, d.YMin, d.XMax, d.YMax)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The image plotter is encoded by nplot.EncodeJSON.
+func (img *Image) MarshalJSON() ([]byte, error) {
+	return nplot.EncodeJSON(img)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (img *Image) UnmarshalJSON(data []byte) error {
+	return nplot.DecodeJSON(data, img)
+}
+
 // GlyphBoxes implements the GlyphBoxes method
 // of the nplot.GlyphBoxer interface.
 func (img *Image) GlyphBoxes(plt *nplot.Plot) []nplot.GlyphBox {
@@ -0,0 +1,265 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// Kernel is the kernel function of a kernel density estimate.
+type Kernel int
+
+const (
+	// GaussianKernel is the density of the
+	// standard normal distribution.
+	GaussianKernel Kernel = iota
+
+	// EpanechnikovKernel is the parabolic kernel
+	// 3/4 (1-u²) for |u| ≤ 1, which is zero
+	// outside of one bandwidth.
+	EpanechnikovKernel
+)
+
+// weight returns the weight of the kernel at u bandwidths
+// from a value.
+func (k Kernel) weight(u float64) float64 {
+	switch k {
+	case GaussianKernel:
+		return math.Exp(-u*u/2) / math.Sqrt(2*math.Pi)
+	case EpanechnikovKernel:
+		if math.Abs(u) > 1 {
+			return 0
+		}
+		return 0.75 * (1 - u*u)
+	}
+	panic("kde: unknown kernel")
+}
+
+// support returns the number of bandwidths beyond
+// which the weight of the kernel is negligible.
+func (k Kernel) support() float64 {
+	if k == EpanechnikovKernel {
+		return 1
+	}
+	return 8
+}
+
+// BandwidthRule is a rule of thumb choosing the
+// bandwidth of a kernel density estimate.
+type BandwidthRule int
+
+const (
+	// SilvermanBandwidth is Silverman's rule of thumb,
+	// 0.9 min(σ, IQR/1.34) n^(-1/5).
+	SilvermanBandwidth BandwidthRule = iota
+
+	// ScottBandwidth is Scott's rule of thumb,
+	// 1.06 σ n^(-1/5).
+	ScottBandwidth
+)
+
+// Bandwidth returns the bandwidth chosen by the rule for the
+// values vs, weighted if vs is a Weighter, where σ is their
+// standard deviation, IQR their interquartile range and n their
+// effective number.  If the rule yields zero, as for equal
+// values, or vs has no values, Bandwidth returns one.
+func (r BandwidthRule) Bandwidth(vs Valuer) float64 {
+	s, err := newSample(vs)
+	if err != nil {
+		return 1
+	}
+	return r.bandwidth(s)
+}
+
+// bandwidth returns the bandwidth chosen by the rule for s.
+func (r BandwidthRule) bandwidth(s sample) float64 {
+	sd := s.sd()
+	n := s.n()
+	var h float64
+	switch r {
+	case SilvermanBandwidth:
+		iqr := s.quantile(0.75) - s.quantile(0.25)
+		if iqr > 0 && iqr/1.34 < sd {
+			sd = iqr / 1.34
+		}
+		h = 0.9 * sd * math.Pow(n, -0.2)
+	case ScottBandwidth:
+		h = 1.06 * sd * math.Pow(n, -0.2)
+	default:
+		panic("kde: unknown bandwidth rule")
+	}
+	if h == 0 || math.IsNaN(h) {
+		return 1
+	}
+	return h
+}
+
+// kde is a kernel density estimate of a sample.
+type kde struct {
+	s      sample
+	kernel Kernel
+	h      float64
+}
+
+// at returns the estimated density at x.  Only the
+// values within the support of the kernel are summed.
+func (e kde) at(x float64) float64 {
+	r := e.kernel.support() * e.h
+	i := sort.SearchFloat64s(e.s.vs, x-r)
+	var d float64
+	for j, v := range e.s.vs[i:] {
+		if v > x+r {
+			break
+		}
+		d += e.s.ws[i+j] * e.kernel.weight((x-v)/e.h)
+	}
+	return d / (e.s.total * e.h)
+}
+
+// KDE implements the Plotter interface, drawing the kernel
+// density estimate of a distribution of values as a line.
+type KDE struct {
+	// Values is a copy of the values.
+	Values
+
+	// Weights are the weights of the values.  If
+	// Weights is nil, all values weigh the same.
+	Weights []float64
+
+	// Kernel is the kernel of the density estimate.
+	Kernel Kernel
+
+	// Bandwidth is the bandwidth of the kernel.  If
+	// Bandwidth is zero, it is chosen by BandwidthRule.
+	Bandwidth float64
+
+	// BandwidthRule chooses the bandwidth if
+	// Bandwidth is zero.
+	BandwidthRule BandwidthRule
+
+	// Cut is the number of bandwidths by which the
+	// line extends beyond the extreme values.
+	Cut float64
+
+	// Samples is the number of points at which the
+	// density is evaluated.
+	Samples int
+
+	// LineStyle is the style of the line.
+	draw.LineStyle
+
+	// FillColor is the color of the area below the
+	// line.  If FillColor is nil, the area is not
+	// filled.
+	FillColor color.Color
+}
+
+// NewKDE returns a KDE of the values vs, weighted if vs is a
+// Weighter, using a Gaussian kernel with Silverman's bandwidth,
+// cut at three bandwidths beyond the extreme values.
+//
+// An error is returned if vs has no values of positive weight,
+// or a value or weight is not finite.
+func NewKDE(vs Valuer) (*KDE, error) {
+	s, err := newSample(vs)
+	if err != nil {
+		return nil, err
+	}
+	k := &KDE{
+		Values:        s.vs,
+		Kernel:        GaussianKernel,
+		BandwidthRule: SilvermanBandwidth,
+		Cut:           3,
+		Samples:       200,
+		LineStyle:     DefaultLineStyle,
+	}
+	if _, ok := vs.(Weighter); ok {
+		k.Weights = s.ws
+	}
+	return k, nil
+}
+
+// estimate returns the density estimate of the KDE.
+func (k *KDE) estimate() kde {
+	var vs Valuer = k.Values
+	if k.Weights != nil {
+		vs = weightedValues{vs: k.Values, ws: k.Weights}
+	}
+	s, err := newSample(vs)
+	if err != nil {
+		panic(err)
+	}
+	h := k.Bandwidth
+	if h <= 0 {
+		h = k.BandwidthRule.bandwidth(s)
+	}
+	return kde{s: s, kernel: k.Kernel, h: h}
+}
+
+// Density returns the points of the line,
+// the values and their estimated densities.
+func (k *KDE) Density() XYs {
+	return k.estimate().density(k.Cut, k.Samples)
+}
+
+// density returns the density at n points from cut bandwidths
+// below the smallest value to cut bandwidths above the largest.
+func (e kde) density(cut float64, n int) XYs {
+	min := e.s.vs[0] - cut*e.h
+	max := e.s.vs[len(e.s.vs)-1] + cut*e.h
+	if n < 2 {
+		n = 2
+	}
+	xys := make(XYs, n)
+	for i := range xys {
+		x := min + (max-min)*float64(i)/float64(n-1)
+		xys[i] = XY{X: x, Y: e.at(x)}
+	}
+	return xys
+}
+
+// line returns the Line drawing the KDE.
+func (k *KDE) line() *Line {
+	return &Line{
+		XYs:       k.Density(),
+		LineStyle: k.LineStyle,
+		FillColor: k.FillColor,
+	}
+}
+
+// Plot implements the Plotter interface, drawing the line
+// and filling the area below it.
+func (k *KDE) Plot(c draw.Canvas, plt *nplot.Plot) {
+	k.line().Plot(c, plt)
+}
+
+// DataRange returns the range of the values along the line
+// and from zero to the largest density, implementing the
+// nplot.DataRanger interface.
+func (k *KDE) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, _, ymax = k.line().DataRange()
+	return xmin, xmax, 0, ymax
+}
+
+// Thumbnail implements the nplot.Thumbnailer interface.
+func (k *KDE) Thumbnail(c *draw.Canvas) {
+	k.line().Thumbnail(c)
+}
+
+// weightedValues implements the Valuer and Weighter
+// interfaces for values and their weights.
+type weightedValues struct {
+	vs Values
+	ws []float64
+}
+
+func (w weightedValues) Len() int             { return len(w.vs) }
+func (w weightedValues) Value(i int) float64  { return w.vs[i] }
+func (w weightedValues) Weight(i int) float64 { return w.ws[i] }
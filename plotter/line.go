@@ -35,9 +35,19 @@ type Line struct {
 	// XYs is a copy of the points for this line.
 	XYs
 
+	// Source, if not nil, is used as the points of
+	// the line instead of XYs.  The points are read
+	// from Source whenever the line is drawn.
+	Source XYer
+
 	// StepStyle is the kind of the step line.
 	StepStyle StepKind
 
+	// Decimation is the method used to reduce the
+	// number of points drawn.  The default is
+	// NoDecimation.
+	Decimation Decimation
+
 	// LineStyle is the style of the line connecting the points.
 	// Use zero width to disable lines.
 	draw.LineStyle
@@ -45,11 +55,22 @@ type Line struct {
 	// FillColor is the color to fill the area below the nplot.
 	// Use nil to disable the filling. This is the default.
 	FillColor color.Color
+
+	// FillStyle, if not nil, is used to fill the
+	// area below the line instead of FillColor.
+	FillStyle vg.FillStyle
 }
 
 // NewLine returns a Line that uses the default line style and
-// does not draw glyphs.
+// does not draw glyphs.  If xys is a Streamer, the line references
+// its points, otherwise the points are copied.
 func NewLine(xys XYer) (*Line, error) {
+	if s, ok := xys.(Streamer); ok {
+		return &Line{
+			Source:    s,
+			LineStyle: DefaultLineStyle,
+		}, nil
+	}
 	data, err := CopyXYs(xys)
 	if err != nil {
 		return nil, err
@@ -63,14 +84,22 @@ func NewLine(xys XYer) (*Line, error) {
 // Plot draws the Line, implementing the nplot.Plotter interface.
 func (pts *Line) Plot(c draw.Canvas, plt *nplot.Plot) {
 	trX, trY := plt.Transforms(&c)
-	ps := make([]vg.Point, len(pts.XYs))
+	xys := pts.points()
+	sty := pts.LineStyle
+	if pts.Decimation != NoDecimation {
+		d := DecimateLine(xys, pts.Decimation, c, plt)
+		sty = decimatedStyle(sty, d)
+		xys = d
+	}
+	ps := make([]vg.Point, xys.Len())
 
-	for i, p := range pts.XYs {
-		ps[i].X = trX(p.X)
-		ps[i].Y = trY(p.Y)
+	for i := range ps {
+		x, y := xys.XY(i)
+		ps[i].X = trX(x)
+		ps[i].Y = trY(y)
 	}
 
-	if pts.FillColor != nil && len(ps) > 0 {
+	if (pts.FillColor != nil || pts.FillStyle != nil) && len(ps) > 0 {
 		minY := trY(plt.Y.Min)
 		fillPoly := []vg.Point{{X: ps[0].X, Y: minY}}
 		switch pts.StepStyle {
@@ -84,7 +113,6 @@ func (pts *Line) Plot(c draw.Canvas, plt *nplot.Plot) {
 		fillPoly = append(fillPoly, vg.Point{X: ps[len(ps)-1].X, Y: minY})
 		fillPoly = c.ClipPolygonXY(fillPoly)
 		if len(fillPoly) > 0 {
-			c.SetColor(pts.FillColor)
 			var pa vg.Path
 			prev := fillPoly[0]
 			pa.Move(prev)
@@ -106,13 +134,13 @@ func (pts *Line) Plot(c draw.Canvas, plt *nplot.Plot) {
 				prev = pt
 			}
 			pa.Close()
-			c.Fill(pa)
+			c.FillPath(pts.FillColor, pts.FillStyle, pa)
 		}
 	}
 
 	lines := c.ClipLinesXY(ps)
-	if pts.LineStyle.Width != 0 && len(lines) != 0 {
-		c.SetLineStyle(pts.LineStyle)
+	if sty.Width != 0 && len(lines) != 0 {
+		c.SetLineStyle(sty)
 		for _, l := range lines {
 			if len(l) == 0 {
 				continue
@@ -141,12 +169,24 @@ func (pts *Line) Plot(c draw.Canvas, plt *nplot.Plot) {
 // DataRange returns the minimum and maximum
 // x and y values, implementing the nplot.DataRanger interface.
 func (pts *Line) DataRange() (xmin, xmax, ymin, ymax float64) {
-	return XYRange(pts)
+	return XYRange(pts.points())
+}
+
+// points returns the points of the line, or a
+// snapshot of the points of a Streamer Source.
+func (pts *Line) points() XYer {
+	if s, ok := pts.Source.(Streamer); ok {
+		return s.XYs()
+	}
+	if pts.Source != nil {
+		return pts.Source
+	}
+	return pts.XYs
 }
 
 // Thumbnail returns the thumbnail for the Line, implementing the nplot.Thumbnailer interface.
 func (pts *Line) Thumbnail(c *draw.Canvas) {
-	if pts.FillColor != nil {
+	if pts.FillColor != nil || pts.FillStyle != nil {
 		var topY vg.Length
 		if pts.LineStyle.Width == 0 {
 			topY = c.Max.Y
@@ -160,7 +200,7 @@ func (pts *Line) Thumbnail(c *draw.Canvas) {
 			{X: c.Max.X, Y: c.Min.Y},
 		}
 		poly := c.ClipPolygonY(points)
-		c.FillPolygon(pts.FillColor, poly)
+		c.FillPolygonStyle(pts.FillColor, pts.FillStyle, poly)
 	}
 
 	if pts.LineStyle.Width != 0 {
@@ -170,7 +210,8 @@ func (pts *Line) Thumbnail(c *draw.Canvas) {
 }
 
 // NewLinePoints returns both a Line and a
-// Points for the given point data.
+// Points for the given point data.  If xys is
+// a Streamer, both reference its points.
 func NewLinePoints(xys XYer) (*Line, *Scatter, error) {
 	s, err := NewScatter(xys)
 	if err != nil {
@@ -178,6 +219,7 @@ func NewLinePoints(xys XYer) (*Line, *Scatter, error) {
 	}
 	l := &Line{
 		XYs:       s.XYs,
+		Source:    s.Source,
 		LineStyle: DefaultLineStyle,
 	}
 	return l, s, nil
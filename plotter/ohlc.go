@@ -0,0 +1,468 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// OHLCer wraps the Len and OHLC methods.
+type OHLCer interface {
+	// Len returns the number of periods.
+	Len() int
+
+	// OHLC returns the time of a period and its
+	// open, high, low and close values.
+	OHLC(int) (t, open, high, low, close float64)
+}
+
+// Volumer wraps the Volume method.  VolumeBars
+// takes the traded volumes of the periods of an
+// OHLCer that is also a Volumer.
+type Volumer interface {
+	// Volume returns the volume of a period.
+	Volume(int) float64
+}
+
+// OHLC holds the values of a period of market data.
+type OHLC struct {
+	// T is the time of the period, in the units of
+	// the X axis, such as seconds of Unix time for
+	// nplot.TimeTicks and nplot.DenseTimeTicks.
+	T float64
+
+	// Open, High, Low and Close are the first,
+	// highest, lowest and last prices.
+	Open, High, Low, Close float64
+
+	// Volume is the traded volume.
+	Volume float64
+}
+
+// OHLCs implements the OHLCer and Volumer interfaces.
+type OHLCs []OHLC
+
+// Len implements the Len method of the OHLCer interface.
+func (o OHLCs) Len() int { return len(o) }
+
+// OHLC implements the OHLC method of the OHLCer interface.
+func (o OHLCs) OHLC(i int) (t, open, high, low, close float64) {
+	return o[i].T, o[i].Open, o[i].High, o[i].Low, o[i].Close
+}
+
+// Volume implements the Volumer interface.
+func (o OHLCs) Volume(i int) float64 { return o[i].Volume }
+
+// CopyOHLCs returns a copy of the periods of data, with their
+// volumes if data is a Volumer.  An error is returned if a value
+// is not finite, or the low and high values do not enclose the
+// open and close values.
+func CopyOHLCs(data OHLCer) (OHLCs, error) {
+	vr, _ := data.(Volumer)
+	cpy := make(OHLCs, data.Len())
+	for i := range cpy {
+		p := &cpy[i]
+		p.T, p.Open, p.High, p.Low, p.Close = data.OHLC(i)
+		if vr != nil {
+			p.Volume = vr.Volume(i)
+		}
+		if err := CheckFloats(p.T, p.Open, p.High, p.Low, p.Close, p.Volume); err != nil {
+			return nil, err
+		}
+		if p.Low > math.Min(p.Open, p.Close) || p.High < math.Max(p.Open, p.Close) {
+			return nil, errors.New("ohlc: open or close outside of low and high")
+		}
+	}
+	return cpy, nil
+}
+
+// periodWidth returns 80% of the shortest time
+// between two periods, or one if there is none.
+func periodWidth(o OHLCs) float64 {
+	ts := make([]float64, len(o))
+	for i, p := range o {
+		ts[i] = p.T
+	}
+	sort.Float64s(ts)
+	min := math.Inf(1)
+	for i := 1; i < len(ts); i++ {
+		if d := ts[i] - ts[i-1]; d > 0 && d < min {
+			min = d
+		}
+	}
+	if math.IsInf(min, 1) {
+		return 1
+	}
+	return 0.8 * min
+}
+
+// Periods holds the periods of market data and the
+// width of their marks, shared by the plotters of
+// market data.
+type Periods struct {
+	// OHLCs is a copy of the periods.
+	OHLCs
+
+	// DataWidth is the width of the marks of the periods
+	// in data coordinates of the X axis.  It is used if
+	// Width is zero.
+	DataWidth float64
+
+	// Width, if positive, is the width of the marks
+	// of the periods on the canvas.
+	Width vg.Length
+}
+
+// newPeriods returns the Periods of data with a DataWidth of
+// 80% of the shortest time between two periods.
+func newPeriods(data OHLCer) (Periods, error) {
+	o, err := CopyOHLCs(data)
+	if err != nil {
+		return Periods{}, err
+	}
+	return Periods{OHLCs: o, DataWidth: periodWidth(o)}, nil
+}
+
+// span returns the left and right edges
+// of the mark of the period at time t.
+func (p *Periods) span(t float64, trX func(float64) vg.Length) (left, right vg.Length) {
+	if p.Width > 0 {
+		x := trX(t)
+		return x - p.Width/2, x + p.Width/2
+	}
+	return trX(t - p.DataWidth/2), trX(t + p.DataWidth/2)
+}
+
+// timeRange returns the range of the times, extended by
+// half of the DataWidth if Width is zero.
+func (p *Periods) timeRange() (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, o := range p.OHLCs {
+		min = math.Min(min, o.T)
+		max = math.Max(max, o.T)
+	}
+	if p.Width > 0 {
+		return min, max
+	}
+	return min - p.DataWidth/2, max + p.DataWidth/2
+}
+
+// DataRange returns the range of the times and from the
+// lowest to the highest price, implementing the
+// nplot.DataRanger interface.
+func (p *Periods) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = p.timeRange()
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for _, o := range p.OHLCs {
+		ymin = math.Min(ymin, o.Low)
+		ymax = math.Max(ymax, o.High)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// GlyphBoxes returns a GlyphBox for the mark of each period if
+// Width is positive, implementing the nplot.GlyphBoxer interface.
+func (p *Periods) GlyphBoxes(plt *nplot.Plot) []nplot.GlyphBox {
+	if p.Width <= 0 {
+		return nil
+	}
+	bs := make([]nplot.GlyphBox, len(p.OHLCs))
+	for i, o := range p.OHLCs {
+		bs[i].X = plt.X.Norm(o.T)
+		bs[i].Y = plt.Y.Norm(o.Close)
+		bs[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{X: -p.Width / 2},
+			Max: vg.Point{X: p.Width / 2},
+		}
+	}
+	return bs
+}
+
+// Default colors of rising and falling periods.
+var (
+	DefaultUpColor   = color.RGBA{R: 38, G: 166, B: 91, A: 255}
+	DefaultDownColor = color.RGBA{R: 220, G: 53, B: 69, A: 255}
+)
+
+// Candlestick implements the Plotter interface, drawing
+// candlesticks of market data.  The body of a candlestick
+// spans the open and close values of a period, and its
+// wick spans the low and high values.
+type Candlestick struct {
+	// Periods are the periods and the
+	// widths of their marks.
+	Periods
+
+	// UpColor and DownColor are the colors of the
+	// bodies of periods closing at or above and
+	// below their open values.  A body is not
+	// filled if its color is nil.
+	UpColor, DownColor color.Color
+
+	// LineStyle is the style of the wicks and the
+	// outlines of the bodies.  If its Color is nil,
+	// the color of the body is used.
+	LineStyle draw.LineStyle
+}
+
+// NewCandlestick returns a Candlestick of the periods of data
+// with bodies 80% as wide as the shortest time between two
+// periods.  An error is returned if a value is not finite, or
+// the low and high values do not enclose the open and close
+// values.
+func NewCandlestick(data OHLCer) (*Candlestick, error) {
+	p, err := newPeriods(data)
+	if err != nil {
+		return nil, err
+	}
+	sty := DefaultLineStyle
+	sty.Color = nil
+	return &Candlestick{
+		Periods:   p,
+		UpColor:   DefaultUpColor,
+		DownColor: DefaultDownColor,
+		LineStyle: sty,
+	}, nil
+}
+
+// Plot implements the Plot method of the nplot.Plotter interface.
+func (cs *Candlestick) Plot(c draw.Canvas, plt *nplot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for _, o := range cs.OHLCs {
+		col := cs.UpColor
+		if o.Close < o.Open {
+			col = cs.DownColor
+		}
+		sty := cs.LineStyle
+		if sty.Color == nil {
+			sty.Color = col
+		}
+		x := trX(o.T)
+		left, right := cs.span(o.T, trX)
+		yo, yc := trY(o.Open), trY(o.Close)
+		bottom, top := yo, yc
+		if bottom > top {
+			bottom, top = top, bottom
+		}
+
+		wick := [][]vg.Point{
+			{{X: x, Y: trY(o.Low)}, {X: x, Y: bottom}},
+			{{X: x, Y: top}, {X: x, Y: trY(o.High)}},
+		}
+		body := []vg.Point{
+			{X: left, Y: bottom},
+			{X: right, Y: bottom},
+			{X: right, Y: top},
+			{X: left, Y: top},
+		}
+		if col != nil {
+			c.FillPolygon(col, c.ClipPolygonXY(body))
+		}
+		if sty.Color != nil && sty.Width > 0 {
+			for _, w := range wick {
+				c.StrokeLines(sty, c.ClipLinesXY(w)...)
+			}
+			c.StrokeLines(sty, c.ClipLinesXY(append(body, body[0]))...)
+		}
+	}
+}
+
+// Thumbnail draws a rising candlestick,
+// implementing the nplot.Thumbnailer interface.
+func (cs *Candlestick) Thumbnail(c *draw.Canvas) {
+	sty := cs.LineStyle
+	if sty.Color == nil {
+		sty.Color = cs.UpColor
+	}
+	x := c.Center().X
+	w := (c.Max.X - c.Min.X) / 4
+	h := (c.Max.Y - c.Min.Y) / 4
+	body := []vg.Point{
+		{X: x - w, Y: c.Min.Y + h},
+		{X: x + w, Y: c.Min.Y + h},
+		{X: x + w, Y: c.Max.Y - h},
+		{X: x - w, Y: c.Max.Y - h},
+	}
+	if cs.UpColor != nil {
+		c.FillPolygon(cs.UpColor, c.ClipPolygonY(body))
+	}
+	if sty.Color != nil && sty.Width > 0 {
+		c.StrokeLine2(sty, x, c.Min.Y, x, c.Max.Y)
+		c.StrokeLines(sty, c.ClipLinesY(append(body, body[0]))...)
+	}
+}
+
+// OHLCBars implements the Plotter interface, drawing OHLC bars
+// of market data.  The vertical line of a bar spans the low
+// and high values of a period, and ticks to its left and right
+// mark the open and close values.
+type OHLCBars struct {
+	// Periods are the periods and the
+	// widths of their marks.
+	Periods
+
+	// UpStyle and DownStyle are the styles of the
+	// bars of periods closing at or above and below
+	// their open values.
+	UpStyle, DownStyle draw.LineStyle
+}
+
+// NewOHLCBars returns OHLCBars of the periods of data with ticks
+// as wide as 80% of the shortest time between two periods.  An
+// error is returned if a value is not finite, or the low and high
+// values do not enclose the open and close values.
+func NewOHLCBars(data OHLCer) (*OHLCBars, error) {
+	p, err := newPeriods(data)
+	if err != nil {
+		return nil, err
+	}
+	up, down := DefaultLineStyle, DefaultLineStyle
+	up.Color, down.Color = DefaultUpColor, DefaultDownColor
+	return &OHLCBars{
+		Periods:   p,
+		UpStyle:   up,
+		DownStyle: down,
+	}, nil
+}
+
+// Plot implements the Plot method of the nplot.Plotter interface.
+func (b *OHLCBars) Plot(c draw.Canvas, plt *nplot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for _, o := range b.OHLCs {
+		sty := b.UpStyle
+		if o.Close < o.Open {
+			sty = b.DownStyle
+		}
+		x := trX(o.T)
+		left, right := b.span(o.T, trX)
+		yo, yc := trY(o.Open), trY(o.Close)
+		c.StrokeLines(sty, c.ClipLinesXY(
+			[]vg.Point{{X: x, Y: trY(o.Low)}, {X: x, Y: trY(o.High)}},
+			[]vg.Point{{X: left, Y: yo}, {X: x, Y: yo}},
+			[]vg.Point{{X: x, Y: yc}, {X: right, Y: yc}},
+		)...)
+	}
+}
+
+// Thumbnail draws a rising bar,
+// implementing the nplot.Thumbnailer interface.
+func (b *OHLCBars) Thumbnail(c *draw.Canvas) {
+	x := c.Center().X
+	w := (c.Max.X - c.Min.X) / 4
+	h := (c.Max.Y - c.Min.Y) / 4
+	c.StrokeLines(b.UpStyle, c.ClipLinesY(
+		[]vg.Point{{X: x, Y: c.Min.Y}, {X: x, Y: c.Max.Y}},
+		[]vg.Point{{X: x - w, Y: c.Min.Y + h}, {X: x, Y: c.Min.Y + h}},
+		[]vg.Point{{X: x, Y: c.Max.Y - h}, {X: x + w, Y: c.Max.Y - h}},
+	)...)
+}
+
+// VolumeBars implements the Plotter interface, drawing the
+// traded volumes of periods of market data as bars, usually
+// in a plot below the prices linked by nplot.LinkX.
+type VolumeBars struct {
+	// Periods are the periods and the
+	// widths of their marks.
+	Periods
+
+	// UpColor and DownColor are the colors of the
+	// bars of periods closing at or above and below
+	// their open values.
+	UpColor, DownColor color.Color
+
+	// LineStyle is the style of the outlines of
+	// the bars.  The outlines are not drawn if the
+	// width is zero.
+	LineStyle draw.LineStyle
+}
+
+// NewVolumeBars returns VolumeBars of the periods of data,
+// which must be a Volumer, with bars as wide as 80% of the
+// shortest time between two periods.  An error is returned if
+// data is not a Volumer, a value is not finite, or the low and
+// high values do not enclose the open and close values.
+func NewVolumeBars(data OHLCer) (*VolumeBars, error) {
+	if _, ok := data.(Volumer); !ok {
+		return nil, errors.New("ohlc: no volumes")
+	}
+	p, err := newPeriods(data)
+	if err != nil {
+		return nil, err
+	}
+	return &VolumeBars{
+		Periods:   p,
+		UpColor:   DefaultUpColor,
+		DownColor: DefaultDownColor,
+	}, nil
+}
+
+// Plot implements the Plot method of the nplot.Plotter interface.
+func (v *VolumeBars) Plot(c draw.Canvas, plt *nplot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for _, o := range v.OHLCs {
+		col := v.UpColor
+		if o.Close < o.Open {
+			col = v.DownColor
+		}
+		left, right := v.span(o.T, trX)
+		bottom, top := trY(0), trY(o.Volume)
+		bar := []vg.Point{
+			{X: left, Y: bottom},
+			{X: right, Y: bottom},
+			{X: right, Y: top},
+			{X: left, Y: top},
+		}
+		if col != nil {
+			c.FillPolygon(col, c.ClipPolygonXY(bar))
+		}
+		if v.LineStyle.Width > 0 {
+			c.StrokeLines(v.LineStyle, c.ClipLinesXY(append(bar, bar[0]))...)
+		}
+	}
+}
+
+// DataRange returns the range of the times and from zero
+// to the largest volume, implementing the nplot.DataRanger
+// interface.
+func (v *VolumeBars) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = v.timeRange()
+	for _, o := range v.OHLCs {
+		ymin = math.Min(ymin, o.Volume)
+		ymax = math.Max(ymax, o.Volume)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// GlyphBoxes returns a GlyphBox for each bar if Width is
+// positive, implementing the nplot.GlyphBoxer interface.
+func (v *VolumeBars) GlyphBoxes(plt *nplot.Plot) []nplot.GlyphBox {
+	bs := v.Periods.GlyphBoxes(plt)
+	for i := range bs {
+		bs[i].Y = plt.Y.Norm(v.OHLCs[i].Volume)
+	}
+	return bs
+}
+
+// Thumbnail fills the thumbnail with the UpColor,
+// implementing the nplot.Thumbnailer interface.
+func (v *VolumeBars) Thumbnail(c *draw.Canvas) {
+	if v.UpColor == nil {
+		return
+	}
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+	}
+	c.FillPolygon(v.UpColor, c.ClipPolygonY(pts))
+}
@@ -0,0 +1,209 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+	"github.com/hneemann/nplot/vg/recorder"
+)
+
+// ohlcTestData are a rising and a falling period.
+var ohlcTestData = OHLCs{
+	{T: 1, Open: 1, High: 2.5, Low: 0.5, Close: 2, Volume: 3},
+	{T: 2, Open: 2, High: 2, Low: 0.5, Close: 1, Volume: 1},
+}
+
+func TestCopyOHLCs(t *testing.T) {
+	for _, data := range []OHLCs{
+		{{T: 0, Open: 1, High: 2, Low: 1.5, Close: 2}},
+		{{T: 0, Open: 1, High: 1.5, Low: 0, Close: 2}},
+		{{T: math.NaN(), Open: 1, High: 2, Low: 0, Close: 1}},
+		{{T: 0, Open: 1, High: 2, Low: 0, Close: 1, Volume: math.Inf(1)}},
+	} {
+		if _, err := CopyOHLCs(data); err == nil {
+			t.Errorf("expected error for %v", data)
+		}
+	}
+	if _, err := NewVolumeBars(noVolumes{ohlcTestData}); err == nil {
+		t.Errorf("expected error for data without volumes")
+	}
+}
+
+// noVolumes hides the Volume method of OHLCs.
+type noVolumes struct {
+	o OHLCs
+}
+
+func (n noVolumes) Len() int { return n.o.Len() }
+func (n noVolumes) OHLC(i int) (t, open, high, low, close float64) {
+	return n.o.OHLC(i)
+}
+
+func TestCandlestick(t *testing.T) {
+	cs, err := NewCandlestick(OHLCs{ohlcTestData[1], ohlcTestData[0]})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cs.DataWidth != 0.8 {
+		t.Errorf("unexpected data width: got:%v want:0.8", cs.DataWidth)
+	}
+	if xmin, xmax, ymin, ymax := cs.DataRange(); xmin != 0.6 || xmax != 2.4 || ymin != 0.5 || ymax != 2.5 {
+		t.Errorf("unexpected data range: %v %v %v %v", xmin, xmax, ymin, ymax)
+	}
+
+	cs.OHLCs = ohlcTestData
+	type fill struct {
+		col                    color.Color
+		xmin, xmax, ymin, ymax vg.Length
+	}
+	want := []fill{
+		{col: DefaultUpColor, xmin: 6, xmax: 14, ymin: 10, ymax: 20},
+		{col: DefaultDownColor, xmin: 16, xmax: 24, ymin: 10, ymax: 20},
+	}
+	for _, width := range []vg.Length{0, 8} {
+		cs.Width = width
+		p, err := nplot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 3
+		p.Y.Min, p.Y.Max = 0, 3
+
+		var rec recorder.Canvas
+		cs.Plot(draw.NewCanvas(&rec, 30, 30), p)
+		var col color.Color
+		var fills []fill
+		for _, a := range rec.Actions {
+			switch a := a.(type) {
+			case *recorder.SetColor:
+				col = a.Color
+			case *recorder.Fill:
+				f := fill{col: col, xmin: 30, ymin: 30}
+				for _, c := range a.Path {
+					if c.Type == vg.CloseComp {
+						continue
+					}
+					f.xmin, f.xmax = minLength(f.xmin, c.Pos.X), maxLength(f.xmax, c.Pos.X)
+					f.ymin, f.ymax = minLength(f.ymin, c.Pos.Y), maxLength(f.ymax, c.Pos.Y)
+				}
+				fills = append(fills, f)
+			}
+		}
+		if len(fills) != len(want) {
+			t.Fatalf("unexpected number of bodies for width %v: got:%d want:%d", width, len(fills), len(want))
+		}
+		for i, f := range fills {
+			w := want[i]
+			if f.col != w.col || math.Abs(float64(f.xmin-w.xmin)) > 1e-9 || math.Abs(float64(f.xmax-w.xmax)) > 1e-9 ||
+				math.Abs(float64(f.ymin-w.ymin)) > 1e-9 || math.Abs(float64(f.ymax-w.ymax)) > 1e-9 {
+				t.Errorf("unexpected body %d for width %v: got:%v want:%v", i, width, f, w)
+			}
+		}
+	}
+}
+
+func minLength(a, b vg.Length) vg.Length { return vg.Length(math.Min(float64(a), float64(b))) }
+func maxLength(a, b vg.Length) vg.Length { return vg.Length(math.Max(float64(a), float64(b))) }
+
+func TestVolumeBars(t *testing.T) {
+	v, err := NewVolumeBars(ohlcTestData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if xmin, xmax, ymin, ymax := v.DataRange(); xmin != 0.6 || xmax != 2.4 || ymin != 0 || ymax != 3 {
+		t.Errorf("unexpected data range: %v %v %v %v", xmin, xmax, ymin, ymax)
+	}
+	v.Width = 4
+	if xmin, xmax, _, _ := v.DataRange(); xmin != 1 || xmax != 2 {
+		t.Errorf("unexpected data range for fixed width: %v %v", xmin, xmax)
+	}
+}
+
+func TestCandlestickTradingDays(t *testing.T) {
+	// Trading days of two weeks in January 2024, without the
+	// weekends and the holidays on January 1 and January 15.
+	var data OHLCs
+	for _, day := range []int{2, 3, 4, 5, 8, 9, 10, 11, 12, 16, 17} {
+		tm := time.Date(2024, time.January, day, 0, 0, 0, 0, time.UTC)
+		data = append(data, OHLC{T: float64(tm.Unix()), Open: 1, High: 3, Low: 0, Close: 2})
+	}
+	cs, err := NewCandlestick(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const day = 24 * 60 * 60
+	if cs.DataWidth != 0.8*day {
+		t.Errorf("unexpected data width: got:%v want:%v", cs.DataWidth, 0.8*day)
+	}
+
+	p, err := nplot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Tick.Marker = &nplot.DenseTimeTicks{Format: "Jan 2", Axis: &p.X}
+	p.Add(cs)
+	xmin, xmax := p.X.Min, p.X.Max
+	if xmin != data[0].T-0.4*day || xmax != data[len(data)-1].T+0.4*day {
+		t.Errorf("unexpected X range: got:[%v, %v]", xmin, xmax)
+	}
+	p.Y.Min, p.Y.Max = 0, 3
+
+	ticks := p.X.Tick.Marker.Ticks(xmin, xmax, func(s string) vg.Length { return vg.Length(6 * len(s)) }, 500)
+	var labels []string
+	for _, tk := range ticks {
+		if tk.Value < xmin || tk.Value > xmax {
+			t.Errorf("tick %v outside of range [%v, %v]", tk.Value, xmin, xmax)
+		}
+		if tk.Label == "" {
+			continue
+		}
+		if math.Mod(tk.Value, day) != 0 {
+			t.Errorf("labeled tick %q not at midnight: %v", tk.Label, tk.Value)
+		}
+		labels = append(labels, tk.Label)
+	}
+	// The axis is continuous in time, so the weekends and
+	// holidays are labeled as gaps between the bodies.
+	var want []string
+	for d := 2; d <= 17; d++ {
+		want = append(want, time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC).Format("Jan 2"))
+	}
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("unexpected tick labels:\ngot: %q\nwant:%q", labels, want)
+	}
+
+	const w = 500
+	var rec recorder.Canvas
+	cs.Plot(draw.NewCanvas(&rec, w, 100), p)
+	width := vg.Length(w * cs.DataWidth / (xmax - xmin))
+	var bodies int
+	for _, a := range rec.Actions {
+		f, ok := a.(*recorder.Fill)
+		if !ok {
+			continue
+		}
+		left, right := vg.Length(w), vg.Length(0)
+		for _, c := range f.Path {
+			if c.Type != vg.CloseComp {
+				left, right = minLength(left, c.Pos.X), maxLength(right, c.Pos.X)
+			}
+		}
+		if math.Abs(float64(right-left-width)) > 1e-9 {
+			t.Errorf("unexpected width of body %d: got:%v want:%v", bodies, right-left, width)
+		}
+		bodies++
+	}
+	if bodies != len(data) {
+		t.Errorf("unexpected number of bodies: got:%d want:%d", bodies, len(data))
+	}
+}
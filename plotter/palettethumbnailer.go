@@ -19,7 +19,7 @@ func PaletteThumbnailers(p palette.Palette) []nplot.Thumbnailer {
 	colors := p.Colors()
 	thumbnailers := make([]nplot.Thumbnailer, len(colors))
 	for i, c := range colors {
-		thumbnailers[i] = paletteThumbnailer{color: c}
+		thumbnailers[i] = paletteThumbnailer{Color: c}
 	}
 	return thumbnailers
 }
@@ -27,7 +27,7 @@ func PaletteThumbnailers(p palette.Palette) []nplot.Thumbnailer {
 // paletteThumbnailer implements the Thumbnailer interface
 // for color palettes.
 type paletteThumbnailer struct {
-	color color.Color
+	Color color.Color
 }
 
 // Thumbnail satisfies the nplot.Thumbnailer interface.
@@ -39,5 +39,5 @@ func (t paletteThumbnailer) Thumbnail(c *draw.Canvas) {
 		{X: c.Max.X, Y: c.Min.Y},
 	}
 	poly := c.ClipPolygonY(pts)
-	c.FillPolygon(t.color, poly)
+	c.FillPolygon(t.Color, poly)
 }
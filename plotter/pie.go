@@ -0,0 +1,358 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/palette"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// PieLabel selects the text of the labels of the slices of a Pie.
+type PieLabel int
+
+const (
+	// PieLabelNone draws no labels.
+	PieLabelNone PieLabel = iota
+
+	// PieLabelPercent labels the slices with
+	// their percentages of the total.
+	PieLabelPercent
+
+	// PieLabelValue labels the slices
+	// with their values.
+	PieLabelValue
+
+	// PieLabelName labels the slices
+	// with their Labels.
+	PieLabelName
+)
+
+// Pie implements the Plotter interface, drawing a pie chart of
+// values, or a donut chart if it has a hole.  The pie is drawn
+// as large as fits into the data area of the plot, independently
+// of the axes, which are hidden when the Pie is added to a plot.
+type Pie struct {
+	// Values are the values of the slices.
+	Values
+
+	// Labels are the names of the slices,
+	// used by the legend and by PieLabelName.
+	Labels []string
+
+	// Colors are the colors of the slices, such as
+	// plotutil.DefaultColors or the colors of a
+	// palette.Palette.  They are repeated if there
+	// are more slices than colors.
+	Colors []color.Color
+
+	// Hole is the radius of the hole of a donut chart
+	// as a fraction of the radius of the pie.  A pie
+	// chart has no hole.
+	Hole float64
+
+	// Explode are the distances by which the slices
+	// are moved out of the center, as fractions of the
+	// radius of the pie.  Slices without an Explode
+	// entry are not moved.
+	Explode []float64
+
+	// StartAngle is the angle in radians at which the
+	// first slice starts, counterclockwise from the
+	// direction of the positive X axis.
+	StartAngle float64
+
+	// Clockwise selects the clockwise direction of the
+	// slices instead of the counterclockwise one.
+	Clockwise bool
+
+	// LineStyle is the style of the outlines of the
+	// slices.  The outlines are not drawn if the width
+	// is zero.
+	LineStyle draw.LineStyle
+
+	// Label selects the text of the labels.
+	Label PieLabel
+
+	// Format is the format of the percentages and
+	// values of the labels.  If Format is empty,
+	// "%.1f%%" is used for the percentages and "%g"
+	// for the values.
+	Format string
+
+	// Outside places the labels outside of the pie,
+	// connected to the slices by leader lines,
+	// instead of inside the slices.
+	Outside bool
+
+	// TextStyle is the style of the labels.
+	TextStyle draw.TextStyle
+
+	// LeaderStyle is the style of the leader lines.
+	LeaderStyle draw.LineStyle
+
+	// LeaderLength is the length of both the radial
+	// and the horizontal part of the leader lines.
+	LeaderLength vg.Length
+}
+
+// NewPie returns a Pie of the values vs with the given labels,
+// which may be nil, starting at the top and running clockwise,
+// with percentages as labels inside the slices and colors from
+// a rainbow palette.
+//
+// An error is returned if the numbers of values and labels
+// differ, a value is negative or not finite, or the values
+// sum to zero.
+func NewPie(vs Valuer, labels []string) (*Pie, error) {
+	if labels != nil && len(labels) != vs.Len() {
+		return nil, errors.New("pie: number of values does not match number of labels")
+	}
+	values, err := CopyValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	var sum float64
+	for _, v := range values {
+		if v < 0 {
+			return nil, errors.New("pie: negative value")
+		}
+		sum += v
+	}
+	if sum == 0 {
+		return nil, errors.New("pie: values sum to zero")
+	}
+
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	n := len(values)
+	return &Pie{
+		Values:       values,
+		Labels:       labels,
+		Colors:       palette.Rainbow(n+1, palette.Red, 1, 0.5, 0.95, 1).Colors()[:n],
+		StartAngle:   math.Pi / 2,
+		Clockwise:    true,
+		LineStyle:    draw.LineStyle{Color: color.White, Width: vg.Points(1)},
+		Label:        PieLabelPercent,
+		TextStyle:    draw.TextStyle{Color: color.Black, Font: fnt},
+		LeaderStyle:  DefaultLineStyle,
+		LeaderLength: vg.Points(8),
+	}, nil
+}
+
+// HidesAxes returns true, implementing the
+// nplot.AxesHider interface.
+func (p *Pie) HidesAxes() bool { return true }
+
+// slice returns the start and sweep angle of slice i.
+func (p *Pie) slice(i int, total float64) (start, sweep float64) {
+	var before float64
+	for _, v := range p.Values[:i] {
+		before += v
+	}
+	dir := 1.0
+	if p.Clockwise {
+		dir = -1
+	}
+	return p.StartAngle + dir*2*math.Pi*before/total, dir * 2 * math.Pi * p.Values[i] / total
+}
+
+// explode returns the Explode entry of slice i.
+func (p *Pie) explode(i int) float64 {
+	if i < len(p.Explode) {
+		return p.Explode[i]
+	}
+	return 0
+}
+
+// text returns the label text of slice i.
+func (p *Pie) text(i int, total float64) string {
+	switch p.Label {
+	case PieLabelPercent:
+		f := p.Format
+		if f == "" {
+			f = "%.1f%%"
+		}
+		return fmt.Sprintf(f, 100*p.Values[i]/total)
+	case PieLabelValue:
+		f := p.Format
+		if f == "" {
+			f = "%g"
+		}
+		return fmt.Sprintf(f, p.Values[i])
+	case PieLabelName:
+		if i < len(p.Labels) {
+			return p.Labels[i]
+		}
+	}
+	return ""
+}
+
+// radius returns the radius of the pie fitting into c
+// with the exploded slices and the outside labels.
+func (p *Pie) radius(c draw.Canvas, total float64) vg.Length {
+	w, h := (c.Max.X-c.Min.X)/2, (c.Max.Y-c.Min.Y)/2
+	if p.Outside && p.Label != PieLabelNone {
+		var tw, th vg.Length
+		for i := range p.Values {
+			t := p.text(i, total)
+			if t == "" {
+				continue
+			}
+			tw = vg.Length(math.Max(float64(tw), float64(p.TextStyle.Width(t))))
+			th = vg.Length(math.Max(float64(th), float64(p.TextStyle.Height(t))))
+		}
+		w -= 2*p.LeaderLength + tw
+		h -= p.LeaderLength + th
+	}
+	var explode float64
+	for i := range p.Values {
+		explode = math.Max(explode, p.explode(i))
+	}
+	r := vg.Length(math.Min(float64(w), float64(h)) / (1 + explode))
+	if r < 0 {
+		return 0
+	}
+	return r
+}
+
+// Plot implements the Plot method of the nplot.Plotter interface.
+func (p *Pie) Plot(c draw.Canvas, plt *nplot.Plot) {
+	var total float64
+	for _, v := range p.Values {
+		total += v
+	}
+	r := p.radius(c, total)
+	if r == 0 || total == 0 {
+		return
+	}
+	center := c.Center()
+	at := func(o vg.Point, rad vg.Length, a float64) vg.Point {
+		return vg.Point{
+			X: o.X + rad*vg.Length(math.Cos(a)),
+			Y: o.Y + rad*vg.Length(math.Sin(a)),
+		}
+	}
+
+	for i, v := range p.Values {
+		if v == 0 {
+			continue
+		}
+		start, sweep := p.slice(i, total)
+		mid := start + sweep/2
+		o := at(center, r*vg.Length(p.explode(i)), mid)
+
+		// A single slice is a full circle or ring, which
+		// has no radial edges.
+		full := math.Abs(sweep) >= 2*math.Pi
+		n := int(math.Ceil(math.Abs(sweep)/(math.Pi/90))) + 1
+		pts := make([]vg.Point, 0, 2*n+1)
+		for j := 0; j < n; j++ {
+			pts = append(pts, at(o, r, start+sweep*float64(j)/float64(n-1)))
+		}
+		if p.Hole > 0 {
+			for j := n - 1; j >= 0; j-- {
+				pts = append(pts, at(o, r*vg.Length(p.Hole), start+sweep*float64(j)/float64(n-1)))
+			}
+		} else if !full {
+			pts = append(pts, o)
+		}
+		if len(p.Colors) != 0 {
+			if col := p.Colors[i%len(p.Colors)]; col != nil {
+				c.FillPolygon(col, c.ClipPolygonXY(pts))
+			}
+		}
+		if p.LineStyle.Color != nil && p.LineStyle.Width > 0 {
+			if full {
+				c.StrokeLines(p.LineStyle, c.ClipLinesXY(pts[:n])...)
+				if p.Hole > 0 {
+					c.StrokeLines(p.LineStyle, c.ClipLinesXY(pts[n:])...)
+				}
+			} else {
+				c.StrokeLines(p.LineStyle, c.ClipLinesXY(append(pts, pts[0]))...)
+			}
+		}
+	}
+
+	for i, v := range p.Values {
+		t := p.text(i, total)
+		if v == 0 || t == "" {
+			continue
+		}
+		start, sweep := p.slice(i, total)
+		mid := start + sweep/2
+		o := at(center, r*vg.Length(p.explode(i)), mid)
+		sty := p.TextStyle
+		if !p.Outside {
+			sty.XAlign, sty.YAlign = draw.XCenter, draw.YCenter
+			c.FillText(sty, at(o, r*vg.Length(1+math.Max(p.Hole, 0.2))/2, mid), t)
+			continue
+		}
+
+		p1, p2 := at(o, r, mid), at(o, r+p.LeaderLength, mid)
+		dx := p.LeaderLength
+		sty.XAlign, sty.YAlign = draw.XLeft, draw.YCenter
+		if math.Cos(mid) < 0 {
+			dx = -dx
+			sty.XAlign = draw.XRight
+		}
+		p3 := vg.Point{X: p2.X + dx, Y: p2.Y}
+		if p.LeaderStyle.Color != nil && p.LeaderStyle.Width > 0 {
+			c.StrokeLines(p.LeaderStyle, []vg.Point{p1, p2, p3})
+		}
+		gap := p.LeaderLength / 4
+		if dx < 0 {
+			gap = -gap
+		}
+		c.FillText(sty, vg.Point{X: p3.X + gap, Y: p3.Y}, t)
+	}
+}
+
+// Thumbnailers returns the Labels and a Thumbnailer of the
+// color of each slice, to be added to the legend of a plot.
+func (p *Pie) Thumbnailers() (legendLabels []string, thumbnailers []nplot.Thumbnailer) {
+	legendLabels = make([]string, len(p.Values))
+	thumbnailers = make([]nplot.Thumbnailer, len(p.Values))
+	for i := range p.Values {
+		if i < len(p.Labels) {
+			legendLabels[i] = p.Labels[i]
+		}
+		var col color.Color
+		if len(p.Colors) != 0 {
+			col = p.Colors[i%len(p.Colors)]
+		}
+		thumbnailers[i] = pieThumbnailer{Color: col}
+	}
+	return legendLabels, thumbnailers
+}
+
+// pieThumbnailer implements the Thumbnailer
+// interface for the slices of a Pie.
+type pieThumbnailer struct {
+	Color color.Color
+}
+
+// Thumbnail fills the thumbnail with the color of the slice,
+// implementing the nplot.Thumbnailer interface.
+func (t pieThumbnailer) Thumbnail(c *draw.Canvas) {
+	if t.Color == nil {
+		return
+	}
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+	}
+	c.FillPolygon(t.Color, c.ClipPolygonY(pts))
+}
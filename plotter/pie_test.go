@@ -0,0 +1,141 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+	"github.com/hneemann/nplot/vg/recorder"
+)
+
+func TestPie(t *testing.T) {
+	for _, vs := range []Values{{}, {0, 0}, {1, -1}, {1, math.NaN()}} {
+		if _, err := NewPie(vs, nil); err == nil {
+			t.Errorf("expected error for %v", vs)
+		}
+	}
+	if _, err := NewPie(Values{1, 2}, []string{"a"}); err == nil {
+		t.Errorf("expected error for missing label")
+	}
+
+	pie, err := NewPie(Values{1, 0, 3}, []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		clockwise    bool
+		start, sweep float64
+	}{
+		{clockwise: true, start: 0, sweep: -3 * math.Pi / 2},
+		{clockwise: false, start: math.Pi, sweep: 3 * math.Pi / 2},
+	} {
+		pie.Clockwise = test.clockwise
+		start, sweep := pie.slice(2, 4)
+		if math.Abs(start-test.start) > 1e-12 || math.Abs(sweep-test.sweep) > 1e-12 {
+			t.Errorf("unexpected slice for clockwise=%t: got:%v %v want:%v %v", test.clockwise, start, sweep, test.start, test.sweep)
+		}
+	}
+
+	for _, test := range []struct {
+		label  PieLabel
+		format string
+		want   string
+	}{
+		{label: PieLabelPercent, want: "75.0%"},
+		{label: PieLabelPercent, format: "%.0f %%", want: "75 %"},
+		{label: PieLabelValue, want: "3"},
+		{label: PieLabelName, want: "c"},
+		{label: PieLabelNone, want: ""},
+	} {
+		pie.Label, pie.Format = test.label, test.format
+		if got := pie.text(2, 4); got != test.want {
+			t.Errorf("unexpected label %d: got:%q want:%q", test.label, got, test.want)
+		}
+	}
+
+	pie.Colors = []color.Color{color.Black, color.White}
+	labels, thumbs := pie.Thumbnailers()
+	if !reflect.DeepEqual(labels, pie.Labels) || len(thumbs) != 3 || thumbs[2].(pieThumbnailer).Color != color.Black {
+		t.Errorf("unexpected thumbnailers: got:%v %v", labels, thumbs)
+	}
+
+	p, err := nplot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(pie)
+	if p.X.Width != 0 || p.Y.Width != 0 {
+		t.Errorf("axes not hidden")
+	}
+
+	// The empty slice is not drawn.
+	var rec recorder.Canvas
+	pie.Label = PieLabelNone
+	pie.Plot(draw.NewCanvas(&rec, 100, 100), p)
+	var fills []color.Color
+	var col color.Color
+	for _, a := range rec.Actions {
+		switch a := a.(type) {
+		case *recorder.SetColor:
+			col = a.Color
+		case *recorder.Fill:
+			fills = append(fills, col)
+		}
+	}
+	if want := []color.Color{color.Black, color.Black}; !reflect.DeepEqual(fills, want) {
+		t.Errorf("unexpected fills: got:%v want:%v", fills, want)
+	}
+}
+
+func TestPieFull(t *testing.T) {
+	p, err := nplot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, hole := range []float64{0, 0.5} {
+		pie, err := NewPie(Values{1}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		pie.Hole = hole
+		pie.Label = PieLabelNone
+
+		// A full circle has no radial edge from
+		// its center, and a full ring none between
+		// its inner and outer edge.
+		var rec recorder.Canvas
+		pie.Plot(draw.NewCanvas(&rec, 100, 100), p)
+		center := vg.Point{X: 50, Y: 50}
+		var strokes int
+		for _, a := range rec.Actions {
+			var path vg.Path
+			switch a := a.(type) {
+			case *recorder.Fill:
+				path = a.Path
+			case *recorder.Stroke:
+				path = a.Path
+				strokes++
+			}
+			for _, c := range path {
+				if c.Type != vg.CloseComp && c.Pos == center {
+					t.Errorf("unexpected center point in path for hole %v", hole)
+				}
+			}
+		}
+		want := 1
+		if hole > 0 {
+			want = 2
+		}
+		if strokes != want {
+			t.Errorf("unexpected number of outlines for hole %v: got:%d want:%d", hole, strokes, want)
+		}
+	}
+}
@@ -26,6 +26,10 @@ type Polygon struct {
 
 	// Color is the fill color of the polygon.
 	Color color.Color
+
+	// FillStyle, if not nil, is used to fill
+	// the polygon instead of Color.
+	FillStyle vg.FillStyle
 }
 
 // NewPolygon returns a polygon that uses the default line style and
@@ -63,8 +67,7 @@ func (pts *Polygon) Plot(c draw.Canvas, plt *nplot.Plot) {
 		}
 		ps[i] = c.ClipPolygonXY(ps[i])
 	}
-	if pts.Color != nil && len(ps) > 0 {
-		c.SetColor(pts.Color)
+	if (pts.Color != nil || pts.FillStyle != nil) && len(ps) > 0 {
 		var pa vg.Path
 		for _, ring := range ps {
 			if len(ring) == 0 {
@@ -76,7 +79,7 @@ func (pts *Polygon) Plot(c draw.Canvas, plt *nplot.Plot) {
 			}
 			pa.Close()
 		}
-		c.Fill(pa)
+		c.FillPath(pts.Color, pts.FillStyle, pa)
 	}
 
 	for _, ring := range ps {
@@ -109,7 +112,7 @@ func (pts *Polygon) DataRange() (xmin, xmax, ymin, ymax float64) {
 // Thumbnail creates the thumbnail for the Polygon,
 // implementing the nplot.Thumbnailer interface.
 func (pts *Polygon) Thumbnail(c *draw.Canvas) {
-	if pts.Color != nil {
+	if pts.Color != nil || pts.FillStyle != nil {
 		points := []vg.Point{
 			{X: c.Min.X, Y: c.Min.Y},
 			{X: c.Min.X, Y: c.Max.Y},
@@ -117,7 +120,7 @@ func (pts *Polygon) Thumbnail(c *draw.Canvas) {
 			{X: c.Max.X, Y: c.Min.Y},
 		}
 		poly := c.ClipPolygonY(points)
-		c.FillPolygon(pts.Color, poly)
+		c.FillPolygonStyle(pts.Color, pts.FillStyle, poly)
 
 		points = append(points, vg.Point{X: c.Min.X, Y: c.Min.Y})
 		c.StrokeLines(pts.LineStyle, points)
@@ -0,0 +1,217 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// QQ implements the Plotter interface, drawing a quantile-quantile
+// plot.  It compares the quantiles of a sample along the Y axis with
+// the quantiles of a reference distribution or of a second sample
+// along the X axis.  The points lie close to the reference line if
+// the distributions differ only in location and scale.
+type QQ struct {
+	// Scatter draws the pairs of quantiles.
+	Scatter
+
+	// Intercept and Slope define the reference
+	// line, y = Intercept + Slope·x.
+	Intercept, Slope float64
+
+	// ReferenceStyle is the style of the reference line.
+	// The line is not drawn if its width is zero.
+	ReferenceStyle draw.LineStyle
+}
+
+// NewQQ returns a QQ plot of the values vs, weighted if vs is
+// a Weighter, against the reference distribution d.  A point is
+// drawn for each value at the quantile of d at its plotting
+// position, which is the middle of its share of the weight.  The
+// reference line passes through the first and third quartiles.
+//
+// An error is returned if vs has no values of positive weight,
+// or a value or weight is not finite.
+func NewQQ(vs Valuer, d Distribution) (*QQ, error) {
+	s, err := newSample(vs)
+	if err != nil {
+		return nil, err
+	}
+	xys := make(XYs, len(s.vs))
+	for i, v := range s.vs {
+		xys[i] = XY{X: d.Quantile(s.position(i)), Y: v}
+	}
+	return newQQ(xys, d.Quantile, s.quantile)
+}
+
+// NewQQSamples returns a QQ plot of the values ys against the
+// values xs, weighted if they are Weighters.  A point is drawn
+// for each value of the sample with fewer values, at the quantile
+// of the other sample at its plotting position.  The reference
+// line passes through the first and third quartiles.
+//
+// An error is returned if a sample has no values of positive
+// weight, or a value or weight is not finite.
+func NewQQSamples(xs, ys Valuer) (*QQ, error) {
+	sx, err := newSample(xs)
+	if err != nil {
+		return nil, err
+	}
+	sy, err := newSample(ys)
+	if err != nil {
+		return nil, err
+	}
+	var xys XYs
+	if len(sx.vs) <= len(sy.vs) {
+		xys = make(XYs, len(sx.vs))
+		for i, x := range sx.vs {
+			xys[i] = XY{X: x, Y: sy.quantile(sx.position(i))}
+		}
+	} else {
+		xys = make(XYs, len(sy.vs))
+		for i, y := range sy.vs {
+			xys[i] = XY{X: sx.quantile(sy.position(i)), Y: y}
+		}
+	}
+	return newQQ(xys, sx.quantile, sy.quantile)
+}
+
+// newQQ returns a QQ plot of the points xys with the reference
+// line through the quartiles of the quantile functions qx and qy.
+func newQQ(xys XYs, qx, qy func(float64) float64) (*QQ, error) {
+	s, err := NewScatter(xys)
+	if err != nil {
+		return nil, err
+	}
+	x1, x3 := qx(0.25), qx(0.75)
+	y1, y3 := qy(0.25), qy(0.75)
+	slope := 1.0
+	if x3 != x1 {
+		slope = (y3 - y1) / (x3 - x1)
+	}
+	return &QQ{
+		Scatter:        *s,
+		Intercept:      y1 - slope*x1,
+		Slope:          slope,
+		ReferenceStyle: DefaultLineStyle,
+	}, nil
+}
+
+// Plot draws the reference line and the points,
+// implementing the nplot.Plotter interface.
+func (qq *QQ) Plot(c draw.Canvas, plt *nplot.Plot) {
+	plotReference(c, plt, qq.Intercept, qq.Slope, qq.ReferenceStyle)
+	qq.Scatter.Plot(c, plt)
+}
+
+// PP implements the Plotter interface, drawing a probability-
+// probability plot.  It compares the cumulative distribution
+// function of a sample along the Y axis with the one of a
+// reference distribution or of a second sample along the X axis.
+// The points lie close to the reference line y = x if the
+// distributions are equal.
+type PP struct {
+	// Scatter draws the pairs of probabilities.
+	Scatter
+
+	// ReferenceStyle is the style of the reference line.
+	// The line is not drawn if its width is zero.
+	ReferenceStyle draw.LineStyle
+}
+
+// NewPP returns a PP plot of the values vs, weighted if vs is
+// a Weighter, against the reference distribution d.  A point is
+// drawn for each value at its plotting position over the value
+// of the cumulative distribution function of d.
+//
+// An error is returned if vs has no values of positive weight,
+// or a value or weight is not finite.
+func NewPP(vs Valuer, d Distribution) (*PP, error) {
+	s, err := newSample(vs)
+	if err != nil {
+		return nil, err
+	}
+	xys := make(XYs, len(s.vs))
+	for i, v := range s.vs {
+		xys[i] = XY{X: d.CDF(v), Y: s.position(i)}
+	}
+	return newPP(xys)
+}
+
+// NewPPSamples returns a PP plot of the values ys against the
+// values xs, weighted if they are Weighters.  A point is drawn
+// for each distinct value of both samples, at the values of their
+// empirical cumulative distribution functions.
+//
+// An error is returned if a sample has no values of positive
+// weight, or a value or weight is not finite.
+func NewPPSamples(xs, ys Valuer) (*PP, error) {
+	sx, err := newSample(xs)
+	if err != nil {
+		return nil, err
+	}
+	sy, err := newSample(ys)
+	if err != nil {
+		return nil, err
+	}
+	var xys XYs
+	i, j := 0, 0
+	for i < len(sx.vs) || j < len(sy.vs) {
+		z := math.Inf(1)
+		if i < len(sx.vs) {
+			z = sx.vs[i]
+		}
+		if j < len(sy.vs) && sy.vs[j] < z {
+			z = sy.vs[j]
+		}
+		for i < len(sx.vs) && sx.vs[i] == z {
+			i++
+		}
+		for j < len(sy.vs) && sy.vs[j] == z {
+			j++
+		}
+		xys = append(xys, XY{X: sx.cdf(z), Y: sy.cdf(z)})
+	}
+	return newPP(xys)
+}
+
+// newPP returns a PP plot of the points xys.
+func newPP(xys XYs) (*PP, error) {
+	s, err := NewScatter(xys)
+	if err != nil {
+		return nil, err
+	}
+	return &PP{
+		Scatter:        *s,
+		ReferenceStyle: DefaultLineStyle,
+	}, nil
+}
+
+// Plot draws the reference line and the points,
+// implementing the nplot.Plotter interface.
+func (pp *PP) Plot(c draw.Canvas, plt *nplot.Plot) {
+	plotReference(c, plt, 0, 1, pp.ReferenceStyle)
+	pp.Scatter.Plot(c, plt)
+}
+
+// plotReference draws the line y = a + b·x across the
+// range of the X axis with the style sty.
+func plotReference(c draw.Canvas, plt *nplot.Plot, a, b float64, sty draw.LineStyle) {
+	if sty.Width == 0 {
+		return
+	}
+	trX, trY := plt.Transforms(&c)
+	const n = 50
+	line := make([]vg.Point, n+1)
+	for i := range line {
+		x := plt.X.Min + (plt.X.Max-plt.X.Min)*float64(i)/n
+		line[i] = vg.Point{X: trX(x), Y: trY(a + b*x)}
+	}
+	c.StrokeLines(sty, c.ClipLinesXY(line)...)
+}
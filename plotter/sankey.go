@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"image/color"
 	"math"
+	"reflect"
 	"sort"
 
 	"github.com/hneemann/nplot"
@@ -186,6 +187,17 @@ func NewSankey(flows ...Flow) (*Sankey, error) {
 	}
 	s.StockBarWidth = s.TextStyle.Font.Extents().Height * 1.15
 
+	s.defaultStyles()
+
+	stocks := s.stockList()
+	s.setStockRange(&stocks)
+
+	return &s, nil
+}
+
+// defaultStyles sets the FlowStyle and StockStyle functions
+// to use the default Color, LineStyle and TextStyle of s.
+func (s *Sankey) defaultStyles() {
 	s.FlowStyle = func(_ string) (color.Color, draw.LineStyle) {
 		return s.Color, s.LineStyle
 	}
@@ -193,11 +205,66 @@ func NewSankey(flows ...Flow) (*Sankey, error) {
 	s.StockStyle = func(label string, category int) (string, draw.TextStyle, vg.Length, vg.Length, color.Color, draw.LineStyle) {
 		return label, s.TextStyle, 0, 0, s.Color, s.LineStyle
 	}
+}
 
-	stocks := s.stockList()
-	s.setStockRange(&stocks)
+// sankey has the fields but not the methods of Sankey.
+type sankey Sankey
 
-	return &s, nil
+// sankeyData is the serialized form of a Sankey.
+type sankeyData struct {
+	sankey
+	Flows []Flow
+}
+
+// JSONProxy implements the nplot.JSONProxy interface.
+// The diagram is encoded with its flows in the field
+// "Flows".  The default FlowStyle and StockStyle functions
+// are not encoded, decoded diagrams use them again.  Other
+// functions cannot be encoded.
+func (s *Sankey) JSONProxy() interface{} {
+	d := sankeyData{sankey: sankey(*s), Flows: s.flows}
+	var def Sankey
+	def.defaultStyles()
+	if sameFunc(d.FlowStyle, def.FlowStyle) {
+		d.FlowStyle = nil
+	}
+	if sameFunc(d.StockStyle, def.StockStyle) {
+		d.StockStyle = nil
+	}
+	return &d
+}
+
+// sameFunc returns whether the functions f and g
+// have the same code, such as two closures of
+// the same function literal.
+func sameFunc(f, g interface{}) bool {
+	fv, gv := reflect.ValueOf(f), reflect.ValueOf(g)
+	return !fv.IsNil() && fv.Pointer() == gv.Pointer()
+}
+
+// SetJSONProxy implements the nplot.JSONProxy interface.
+func (s *Sankey) SetJSONProxy(proxy interface{}) error {
+	d := proxy.(*sankeyData)
+	n, err := NewSankey(d.Flows...)
+	if err != nil {
+		return err
+	}
+	*s = Sankey(d.sankey)
+	s.flows = n.flows
+	s.stocks = n.stocks
+	s.defaultStyles()
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The diagram is encoded by nplot.EncodeJSON.
+func (s *Sankey) MarshalJSON() ([]byte, error) {
+	return nplot.EncodeJSON(s)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *Sankey) UnmarshalJSON(data []byte) error {
+	return nplot.DecodeJSON(data, s)
 }
 
 // Plot implements the nplot.Plotter interface.
@@ -16,18 +16,38 @@ type Scatter struct {
 	// XYs is a copy of the points for this scatter.
 	XYs
 
+	// Source, if not nil, is used as the points of
+	// the scatter instead of XYs.  The points are read
+	// from Source whenever the scatter is drawn.
+	Source XYer
+
 	// GlyphStyleFunc, if not nil, specifies GlyphStyles
 	// for individual points
 	GlyphStyleFunc func(int) draw.GlyphStyle
 
+	// Decimation is the method used to reduce the
+	// number of points drawn.  The default is
+	// NoDecimation.  MinMaxDecimation draws the
+	// same picture from fewer glyphs, while
+	// LTTBDecimation leaves out visible glyphs.
+	Decimation Decimation
+
 	// GlyphStyle is the style of the glyphs drawn
 	// at each point.
 	draw.GlyphStyle
 }
 
 // NewScatter returns a Scatter that uses the
-// default glyph style.
+// default glyph style.  If xys is a Streamer, the
+// scatter references its points, otherwise the
+// points are copied.
 func NewScatter(xys XYer) (*Scatter, error) {
+	if s, ok := xys.(Streamer); ok {
+		return &Scatter{
+			Source:     s,
+			GlyphStyle: DefaultGlyphStyle,
+		}, nil
+	}
 	data, err := CopyXYs(xys)
 	if err != nil {
 		return nil, err
@@ -46,8 +66,18 @@ func (pts *Scatter) Plot(c draw.Canvas, plt *nplot.Plot) {
 	if pts.GlyphStyleFunc != nil {
 		glyph = pts.GlyphStyleFunc
 	}
-	for i, p := range pts.XYs {
-		c.DrawGlyph(glyph(i), vg.Point{X: trX(p.X), Y: trY(p.Y)})
+	xys := pts.points()
+	if pts.Decimation != NoDecimation {
+		d := DecimateGlyphs(xys, pts.Decimation, c, plt)
+		for i, j := range d.Index {
+			x, y := d.XY(i)
+			c.DrawGlyph(glyph(j), vg.Point{X: trX(x), Y: trY(y)})
+		}
+		return
+	}
+	for i, n := 0, xys.Len(); i < n; i++ {
+		x, y := xys.XY(i)
+		c.DrawGlyph(glyph(i), vg.Point{X: trX(x), Y: trY(y)})
 	}
 }
 
@@ -55,7 +85,19 @@ func (pts *Scatter) Plot(c draw.Canvas, plt *nplot.Plot) {
 // x and y values, implementing the nplot.DataRanger
 // interface.
 func (pts *Scatter) DataRange() (xmin, xmax, ymin, ymax float64) {
-	return XYRange(pts)
+	return XYRange(pts.points())
+}
+
+// points returns the points of the scatter, or a
+// snapshot of the points of a Streamer Source.
+func (pts *Scatter) points() XYer {
+	if s, ok := pts.Source.(Streamer); ok {
+		return s.XYs()
+	}
+	if pts.Source != nil {
+		return pts.Source
+	}
+	return pts.XYs
 }
 
 // GlyphBoxes returns a slice of nplot.GlyphBoxes,
@@ -65,10 +107,15 @@ func (pts *Scatter) GlyphBoxes(plt *nplot.Plot) []nplot.GlyphBox {
 	if pts.GlyphStyleFunc != nil {
 		glyph = pts.GlyphStyleFunc
 	}
-	bs := make([]nplot.GlyphBox, len(pts.XYs))
-	for i, p := range pts.XYs {
-		bs[i].X = plt.X.Norm(p.X)
-		bs[i].Y = plt.Y.Norm(p.Y)
+	xys := pts.points()
+	if pts.Decimation != NoDecimation {
+		return pts.outerGlyphBoxes(plt, xys, glyph)
+	}
+	bs := make([]nplot.GlyphBox, xys.Len())
+	for i := range bs {
+		x, y := xys.XY(i)
+		bs[i].X = plt.X.Norm(x)
+		bs[i].Y = plt.Y.Norm(y)
 		r := glyph(i).Radius
 		bs[i].Rectangle = vg.Rectangle{
 			Min: vg.Point{X: -r, Y: -r},
@@ -78,6 +125,64 @@ func (pts *Scatter) GlyphBoxes(plt *nplot.Plot) []nplot.GlyphBox {
 	return bs
 }
 
+// outerGlyphBoxes returns the glyph boxes of the outermost
+// points within the ranges of the axes, which alone pad the
+// data area, with the radius of the largest glyph.  It is used
+// for decimated scatters, which are expected to hold too many
+// points for a glyph box each.
+func (pts *Scatter) outerGlyphBoxes(plt *nplot.Plot, xys XYer, glyph func(int) draw.GlyphStyle) []nplot.GlyphBox {
+	r := pts.GlyphStyle.Radius
+	if pts.GlyphStyleFunc != nil {
+		r = 0
+		for i, n := 0, xys.Len(); i < n; i++ {
+			if gr := glyph(i).Radius; gr > r {
+				r = gr
+			}
+		}
+	}
+
+	// The left, right, bottom and top points, with
+	// their normalized coordinates, are those with the
+	// least and greatest X and Y not beyond the axes.
+	var bs []nplot.GlyphBox
+	var outer [4]int
+	for k := range outer {
+		outer[k] = -1
+	}
+	box := func(k int, b nplot.GlyphBox) {
+		if outer[k] < 0 {
+			outer[k] = len(bs)
+			bs = append(bs, b)
+		} else {
+			bs[outer[k]] = b
+		}
+	}
+	for i, n := 0, xys.Len(); i < n; i++ {
+		x, y := xys.XY(i)
+		b := nplot.GlyphBox{
+			X: plt.X.Norm(x),
+			Y: plt.Y.Norm(y),
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: -r, Y: -r},
+				Max: vg.Point{X: +r, Y: +r},
+			},
+		}
+		if b.X >= 0 && (outer[0] < 0 || b.X < bs[outer[0]].X) {
+			box(0, b)
+		}
+		if b.X <= 1 && (outer[1] < 0 || b.X > bs[outer[1]].X) {
+			box(1, b)
+		}
+		if b.Y >= 0 && (outer[2] < 0 || b.Y < bs[outer[2]].Y) {
+			box(2, b)
+		}
+		if b.Y <= 1 && (outer[3] < 0 || b.Y > bs[outer[3]].Y) {
+			box(3, b)
+		}
+	}
+	return bs
+}
+
 // Thumbnail the thumbnail for the Scatter,
 // implementing the nplot.Thumbnailer interface.
 func (pts *Scatter) Thumbnail(c *draw.Canvas) {
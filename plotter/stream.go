@@ -0,0 +1,268 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/hneemann/nplot"
+)
+
+// Streamer is implemented by XYers whose points change after
+// a plotter is created for them, such as Ring and Series.
+// NewLine, NewScatter and NewLinePoints reference the points
+// of a Streamer instead of copying them, and draw a snapshot
+// of the points taken by XYs.
+type Streamer interface {
+	XYer
+
+	// XYs returns a copy of the points.
+	XYs() XYs
+
+	// Revision returns a number that changes with
+	// every change of the points.
+	Revision() uint64
+}
+
+// Ring is a Streamer holding the most recent points appended
+// to it, up to a fixed capacity.  Appending a point to a full
+// Ring drops its oldest point.
+//
+// Rings are created by NewRing.  The zero value has no
+// capacity, and appending to it returns an error.
+//
+// The methods of Ring may be called concurrently.  Points
+// appended while a plotter draws a Ring may or may not be
+// drawn.
+type Ring struct {
+	mu    sync.RWMutex
+	xys   XYs
+	start int
+	n     int
+	rev   uint64
+}
+
+var _ Streamer = (*Ring)(nil)
+
+// NewRing returns an empty Ring with the given capacity.
+func NewRing(capacity int) *Ring {
+	if capacity < 1 {
+		panic("plotter: ring capacity less than 1")
+	}
+	return &Ring{xys: make(XYs, capacity)}
+}
+
+// Append appends a point to the ring, dropping the oldest
+// point if the ring is full.  It returns an error if x or y
+// is NaN or Infinity, or if the ring has no capacity.
+func (r *Ring) Append(x, y float64) error {
+	if err := CheckFloats(x, y); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.xys) == 0 {
+		return errors.New("plotter: ring without capacity")
+	}
+	if r.n < len(r.xys) {
+		r.xys[(r.start+r.n)%len(r.xys)] = XY{X: x, Y: y}
+		r.n++
+	} else {
+		r.xys[r.start] = XY{X: x, Y: y}
+		r.start = (r.start + 1) % len(r.xys)
+	}
+	r.rev++
+	return nil
+}
+
+// Len returns the number of points in the ring,
+// implementing the XYer interface.
+func (r *Ring) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.n
+}
+
+// XY returns the i-th oldest point of the ring,
+// implementing the XYer interface.  It panics if
+// i is out of range.
+func (r *Ring) XY(i int) (x, y float64) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if i < 0 || i >= r.n {
+		panic("plotter: ring index out of range")
+	}
+	p := r.xys[(r.start+i)%len(r.xys)]
+	return p.X, p.Y
+}
+
+// XYs returns a copy of the points of the ring,
+// from the oldest to the most recent, implementing
+// the Streamer interface.
+func (r *Ring) XYs() XYs {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.points()
+}
+
+// points returns a copy of the points of the ring.
+// The caller must hold r.mu.
+func (r *Ring) points() XYs {
+	xys := make(XYs, r.n)
+	for i := range xys {
+		xys[i] = r.xys[(r.start+i)%len(r.xys)]
+	}
+	return xys
+}
+
+// Cap returns the capacity of the ring.
+func (r *Ring) Cap() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.xys)
+}
+
+// Reset removes all points from the ring.
+func (r *Ring) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.start, r.n = 0, 0
+	r.rev++
+}
+
+// Revision implements the Streamer interface.
+func (r *Ring) Revision() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.rev
+}
+
+// ringData is the serialized form of a Ring.
+type ringData struct {
+	Cap int
+	XYs XYs
+}
+
+// JSONProxy implements the nplot.JSONProxy interface.
+// The ring is encoded as its capacity and its points,
+// from the oldest to the most recent.
+func (r *Ring) JSONProxy() interface{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return &ringData{Cap: len(r.xys), XYs: r.points()}
+}
+
+// SetJSONProxy implements the nplot.JSONProxy interface.
+func (r *Ring) SetJSONProxy(proxy interface{}) error {
+	d := proxy.(*ringData)
+	if d.Cap < 1 || len(d.XYs) > d.Cap {
+		return errors.New("plotter: invalid ring capacity")
+	}
+	xys := make(XYs, d.Cap)
+	copy(xys, d.XYs)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.xys, r.start, r.n = xys, 0, len(d.XYs)
+	r.rev++
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The ring is encoded by nplot.EncodeJSON.
+func (r *Ring) MarshalJSON() ([]byte, error) {
+	return nplot.EncodeJSON(r)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *Ring) UnmarshalJSON(data []byte) error {
+	return nplot.DecodeJSON(data, r)
+}
+
+// Series is a Streamer holding all points appended to it.
+// The zero value is an empty series ready to use.
+//
+// The methods of Series may be called concurrently.  Points
+// appended while a plotter draws a Series may or may not be
+// drawn.
+type Series struct {
+	mu  sync.RWMutex
+	xys XYs
+	rev uint64
+}
+
+var _ Streamer = (*Series)(nil)
+
+// Append appends a point to the series.  It returns an
+// error if x or y is NaN or Infinity.
+func (s *Series) Append(x, y float64) error {
+	if err := CheckFloats(x, y); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.xys = append(s.xys, XY{X: x, Y: y})
+	s.rev++
+	return nil
+}
+
+// Len returns the number of points in the series,
+// implementing the XYer interface.
+func (s *Series) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.xys)
+}
+
+// XY returns the i-th point of the series,
+// implementing the XYer interface.
+func (s *Series) XY(i int) (x, y float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.xys[i].X, s.xys[i].Y
+}
+
+// XYs returns a copy of the points of the series,
+// implementing the Streamer interface.
+func (s *Series) XYs() XYs {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append(XYs(nil), s.xys...)
+}
+
+// Revision implements the Streamer interface.
+func (s *Series) Revision() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rev
+}
+
+// JSONProxy implements the nplot.JSONProxy interface.
+// The series is encoded as its points.
+func (s *Series) JSONProxy() interface{} {
+	xys := s.XYs()
+	return &xys
+}
+
+// SetJSONProxy implements the nplot.JSONProxy interface.
+func (s *Series) SetJSONProxy(proxy interface{}) error {
+	xys := *proxy.(*XYs)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.xys = xys
+	s.rev++
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The series is encoded by nplot.EncodeJSON.
+func (s *Series) MarshalJSON() ([]byte, error) {
+	return nplot.EncodeJSON(s)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *Series) UnmarshalJSON(data []byte) error {
+	return nplot.DecodeJSON(data, s)
+}
@@ -0,0 +1,143 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	r := NewRing(3)
+	for i := 0; i < 5; i++ {
+		if err := r.Append(float64(i), float64(10*i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := r.Append(math.NaN(), 0); err != ErrNaN {
+		t.Errorf("unexpected error for NaN: got:%v want:%v", err, ErrNaN)
+	}
+	got, err := CopyXYs(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := XYs{{X: 2, Y: 20}, {X: 3, Y: 30}, {X: 4, Y: 40}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected points: got:%v want:%v", got, want)
+	}
+	if r.Revision() != 5 {
+		t.Errorf("unexpected revision: got:%d want:5", r.Revision())
+	}
+
+	l, err := NewLine(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r.Append(5, -1)
+	if xmin, xmax, ymin, ymax := l.DataRange(); xmin != 3 || xmax != 5 || ymin != -1 || ymax != 40 {
+		t.Errorf("unexpected range of line: %v %v %v %v", xmin, xmax, ymin, ymax)
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var dec Ring
+	if err := json.Unmarshal(b, &dec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ = CopyXYs(&dec)
+	want, _ = CopyXYs(r)
+	if !reflect.DeepEqual(got, want) || dec.Cap() != 3 {
+		t.Errorf("unexpected decoded ring: got:%v cap %d want:%v cap 3", got, dec.Cap(), want)
+	}
+
+	xys := r.XYs()
+	r.Append(6, 0)
+	if !reflect.DeepEqual(xys, want) {
+		t.Errorf("unexpected snapshot: got:%v want:%v", xys, want)
+	}
+
+	r.Reset()
+	if r.Len() != 0 {
+		t.Errorf("unexpected length after reset: %d", r.Len())
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected panic for index after reset")
+			}
+		}()
+		r.XY(0)
+	}()
+
+	var zero Ring
+	if err := zero.Append(1, 2); err == nil {
+		t.Errorf("expected error for zero ring")
+	}
+	if zero.Len() != 0 || zero.Cap() != 0 || len(zero.XYs()) != 0 {
+		t.Errorf("unexpected zero ring: len %d cap %d", zero.Len(), zero.Cap())
+	}
+}
+
+func TestRingConcurrentDecode(t *testing.T) {
+	r := NewRing(2)
+	r.Append(1, 1)
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			if err := json.Unmarshal(b, r); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			d := r.JSONProxy().(*ringData)
+			if d.Cap != 2 || len(d.XYs) != 1 || r.Cap() != 2 {
+				t.Errorf("unexpected ring: cap %d points %v", d.Cap, d.XYs)
+			}
+		}
+	}()
+	wg.Wait()
+}
+
+func TestSeries(t *testing.T) {
+	var s Series
+	_, sc, err := NewLinePoints(&s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.Append(float64(j), float64(j))
+				XYRange(&s)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if s.Len() != 400 || s.Revision() != 400 {
+		t.Errorf("unexpected length and revision: %d %d", s.Len(), s.Revision())
+	}
+	if xmin, xmax, _, _ := sc.DataRange(); xmin != 0 || xmax != 99 {
+		t.Errorf("unexpected range of scatter: %v %v", xmin, xmax)
+	}
+}
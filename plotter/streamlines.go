@@ -0,0 +1,460 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/palette"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// Streamlines implements the Plotter interface, drawing
+// streamlines of the vector field in a FieldXY.  The
+// streamlines are traced between the grid points of the
+// field by a fourth order Runge-Kutta integration of the
+// bilinearly interpolated vectors, starting at evenly
+// spaced seed points and ending where they come close to
+// other streamlines, leave the grid or reach a NaN or
+// zero vector.
+type Streamlines struct {
+	FieldXY FieldXY
+
+	// LineStyle is the style of the streamlines.
+	LineStyle draw.LineStyle
+
+	// Spacing is the distance between neighbouring
+	// streamlines.  Streamlines end where they come
+	// closer to other streamlines than half of the
+	// spacing.
+	Spacing vg.Length
+
+	// ArrowSize is the length of the arrowheads drawn
+	// at the middle of the streamlines in the direction
+	// of the field.  If ArrowSize is zero, no arrowheads
+	// are drawn.
+	ArrowSize vg.Length
+
+	// ColorMap, if not nil, colors the streamlines by
+	// the magnitude of the vectors instead of the color
+	// of LineStyle.  Magnitudes outside of the range of
+	// ColorMap are clamped to its range.
+	ColorMap palette.ColorMap
+
+	// MaxWidth, if greater than the width of LineStyle,
+	// widens the streamlines linearly with the magnitude
+	// of the vectors, from the width of LineStyle at zero
+	// to MaxWidth at the largest magnitude of the field.
+	MaxWidth vg.Length
+}
+
+// NewStreamlines returns a streamline plotter for the vector
+// field f, drawing black lines with arrowheads half a
+// centimeter apart.
+func NewStreamlines(f FieldXY) *Streamlines {
+	return &Streamlines{
+		FieldXY:   f,
+		LineStyle: DefaultLineStyle,
+		Spacing:   vg.Centimeter / 2,
+		ArrowSize: vg.Points(8),
+	}
+}
+
+// streamline is a traced streamline.
+type streamline struct {
+	// pts are the points on the canvas.
+	pts []vg.Point
+
+	// grid are the positions of the points
+	// in fractional grid indices.
+	grid []XY
+
+	// mags are the magnitudes of the vectors
+	// at the points.
+	mags []float64
+}
+
+// add appends a point to the streamline.
+func (l *streamline) add(p vg.Point, g XY, mag float64) {
+	l.pts = append(l.pts, p)
+	l.grid = append(l.grid, g)
+	l.mags = append(l.mags, mag)
+}
+
+// Plot implements the Plot method of the nplot.Plotter interface.
+func (s *Streamlines) Plot(c draw.Canvas, plt *nplot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	lines := s.trace(c, trX, trY)
+
+	var max float64
+	cols, rows := s.FieldXY.Dims()
+	for i := 0; i < cols; i++ {
+		for j := 0; j < rows; j++ {
+			v := s.FieldXY.Vector(i, j)
+			if d := math.Hypot(v.X, v.Y); d > max {
+				max = d
+			}
+		}
+	}
+
+	varying := s.ColorMap != nil || s.MaxWidth > s.LineStyle.Width
+	for _, l := range lines {
+		if !varying {
+			c.StrokeLines(s.LineStyle, l.pts)
+		} else {
+			sty := s.LineStyle
+			sty.Cap = vg.RoundCap
+			for i := 1; i < len(l.pts); i++ {
+				sty.Color, sty.Width = s.style(l.mags[i-1], max)
+				c.StrokeLines(sty, l.pts[i-1:i+1])
+			}
+		}
+	}
+	if s.ArrowSize > 0 {
+		for _, l := range lines {
+			m := len(l.pts) / 2
+			col, _ := s.style(l.mags[m], max)
+			s.arrow(c, l.pts[m-1], l.pts[m], col)
+		}
+	}
+}
+
+// style returns the color and the width of a streamline
+// where the magnitude of the vectors is mag and the largest
+// magnitude of the field is max.
+func (s *Streamlines) style(mag, max float64) (color.Color, vg.Length) {
+	col, width := s.LineStyle.Color, s.LineStyle.Width
+	if s.ColorMap != nil {
+		v := math.Max(s.ColorMap.Min(), math.Min(mag, s.ColorMap.Max()))
+		var err error
+		col, err = s.ColorMap.At(v)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if s.MaxWidth > width && max > 0 {
+		width += (s.MaxWidth - width) * vg.Length(mag/max)
+	}
+	return col, width
+}
+
+// arrow draws an arrowhead in the color col at the point to,
+// pointing in the direction from the point from.
+func (s *Streamlines) arrow(c draw.Canvas, from, to vg.Point, col color.Color) {
+	d := to.Sub(from)
+	l := vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+	if l == 0 {
+		return
+	}
+	d = d.Scale(s.ArrowSize / l)
+	n := vg.Point{X: -d.Y, Y: d.X}.Scale(1.0 / 3)
+	base := to.Sub(d.Scale(0.5))
+	c.FillPolygon(col, []vg.Point{
+		to.Add(d.Scale(0.5)),
+		base.Add(n),
+		base.Sub(n),
+	})
+}
+
+// trace returns the streamlines of the field drawn on the canvas
+// c with the coordinate transforms trX and trY.  The streamlines
+// are seeded as by Jobard and Lefer, at the spacing beside the
+// streamlines traced before, and additionally on a lattice of the
+// spacing to reach regions not connected to the other streamlines.
+func (s *Streamlines) trace(c draw.Canvas, trX, trY func(float64) vg.Length) []streamline {
+	f := streamField{FieldXY: s.FieldXY}
+	f.cols, f.rows = s.FieldXY.Dims()
+	if f.cols < 2 || f.rows < 2 || s.Spacing <= 0 {
+		return nil
+	}
+	// cw and ch are the mean sizes of the grid cells
+	// on the canvas, negative for inverted axes.
+	cw := float64(trX(f.X(f.cols-1))-trX(f.X(0))) / float64(f.cols-1)
+	ch := float64(trY(f.Y(f.rows-1))-trY(f.Y(0))) / float64(f.rows-1)
+	if cw == 0 || ch == 0 {
+		return nil
+	}
+	f.cw, f.ch = cw, ch
+
+	sep := s.Spacing
+	step := math.Min(float64(sep)/4, math.Min(math.Abs(cw), math.Abs(ch))/2)
+	canvasPoint := func(u, v float64) vg.Point {
+		x, y := f.pos(u, v)
+		return vg.Point{X: trX(x), Y: trY(y)}
+	}
+
+	var lines []streamline
+	taken := newPointGrid(sep)
+
+	// follow traces and keeps the streamline through the grid
+	// position (u0, v0) if it is far enough from the others.
+	follow := func(u0, v0 float64) {
+		seed := canvasPoint(u0, v0)
+		if !c.Contains(seed) || taken.near(seed, sep*seedSpacing, nil) {
+			return
+		}
+		if _, ok := f.at(u0, v0); !ok {
+			return
+		}
+
+		own := newPointGrid(sep)
+		var halves [2]streamline
+		for h, dir := range []float64{1, -1} {
+			u, v := u0, v0
+			var arc vg.Length
+			p := seed
+			for n := 0; n < maxStreamSteps; n++ {
+				mag, _ := f.at(u, v)
+				halves[h].add(p, XY{X: u, Y: v}, mag)
+				own.add(p, dir*float64(arc))
+
+				nu, nv, ok := f.rk4(u, v, dir*step)
+				if !ok {
+					break
+				}
+				np := canvasPoint(nu, nv)
+				if !c.Contains(np) {
+					break
+				}
+				d := np.Sub(p)
+				arc += vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+				sarc := dir * float64(arc)
+				if taken.near(np, sep/2, nil) || own.near(np, sep/2, func(a float64) bool {
+					return math.Abs(a-sarc) > 2*float64(sep)
+				}) {
+					break
+				}
+				u, v, p = nu, nv, np
+			}
+		}
+
+		// Join the backward half, reversed, and the forward half.
+		var l streamline
+		back := halves[1]
+		for i := len(back.pts) - 1; i > 0; i-- {
+			l.add(back.pts[i], back.grid[i], back.mags[i])
+		}
+		l.pts = append(l.pts, halves[0].pts...)
+		l.grid = append(l.grid, halves[0].grid...)
+		l.mags = append(l.mags, halves[0].mags...)
+		if len(l.pts) < 2 || polylineLength(l.pts) < sep {
+			return
+		}
+		for _, p := range l.pts {
+			taken.add(p, 0)
+		}
+		lines = append(lines, l)
+	}
+
+	// next is the index of the first streamline
+	// not yet seeded beside.
+	var next int
+	du, dv := float64(sep)/math.Abs(cw), float64(sep)/math.Abs(ch)
+	for v0 := dv / 2; v0 < float64(f.rows-1); v0 += dv {
+		for u0 := du / 2; u0 < float64(f.cols-1); u0 += du {
+			follow(u0, v0)
+			for ; next < len(lines); next++ {
+				l := lines[next]
+				var arc vg.Length
+				for j := 1; j < len(l.pts)-1; j++ {
+					d := l.pts[j+1].Sub(l.pts[j-1])
+					arc += vg.Length(math.Hypot(float64(d.X), float64(d.Y))) / 2
+					if arc < sep/2 {
+						continue
+					}
+					arc = 0
+					n := vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+					if n == 0 {
+						continue
+					}
+					// The normal of the streamline on the canvas
+					// in grid units.
+					nu := -float64(d.Y/n*sep) / cw
+					nv := float64(d.X/n*sep) / ch
+					g := l.grid[j]
+					follow(g.X+nu, g.Y+nv)
+					follow(g.X-nu, g.Y-nv)
+				}
+			}
+		}
+	}
+	return lines
+}
+
+// seedSpacing is the least distance of seed points from
+// other streamlines relative to the spacing of streamlines.
+const seedSpacing = 0.9
+
+// maxStreamSteps is the largest number of integration
+// steps in each direction of a streamline.
+const maxStreamSteps = 10000
+
+// polylineLength returns the length of the polyline pts.
+func polylineLength(pts []vg.Point) vg.Length {
+	var l vg.Length
+	for i := 1; i < len(pts); i++ {
+		d := pts[i].Sub(pts[i-1])
+		l += vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+	}
+	return l
+}
+
+// streamField evaluates a FieldXY between its grid points,
+// addressed by fractional column and row indices.
+type streamField struct {
+	FieldXY
+	cols, rows int
+
+	// cw and ch are the sizes of the grid cells
+	// on the canvas used to scale the speed of
+	// integration.
+	cw, ch float64
+}
+
+// gridCell returns the cell and the position inside the cell of
+// the fractional index u of n grid points.
+func gridCell(u float64, n int) (int, float64) {
+	i := int(math.Floor(u))
+	if i > n-2 {
+		i = n - 2
+	}
+	return i, u - float64(i)
+}
+
+// pos returns the coordinates at the grid position (u, v).
+func (f streamField) pos(u, v float64) (x, y float64) {
+	i, fu := gridCell(u, f.cols)
+	j, fv := gridCell(v, f.rows)
+	x = f.X(i) + fu*(f.X(i+1)-f.X(i))
+	y = f.Y(j) + fv*(f.Y(j+1)-f.Y(j))
+	return x, y
+}
+
+// at returns the magnitude of the vector at the grid position
+// (u, v) and whether the position is inside the grid and the
+// vector is defined there.
+func (f streamField) at(u, v float64) (float64, bool) {
+	vec, ok := f.vector(u, v)
+	return math.Hypot(vec.X, vec.Y), ok
+}
+
+// vector returns the bilinearly interpolated vector at the
+// grid position (u, v) and whether it is defined.
+func (f streamField) vector(u, v float64) (XY, bool) {
+	if u < 0 || v < 0 || u > float64(f.cols-1) || v > float64(f.rows-1) {
+		return XY{}, false
+	}
+	i, fu := gridCell(u, f.cols)
+	j, fv := gridCell(v, f.rows)
+	v00, v10 := f.Vector(i, j), f.Vector(i+1, j)
+	v01, v11 := f.Vector(i, j+1), f.Vector(i+1, j+1)
+	vec := XY{
+		X: (1-fv)*((1-fu)*v00.X+fu*v10.X) + fv*((1-fu)*v01.X+fu*v11.X),
+		Y: (1-fv)*((1-fu)*v00.Y+fu*v10.Y) + fv*((1-fu)*v01.Y+fu*v11.Y),
+	}
+	if math.IsNaN(vec.X) || math.IsNaN(vec.Y) {
+		return XY{}, false
+	}
+	return vec, true
+}
+
+// direction returns the direction of the field at the grid
+// position (u, v) in grid coordinates, scaled to unit speed on
+// the canvas, and whether it is defined and not zero.
+func (f streamField) direction(u, v float64) (du, dv float64, ok bool) {
+	vec, ok := f.vector(u, v)
+	if !ok {
+		return 0, 0, false
+	}
+	i, _ := gridCell(u, f.cols)
+	j, _ := gridCell(v, f.rows)
+	du = vec.X / (f.X(i+1) - f.X(i))
+	dv = vec.Y / (f.Y(j+1) - f.Y(j))
+	speed := math.Hypot(du*f.cw, dv*f.ch)
+	if speed == 0 || math.IsInf(speed, 0) {
+		return 0, 0, false
+	}
+	return du / speed, dv / speed, true
+}
+
+// rk4 returns the grid position reached from (u, v) by a fourth
+// order Runge-Kutta step of length h along the field, and whether
+// the step stayed inside the defined field.
+func (f streamField) rk4(u, v, h float64) (float64, float64, bool) {
+	k1u, k1v, ok1 := f.direction(u, v)
+	k2u, k2v, ok2 := f.direction(u+h/2*k1u, v+h/2*k1v)
+	k3u, k3v, ok3 := f.direction(u+h/2*k2u, v+h/2*k2v)
+	k4u, k4v, ok4 := f.direction(u+h*k3u, v+h*k3v)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return u, v, false
+	}
+	u += h / 6 * (k1u + 2*k2u + 2*k3u + k4u)
+	v += h / 6 * (k1v + 2*k2v + 2*k3v + k4v)
+	return u, v, true
+}
+
+// pointGrid is a spatial hash of points on a canvas, each with a
+// tag, for finding the points near a location.
+type pointGrid struct {
+	size  vg.Length
+	cells map[[2]int][]taggedPoint
+}
+
+// taggedPoint is a point of a pointGrid.
+type taggedPoint struct {
+	vg.Point
+	tag float64
+}
+
+// newPointGrid returns an empty pointGrid with the given cell size.
+func newPointGrid(size vg.Length) *pointGrid {
+	return &pointGrid{size: size, cells: make(map[[2]int][]taggedPoint)}
+}
+
+// key returns the cell of the point p.
+func (g *pointGrid) key(p vg.Point) [2]int {
+	return [2]int{int(math.Floor(float64(p.X / g.size))), int(math.Floor(float64(p.Y / g.size)))}
+}
+
+// add adds the point p with the tag to the grid.
+func (g *pointGrid) add(p vg.Point, tag float64) {
+	k := g.key(p)
+	g.cells[k] = append(g.cells[k], taggedPoint{Point: p, tag: tag})
+}
+
+// near returns whether a point with a tag accepted by the filter
+// is closer than d, which must not exceed the cell size, to p.
+// A nil filter accepts all tags.
+func (g *pointGrid) near(p vg.Point, d vg.Length, filter func(tag float64) bool) bool {
+	k := g.key(p)
+	for i := k[0] - 1; i <= k[0]+1; i++ {
+		for j := k[1] - 1; j <= k[1]+1; j++ {
+			for _, q := range g.cells[[2]int{i, j}] {
+				e := q.Sub(p)
+				if math.Hypot(float64(e.X), float64(e.Y)) < float64(d) && (filter == nil || filter(q.tag)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// DataRange implements the DataRange method
+// of the nplot.DataRanger interface.
+func (s *Streamlines) DataRange() (xmin, xmax, ymin, ymax float64) {
+	c, r := s.FieldXY.Dims()
+	return s.FieldXY.X(0), s.FieldXY.X(c - 1), s.FieldXY.Y(0), s.FieldXY.Y(r - 1)
+}
+
+// Thumbnail implements the Thumbnail method
+// of the nplot.Thumbnailer interface.
+func (s *Streamlines) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(s.LineStyle, c.Min.X, y, c.Max.X, y)
+}
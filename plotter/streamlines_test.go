@@ -0,0 +1,99 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+	"github.com/hneemann/nplot/vg/recorder"
+)
+
+// funcField is an 11×11 field on [-1, 1]² of the vectors of a function.
+type funcField func(x, y float64) XY
+
+func (f funcField) Dims() (c, r int)   { return 11, 11 }
+func (f funcField) X(c int) float64    { return float64(c)/5 - 1 }
+func (f funcField) Y(r int) float64    { return float64(r)/5 - 1 }
+func (f funcField) Vector(c, r int) XY { return f(f.X(c), f.Y(r)) }
+
+// traceStreamlines returns the streamlines of f drawn on a 4×4 inch canvas.
+func traceStreamlines(t *testing.T, f FieldXY, sep vg.Length) []streamline {
+	p, err := nplot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := NewStreamlines(f)
+	s.Spacing = sep
+	p.Add(s)
+	c := draw.NewCanvas(&recorder.Canvas{}, 4*vg.Inch, 4*vg.Inch)
+	trX, trY := p.Transforms(&c)
+	return s.trace(c, trX, trY)
+}
+
+func TestStreamlinesUniform(t *testing.T) {
+	lines := traceStreamlines(t, funcField(func(x, y float64) XY { return XY{X: 1, Y: 0.5} }), vg.Inch/2)
+	if len(lines) < 10 {
+		t.Errorf("unexpected number of streamlines: %d", len(lines))
+	}
+	for i, l := range lines {
+		a, b := l.pts[0], l.pts[len(l.pts)-1]
+		if b.X <= a.X {
+			t.Errorf("unexpected direction of streamline %d: from %v to %v", i, a, b)
+		}
+		for _, p := range l.pts {
+			// The slope is the same on the canvas.
+			if d := float64(p.Y-a.Y) - 0.5*float64(p.X-a.X); math.Abs(d) > 1e-6 {
+				t.Errorf("unexpected point of straight streamline %d: %v", i, p)
+				break
+			}
+		}
+	}
+}
+
+func TestStreamlinesSpacing(t *testing.T) {
+	const sep = vg.Inch / 4
+	rotation := funcField(func(x, y float64) XY { return XY{X: -y, Y: x} })
+	lines := traceStreamlines(t, rotation, sep)
+	if len(lines) == 0 {
+		t.Fatal("missing streamlines")
+	}
+	for i, l := range lines {
+		for j, o := range lines[:i] {
+			for _, p := range l.pts {
+				for _, q := range o.pts {
+					d := p.Sub(q)
+					if vg.Length(math.Hypot(float64(d.X), float64(d.Y))) < sep/2-1e-6 {
+						t.Fatalf("streamlines %d and %d are too close at %v and %v", i, j, p, q)
+					}
+				}
+			}
+		}
+		for _, p := range l.pts {
+			// The streamlines are circles.
+			r := math.Hypot(float64(p.X-2*vg.Inch), float64(p.Y-2*vg.Inch))
+			r0 := math.Hypot(float64(l.pts[0].X-2*vg.Inch), float64(l.pts[0].Y-2*vg.Inch))
+			if math.Abs(r-r0) > 0.5 {
+				t.Errorf("unexpected point of circular streamline %d: %v", i, p)
+				break
+			}
+		}
+	}
+
+	holes := funcField(func(x, y float64) XY {
+		if x > 0 {
+			return XY{X: math.NaN(), Y: math.NaN()}
+		}
+		return XY{X: 1}
+	})
+	for _, l := range traceStreamlines(t, holes, sep) {
+		if end := l.pts[len(l.pts)-1]; end.X > 2*vg.Inch {
+			t.Errorf("unexpected streamline into NaN vectors ending at %v", end)
+		}
+	}
+}
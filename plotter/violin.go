@@ -0,0 +1,329 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// ViolinSide selects the sides of a violin that are drawn.
+type ViolinSide int
+
+const (
+	// ViolinBoth draws the density mirrored
+	// on both sides of the location.
+	ViolinBoth ViolinSide = iota
+
+	// ViolinLow draws the half violin left of, or
+	// for horizontal violins below, the location.
+	ViolinLow
+
+	// ViolinHigh draws the half violin right of, or
+	// for horizontal violins above, the location.
+	ViolinHigh
+)
+
+// ViolinInner selects the markers drawn inside a violin.
+type ViolinInner int
+
+const (
+	// ViolinInnerBox draws a narrow box from the first to
+	// the third quartile with whiskers to the adjacent
+	// values and a glyph at the median.
+	ViolinInnerBox ViolinInner = iota
+
+	// ViolinInnerQuartiles draws lines across the violin
+	// at the quartiles, dashed at the first and third.
+	ViolinInnerQuartiles
+
+	// ViolinInnerNone draws no markers.
+	ViolinInnerNone
+)
+
+// Violin implements the Plotter interface, drawing a violin
+// plot of the kernel density estimate of a distribution of
+// values.  Like a BoxPlot, it is drawn at a location along
+// one axis, with the values along the other axis.
+type Violin struct {
+	fiveStatPlot
+
+	// Offset is added to the location of the violin.
+	// When the Offset is zero, the violin is drawn
+	// centered at its location.
+	Offset vg.Length
+
+	// Width is the width of the violin
+	// at its largest density.
+	Width vg.Length
+
+	// Kernel is the kernel of the density estimate.
+	Kernel Kernel
+
+	// Bandwidth is the bandwidth of the kernel.  If
+	// Bandwidth is zero, it is chosen by BandwidthRule.
+	Bandwidth float64
+
+	// BandwidthRule chooses the bandwidth if
+	// Bandwidth is zero.
+	BandwidthRule BandwidthRule
+
+	// Cut is the number of bandwidths by which the
+	// violin extends beyond the extreme values.
+	Cut float64
+
+	// Samples is the number of points at which the
+	// density is evaluated.
+	Samples int
+
+	// Side selects the sides of the violin.
+	Side ViolinSide
+
+	// FillColor is the color of the violin.  If
+	// FillColor is nil, the violin is not filled.
+	FillColor color.Color
+
+	// LineStyle is the style of the outline.
+	draw.LineStyle
+
+	// Inner selects the markers inside the violin.
+	Inner ViolinInner
+
+	// InnerStyle is the line style of the whiskers
+	// and the box of the inner box and of the lines
+	// of the inner quartiles.
+	InnerStyle draw.LineStyle
+
+	// BoxWidth is the width of the inner box.
+	BoxWidth vg.Length
+
+	// MedianStyle is the style of the median
+	// glyph of the inner box.
+	MedianStyle draw.GlyphStyle
+
+	// Horizontal dictates whether the Violin should be in
+	// the vertical (default) or horizontal direction.
+	Horizontal bool
+}
+
+// NewViolin returns a new Violin of the width w at the location
+// loc that represents the distribution of the given values by
+// a Gaussian kernel density estimate with Silverman's bandwidth,
+// cut at two bandwidths beyond the extreme values.
+//
+// An error is returned if the violin is created with no values.
+func NewViolin(w vg.Length, loc float64, values Valuer) (*Violin, error) {
+	if w < 0 {
+		return nil, errors.New("plotter: negative violin width")
+	}
+	if values.Len() == 0 {
+		return nil, errors.New("plotter: violin without values")
+	}
+
+	v := new(Violin)
+	var err error
+	if v.fiveStatPlot, err = newFiveStat(w, loc, values); err != nil {
+		return nil, err
+	}
+	v.Width = w
+	v.Kernel = GaussianKernel
+	v.BandwidthRule = SilvermanBandwidth
+	v.Cut = 2
+	v.Samples = 100
+	v.FillColor = color.Gray{Y: 200}
+	v.LineStyle = DefaultLineStyle
+	v.InnerStyle = draw.LineStyle{
+		Color: color.Black,
+		Width: vg.Points(1),
+	}
+	v.BoxWidth = w / 10
+	v.MedianStyle = draw.GlyphStyle{
+		Color:  color.White,
+		Radius: w / 30,
+		Shape:  draw.CircleGlyph{},
+	}
+	return v, nil
+}
+
+// estimate returns the density estimate of the violin.
+func (v *Violin) estimate() kde {
+	s, err := newSample(v.Values)
+	if err != nil {
+		panic(err)
+	}
+	h := v.Bandwidth
+	if h <= 0 {
+		h = v.BandwidthRule.bandwidth(s)
+	}
+	return kde{s: s, kernel: v.Kernel, h: h}
+}
+
+// valueRange returns the range of values covered by the violin.
+func (v *Violin) valueRange() (min, max float64) {
+	cut := v.Cut * v.estimate().h
+	return v.Min - cut, v.Max + cut
+}
+
+// Density returns the values at which the density of the violin
+// is evaluated and the estimated densities at these values.
+func (v *Violin) Density() XYs {
+	return v.estimate().density(v.Cut, v.Samples)
+}
+
+// Plot draws the Violin on Canvas c and Plot plt.
+func (v *Violin) Plot(c draw.Canvas, plt *nplot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	// pt returns the canvas point of the value val
+	// at the distance off from the location.  Like
+	// a BoxPlot, the violin is only clipped along
+	// the axis of the values.
+	var (
+		pt       func(val float64, off vg.Length) vg.Point
+		clip     func(lines ...[]vg.Point) [][]vg.Point
+		clipPoly func(pts []vg.Point) []vg.Point
+		contains func(p vg.Point) bool
+	)
+	if v.Horizontal {
+		y := trY(v.Location)
+		if !c.ContainsY(y) {
+			return
+		}
+		y += v.Offset
+		pt = func(val float64, off vg.Length) vg.Point {
+			return vg.Point{X: trX(val), Y: y + off}
+		}
+		clip, clipPoly = c.ClipLinesX, c.ClipPolygonX
+		contains = func(p vg.Point) bool { return c.ContainsX(p.X) }
+	} else {
+		x := trX(v.Location)
+		if !c.ContainsX(x) {
+			return
+		}
+		x += v.Offset
+		pt = func(val float64, off vg.Length) vg.Point {
+			return vg.Point{X: x + off, Y: trY(val)}
+		}
+		clip, clipPoly = c.ClipLinesY, c.ClipPolygonY
+		contains = func(p vg.Point) bool { return c.ContainsY(p.Y) }
+	}
+
+	e := v.estimate()
+	dens := e.density(v.Cut, v.Samples)
+	var max float64
+	for _, d := range dens {
+		max = math.Max(max, d.Y)
+	}
+	// half returns the half width of the
+	// violin at the density d.
+	half := func(d float64) vg.Length {
+		if max == 0 {
+			return 0
+		}
+		return vg.Length(d/max) * v.Width / 2
+	}
+
+	lo, hi := vg.Length(-1), vg.Length(1)
+	switch v.Side {
+	case ViolinLow:
+		hi = 0
+	case ViolinHigh:
+		lo = 0
+	}
+	outline := make([]vg.Point, 0, 2*len(dens)+1)
+	for _, d := range dens {
+		outline = append(outline, pt(d.X, hi*half(d.Y)))
+	}
+	for i := len(dens) - 1; i >= 0; i-- {
+		outline = append(outline, pt(dens[i].X, lo*half(dens[i].Y)))
+	}
+	if v.FillColor != nil {
+		c.FillPolygon(v.FillColor, clipPoly(outline))
+	}
+	outline = append(outline, outline[0])
+	c.StrokeLines(v.LineStyle, clip(outline)...)
+
+	switch v.Inner {
+	case ViolinInnerBox:
+		c.StrokeLines(v.InnerStyle, clip([]vg.Point{pt(v.AdjLow, 0), pt(v.AdjHigh, 0)})...)
+		w := v.BoxWidth / 2
+		box := []vg.Point{pt(v.Quartile1, -w), pt(v.Quartile3, -w), pt(v.Quartile3, w), pt(v.Quartile1, w)}
+		c.FillPolygon(v.InnerStyle.Color, clipPoly(box))
+		if p := pt(v.Median, 0); contains(p) {
+			c.DrawGlyphNoClip(v.MedianStyle, p)
+		}
+	case ViolinInnerQuartiles:
+		dashed := v.InnerStyle
+		if len(dashed.Dashes) == 0 {
+			dashed.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+		}
+		solid := v.InnerStyle
+		solid.Dashes = nil
+		for _, q := range []struct {
+			val float64
+			sty draw.LineStyle
+		}{
+			{val: v.Quartile1, sty: dashed},
+			{val: v.Median, sty: solid},
+			{val: v.Quartile3, sty: dashed},
+		} {
+			h := half(e.at(q.val))
+			c.StrokeLines(q.sty, clip([]vg.Point{pt(q.val, lo*h), pt(q.val, hi*h)})...)
+		}
+	}
+}
+
+// DataRange returns the minimum and maximum x
+// and y values, implementing the nplot.DataRanger
+// interface.
+func (v *Violin) DataRange() (xmin, xmax, ymin, ymax float64) {
+	min, max := v.valueRange()
+	if v.Horizontal {
+		return min, max, v.Location, v.Location
+	}
+	return v.Location, v.Location, min, max
+}
+
+// GlyphBoxes returns a GlyphBox for the width of the violin
+// at its median, implementing the nplot.GlyphBoxer interface.
+func (v *Violin) GlyphBoxes(plt *nplot.Plot) []nplot.GlyphBox {
+	w := v.Width/2 + v.LineStyle.Width/2
+	b := nplot.GlyphBox{
+		X: plt.X.Norm(v.Location),
+		Y: plt.Y.Norm(v.Median),
+		Rectangle: vg.Rectangle{
+			Min: vg.Point{X: v.Offset - w},
+			Max: vg.Point{X: v.Offset + w},
+		},
+	}
+	if v.Horizontal {
+		b.X = plt.X.Norm(v.Median)
+		b.Y = plt.Y.Norm(v.Location)
+		b.Rectangle = vg.Rectangle{
+			Min: vg.Point{Y: v.Offset - w},
+			Max: vg.Point{Y: v.Offset + w},
+		}
+	}
+	return []nplot.GlyphBox{b}
+}
+
+// Thumbnail fulfills the nplot.Thumbnailer interface.
+func (v *Violin) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+	}
+	if v.FillColor != nil {
+		c.FillPolygon(v.FillColor, c.ClipPolygonY(pts))
+	}
+	pts = append(pts, pts[0])
+	c.StrokeLines(v.LineStyle, c.ClipLinesY(pts)...)
+}
@@ -0,0 +1,130 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+	"github.com/hneemann/nplot/vg/recorder"
+)
+
+func TestBandwidth(t *testing.T) {
+	vs := Values{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	sd := math.Sqrt(55.0 / 6)
+	n5 := math.Pow(10, -0.2)
+	for _, test := range []struct {
+		rule BandwidthRule
+		vs   Values
+		want float64
+	}{
+		{rule: ScottBandwidth, vs: vs, want: 1.06 * sd * n5},
+		// The IQR of 5 is larger than 1.34 sd.
+		{rule: SilvermanBandwidth, vs: vs, want: 0.9 * sd * n5},
+		{rule: SilvermanBandwidth, vs: Values{0, 4, 5, 5, 5, 6, 20}, want: 0.9 * 1.5 / 1.34 * math.Pow(7, -0.2)},
+		{rule: SilvermanBandwidth, vs: Values{3, 3, 3}, want: 1},
+		{rule: ScottBandwidth, vs: Values{3}, want: 1},
+	} {
+		if got := test.rule.Bandwidth(test.vs); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected bandwidth of rule %d for %v: got:%v want:%v", test.rule, test.vs, got, test.want)
+		}
+	}
+}
+
+func TestKDE(t *testing.T) {
+	vs := Values{-1, 0, 0.5, 2, 4}
+	for _, k := range []Kernel{GaussianKernel, EpanechnikovKernel} {
+		s, err := newSample(vs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		e := kde{s: s, kernel: k, h: 0.7}
+		// The density integrates to one.
+		var sum float64
+		const dx = 0.001
+		for x := -10.0; x < 10; x += dx {
+			sum += e.at(x) * dx
+		}
+		if math.Abs(sum-1) > 1e-3 {
+			t.Errorf("unexpected integral of kernel %d: got:%v want:1", k, sum)
+		}
+	}
+
+	s, err := newSample(WeightedValues{{Value: 0, Weight: 2}, {Value: 5, Weight: 0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e := kde{s: s, kernel: EpanechnikovKernel, h: 2}
+	if got, want := e.at(1), 0.75*0.75/2; math.Abs(got-want) > 1e-12 {
+		t.Errorf("unexpected Epanechnikov density: got:%v want:%v", got, want)
+	}
+	if got := e.at(2.1); got != 0 {
+		t.Errorf("unexpected density outside the support: got:%v want:0", got)
+	}
+}
+
+func TestViolin(t *testing.T) {
+	_, err := NewViolin(vg.Points(20), 0, Values{})
+	if err == nil {
+		t.Errorf("expected error for a violin without values")
+	}
+
+	v, err := NewViolin(vg.Points(20), 1, Values{1, 2, 2, 3, 3, 3, 4, 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v.Bandwidth = 0.5
+	xmin, xmax, ymin, ymax := v.DataRange()
+	if xmin != 1 || xmax != 1 || ymin != 0 || ymax != 11 {
+		t.Errorf("unexpected data range: %v %v %v %v", xmin, xmax, ymin, ymax)
+	}
+	v.Horizontal = true
+	xmin, xmax, ymin, ymax = v.DataRange()
+	if xmin != 0 || xmax != 11 || ymin != 1 || ymax != 1 {
+		t.Errorf("unexpected horizontal data range: %v %v %v %v", xmin, xmax, ymin, ymax)
+	}
+
+	dens := v.Density()
+	if len(dens) != v.Samples || dens[0].X != 0 || dens[len(dens)-1].X != 11 {
+		t.Errorf("unexpected density samples: %d from %v to %v", len(dens), dens[0].X, dens[len(dens)-1].X)
+	}
+
+	// The outline of the violin spans its width.
+	v.Horizontal = false
+	v.Inner = ViolinInnerNone
+	for _, test := range []struct {
+		side   ViolinSide
+		lo, hi vg.Length
+	}{
+		{side: ViolinBoth, lo: 40, hi: 60},
+		{side: ViolinLow, lo: 40, hi: 50},
+		{side: ViolinHigh, lo: 50, hi: 60},
+	} {
+		v.Side = test.side
+		p, err := nplot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 2
+		p.Y.Min, p.Y.Max = 0, 11
+
+		var rec recorder.Canvas
+		v.Plot(draw.NewCanvas(&rec, 100, 100), p)
+		lo, hi := vg.Length(math.Inf(1)), vg.Length(math.Inf(-1))
+		for _, a := range rec.Actions {
+			if a, ok := a.(*recorder.Stroke); ok {
+				for _, c := range a.Path {
+					lo, hi = vg.Length(math.Min(float64(lo), float64(c.Pos.X))), vg.Length(math.Max(float64(hi), float64(c.Pos.X)))
+				}
+			}
+		}
+		if math.Abs(float64(lo-test.lo)) > 1e-9 || math.Abs(float64(hi-test.hi)) > 1e-9 {
+			t.Errorf("unexpected extent of side %d: got:%v to %v want:%v to %v", test.side, lo, hi, test.lo, test.hi)
+		}
+	}
+}
@@ -122,6 +122,45 @@ func AddBoxPlots(plt *nplot.Plot, width vg.Length, vs ...interface{}) error {
 	return nil
 }
 
+// AddViolins adds violin plotters to a nplot and
+// sets the X axis of the nplot to be nominal.
+// The variadic arguments must be either strings
+// or plotter.Valuers.  Each valuer adds a violin
+// to the nplot at the X location corresponding to
+// the number of violins added before it.  If a
+// plotter.Valuer is immediately preceeded by a
+// string then the string value is used to label the
+// tick mark for the violin's X location.
+//
+// If an error occurs then none of the plotters are added
+// to the nplot, and the error is returned.
+func AddViolins(plt *nplot.Plot, width vg.Length, vs ...interface{}) error {
+	var ps []nplot.Plotter
+	var names []string
+	name := ""
+	for _, v := range vs {
+		switch t := v.(type) {
+		case string:
+			name = t
+
+		case plotter.Valuer:
+			b, err := plotter.NewViolin(width, float64(len(names)), t)
+			if err != nil {
+				return err
+			}
+			ps = append(ps, b)
+			names = append(names, name)
+			name = ""
+
+		default:
+			panic(fmt.Sprintf("plotutil: AddViolins handles strings and plotter.Valuers, got %T", t))
+		}
+	}
+	plt.Add(ps...)
+	plt.NominalX(names...)
+	return nil
+}
+
 // AddScatters adds Scatter plotters to a nplot.
 // The variadic arguments must be either strings
 // or plotter.XYers.  Each plotter.XYer is added to
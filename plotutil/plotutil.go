@@ -65,6 +65,23 @@ var DefaultGlyphShapes = []draw.GlyphDrawer{
 	draw.PyramidGlyph{},
 }
 
+// ExtendedGlyphShapes is the set of DefaultGlyphShapes
+// followed by the further shapes of the draw package.
+var ExtendedGlyphShapes = []draw.GlyphDrawer{
+	draw.RingGlyph{},
+	draw.SquareGlyph{},
+	draw.TriangleGlyph{},
+	draw.CrossGlyph{},
+	draw.PlusGlyph{},
+	draw.CircleGlyph{},
+	draw.BoxGlyph{},
+	draw.PyramidGlyph{},
+	draw.DiamondGlyph{},
+	draw.StarGlyph{},
+	draw.HexagonGlyph{},
+	draw.PentagonGlyph{},
+}
+
 // Shape returns the ith default glyph shape,
 // wrapping if i is less than zero or greater
 // than the max number of GlyphDrawers
@@ -0,0 +1,88 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nplot
+
+import (
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// Output is a rendering of a plot written by RenderAll.
+type Output struct {
+	// Plot is the rendered plot.
+	Plot *Plot
+
+	// Width and Height are the size of the rendering.
+	Width, Height vg.Length
+
+	// Format is the image format of the rendering,
+	// as accepted by Plot.WriterTo.
+	Format string
+
+	// Options are the options of the image format.
+	Options []draw.FormatOption
+
+	// W is the writer of the rendering.
+	W io.Writer
+
+	// File, if not empty, is the name of the file the
+	// rendering is saved to as by Plot.Save, instead of
+	// writing it to W.  The format is determined by the
+	// extension of the file name and Format is ignored.
+	File string
+}
+
+// RenderAll renders the outputs concurrently by at most
+// runtime.GOMAXPROCS(0) goroutines and returns the first
+// of the errors in the order of the outputs.  Several outputs
+// may render the same plot, for example in different formats.
+// The plots must not be modified until RenderAll returns.
+func RenderAll(outs ...Output) error {
+	errs := make([]error, len(outs))
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i := range outs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = outs[i].render()
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// render writes the output.
+func (o Output) render() error {
+	if o.Plot == nil {
+		return errors.New("nplot: missing plot of output")
+	}
+	if o.File != "" {
+		return o.Plot.Save(o.Width, o.Height, o.File, o.Options...)
+	}
+	if o.W == nil {
+		return errors.New("nplot: missing writer of output")
+	}
+	wt, err := o.Plot.WriterTo(o.Width, o.Height, o.Format, o.Options...)
+	if err != nil {
+		return err
+	}
+	_, err = wt.WriteTo(o.W)
+	return err
+}
@@ -0,0 +1,91 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nplot_test
+
+import (
+	"bytes"
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/cmpimg"
+	"github.com/hneemann/nplot/plotter"
+	"github.com/hneemann/nplot/vg"
+)
+
+func TestRenderAll(t *testing.T) {
+	// Render concurrently on machines with a single CPU, too.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	p, err := nplot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Concurrent"
+	p.X.Tick.Marker = &nplot.DenseTimeTicks{Format: "Jan 2"}
+	p.Y.Tick.Marker = &nplot.DenseTicks{}
+	xys := make(plotter.XYs, 100)
+	for i := range xys {
+		xys[i] = plotter.XY{X: float64(i) * 86400, Y: math.Sin(float64(i) / 10)}
+	}
+	l, s, err := plotter.NewLinePoints(xys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l, s, plotter.NewGrid())
+	p.Legend.Add("sin", l, s)
+	x, y := p.X, p.Y
+
+	formats := []string{"png", "svg", "eps", "tex"}
+	want := make(map[string][]byte)
+	for _, f := range formats {
+		var buf bytes.Buffer
+		err := nplot.RenderAll(nplot.Output{Plot: p, Width: 10 * vg.Centimeter, Height: 8 * vg.Centimeter, Format: f, W: &buf})
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", f, err)
+		}
+		want[f] = buf.Bytes()
+	}
+
+	var outs []nplot.Output
+	bufs := make([]bytes.Buffer, 4*len(formats))
+	for i := range bufs {
+		outs = append(outs, nplot.Output{
+			Plot:   p,
+			Width:  10 * vg.Centimeter,
+			Height: 8 * vg.Centimeter,
+			Format: formats[i%len(formats)],
+			W:      &bufs[i],
+		})
+	}
+	if err := nplot.RenderAll(outs...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, o := range outs {
+		ok, err := cmpimg.Equal(o.Format, bufs[i].Bytes(), want[o.Format])
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", o.Format, err)
+		}
+		if !ok {
+			t.Errorf("unexpected concurrent rendering %d of %s", i, o.Format)
+		}
+	}
+
+	if !sameRangeAndMarker(x, p.X) || !sameRangeAndMarker(y, p.Y) {
+		t.Errorf("unexpected modification of axes")
+	}
+
+	err = nplot.RenderAll(outs[0], nplot.Output{Plot: p, Format: "png"}, nplot.Output{Plot: p, Width: 1, Height: 1, Format: "foo", W: &bytes.Buffer{}})
+	if err == nil || err.Error() != "nplot: missing writer of output" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// sameRangeAndMarker returns whether the axes
+// have the same range and tick marker.
+func sameRangeAndMarker(a, b nplot.Axis) bool {
+	return a.Min == b.Min && a.Max == b.Max && a.Tick.Marker == b.Tick.Marker
+}
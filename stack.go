@@ -0,0 +1,89 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nplot
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+)
+
+// LinkX links the X axes of plots, such as a plot of prices
+// and a plot of traded volumes below it, by setting the range
+// of each X axis to the union of the ranges of all X axes.
+func LinkX(plots ...*Plot) {
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, p := range plots {
+		min = math.Min(min, p.X.Min)
+		max = math.Max(max, p.X.Max)
+	}
+	for _, p := range plots {
+		p.X.Min, p.X.Max = min, max
+	}
+}
+
+// Stack returns a Canvas for each of the plots, stacking
+// them from the top to the bottom of dc with the vertical
+// padding pad between them.  The left and right edges of
+// the DataCanvases of the plots are aligned, and their
+// heights are in proportion to the given heights.  Stack
+// panics if the numbers of plots and heights differ.
+func Stack(plots []*Plot, heights []float64, pad vg.Length, dc draw.Canvas) []draw.Canvas {
+	if len(plots) != len(heights) {
+		panic(fmt.Errorf("nplot: plots (%d) != heights (%d)", len(plots), len(heights)))
+	}
+	var sum float64
+	for _, h := range heights {
+		sum += h
+	}
+	space := dc.Max.Y - dc.Min.Y - pad*vg.Length(len(plots)-1)
+
+	// rows returns the canvases of the plots
+	// for the given total heights.
+	rows := func(hs []vg.Length) []draw.Canvas {
+		o := make([]draw.Canvas, len(plots))
+		top := dc.Max.Y
+		for i, h := range hs {
+			o[i] = dc
+			o[i].Max.Y, o[i].Min.Y = top, top-h
+			top -= h + pad
+		}
+		return o
+	}
+
+	// Distribute the space left by the axes and titles
+	// of the plots among the DataCanvases.
+	hs := make([]vg.Length, len(plots))
+	for i, h := range heights {
+		hs[i] = space * vg.Length(h/sum)
+	}
+	o := rows(hs)
+	margins := make([]vg.Length, len(plots))
+	data := space
+	for i, p := range plots {
+		d := p.DataCanvas(o[i])
+		margins[i] = o[i].Max.Y - o[i].Min.Y - (d.Max.Y - d.Min.Y)
+		data -= margins[i]
+	}
+	for i, h := range heights {
+		hs[i] = margins[i] + data*vg.Length(h/sum)
+	}
+	o = rows(hs)
+
+	// Align the left and right edges of the DataCanvases.
+	var left, right vg.Length
+	for i, p := range plots {
+		d := p.DataCanvas(o[i])
+		left = vg.Length(math.Max(float64(left), float64(d.Min.X-o[i].Min.X)))
+		right = vg.Length(math.Max(float64(right), float64(o[i].Max.X-d.Max.X)))
+	}
+	for i, p := range plots {
+		d := p.DataCanvas(o[i])
+		o[i] = draw.Crop(o[i], left-(d.Min.X-o[i].Min.X), (o[i].Max.X-d.Max.X)-right, 0, 0)
+	}
+	return o
+}
@@ -0,0 +1,66 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nplot_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hneemann/nplot"
+	"github.com/hneemann/nplot/vg/draw"
+	"github.com/hneemann/nplot/vg/recorder"
+)
+
+func TestLinkX(t *testing.T) {
+	a, err := nplot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := nplot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.X.Min, a.X.Max = 0, 5
+	b.X.Min, b.X.Max = 2, 8
+	nplot.LinkX(a, b)
+	if a.X.Min != 0 || a.X.Max != 8 || b.X.Min != 0 || b.X.Max != 8 {
+		t.Errorf("unexpected ranges: got:[%v, %v] [%v, %v] want:[0, 8] [0, 8]", a.X.Min, a.X.Max, b.X.Min, b.X.Max)
+	}
+}
+
+func TestStack(t *testing.T) {
+	top, err := nplot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	top.Title.Text = "Prices"
+	top.X.Min, top.X.Max = 0, 1
+	top.Y.Min, top.Y.Max = 0, 1e6
+	bottom, err := nplot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bottom.X.Label.Text = "Time"
+	bottom.X.Min, bottom.X.Max = 0, 1
+	bottom.Y.Min, bottom.Y.Max = 0, 1
+
+	c := draw.NewCanvas(&recorder.Canvas{}, 300, 400)
+	const pad = 10
+	cs := nplot.Stack([]*nplot.Plot{top, bottom}, []float64{3, 1}, pad, c)
+	if len(cs) != 2 {
+		t.Fatalf("unexpected number of canvases: got:%d want:2", len(cs))
+	}
+	if cs[0].Max.Y != 400 || cs[1].Min.Y != 0 || cs[0].Min.Y-cs[1].Max.Y != pad {
+		t.Errorf("unexpected vertical placement: got:%v %v", cs[0].Rectangle, cs[1].Rectangle)
+	}
+	dt, db := top.DataCanvas(cs[0]), bottom.DataCanvas(cs[1])
+	if dt.Min.X != db.Min.X || dt.Max.X != db.Max.X {
+		t.Errorf("data canvases not aligned: got:%v %v", dt.Rectangle, db.Rectangle)
+	}
+	ht, hb := dt.Max.Y-dt.Min.Y, db.Max.Y-db.Min.Y
+	if math.Abs(float64(ht-3*hb)) > 1e-9 {
+		t.Errorf("unexpected data heights: got:%v %v want ratio 3", ht, hb)
+	}
+}
@@ -5,16 +5,11 @@
 package draw // import "github.com/hneemann/nplot/vg/draw"
 
 import (
-	"fmt"
 	"image/color"
 	"math"
 	"strings"
 
 	"github.com/hneemann/nplot/vg"
-	"github.com/hneemann/nplot/vg/vgeps"
-	"github.com/hneemann/nplot/vg/vgimg"
-	"github.com/hneemann/nplot/vg/vgpdf"
-	"github.com/hneemann/nplot/vg/vgsvg"
 )
 
 // A Canvas is a vector graphics canvas along with
@@ -33,6 +28,12 @@ type TextStyle struct {
 	// Font is the font description.
 	Font vg.Font
 
+	// Typeface selects the face of the font by its family,
+	// weight and style, if its Family is not empty.  The face
+	// is resolved through the registered fonts, using the size
+	// of Font, and Font is used if it can not be resolved.
+	Typeface vg.Typeface
+
 	// Rotation is the text rotation in radians, performed around the axis
 	// defined by XAlign and YAlign.
 	Rotation float64
@@ -80,6 +81,18 @@ type LineStyle struct {
 
 	Dashes   []vg.Length
 	DashOffs vg.Length
+
+	// Cap is the shape of the ends of the line
+	// and of its dashes.
+	Cap vg.LineCap
+
+	// Join is the shape of the corners of the line.
+	Join vg.LineJoin
+
+	// MiterLimit is the miter limit of mitered corners.
+	// If MiterLimit is not positive, vg.DefaultMiterLimit
+	// is used.
+	MiterLimit float64
 }
 
 // A GlyphStyle specifies the look of a glyph used to draw
@@ -124,8 +137,12 @@ func (c *Canvas) DrawGlyphNoClip(sty GlyphStyle, pt vg.Point) {
 }
 
 // Rectangle returns the rectangle surrounding this glyph,
-// assuming that it is drawn centered at 0,0
+// assuming that it is drawn centered at 0,0.  If the
+// Shape implements GlyphRectangler, its rectangle is used.
 func (g GlyphStyle) Rectangle() vg.Rectangle {
+	if r, ok := g.Shape.(GlyphRectangler); ok {
+		return r.GlyphRectangle(g)
+	}
 	return vg.Rectangle{
 		Min: vg.Point{X: -g.Radius, Y: -g.Radius},
 		Max: vg.Point{X: +g.Radius, Y: +g.Radius},
@@ -263,39 +280,6 @@ func New(c vg.CanvasSizer) Canvas {
 	return NewCanvas(c, w, h)
 }
 
-// NewFormattedCanvas creates a new vg.CanvasWriterTo with the specified
-// image format.
-//
-// Supported formats are:
-//
-//  eps, jpg|jpeg, pdf, png, svg, and tif|tiff.
-func NewFormattedCanvas(w, h vg.Length, format string) (vg.CanvasWriterTo, error) {
-	var c vg.CanvasWriterTo
-	switch format {
-	case "eps":
-		c = vgeps.New(w, h)
-
-	case "jpg", "jpeg":
-		c = vgimg.JpegCanvas{Canvas: vgimg.New(w, h)}
-
-	case "pdf":
-		c = vgpdf.New(w, h)
-
-	case "png":
-		c = vgimg.PngCanvas{Canvas: vgimg.New(w, h)}
-
-	case "svg":
-		c = vgsvg.New(w, h)
-
-	case "tif", "tiff":
-		c = vgimg.TiffCanvas{Canvas: vgimg.New(w, h)}
-
-	default:
-		return nil, fmt.Errorf("unsupported format: %q", format)
-	}
-	return c, nil
-}
-
 // NewCanvas returns a new (bounded) draw.Canvas of the given size.
 func NewCanvas(c vg.Canvas, w, h vg.Length) Canvas {
 	return Canvas{
@@ -413,6 +397,13 @@ func (c *Canvas) SetLineStyle(sty LineStyle) {
 		dashDots = append(dashDots, dash)
 	}
 	c.SetLineDash(dashDots, sty.DashOffs)
+	c.SetLineCap(sty.Cap)
+	c.SetLineJoin(sty.Join)
+	limit := sty.MiterLimit
+	if limit <= 0 {
+		limit = vg.DefaultMiterLimit
+	}
+	c.SetMiterLimit(limit)
 }
 
 // StrokeLines draws a line connecting a set of points
@@ -531,6 +522,39 @@ func (c *Canvas) FillPolygon(clr color.Color, pts []vg.Point) {
 	c.Fill(p)
 }
 
+// FillPolygonStyle fills a polygon with the given fill style.
+// If the fill style is nil, the polygon is filled with the
+// given color.
+func (c *Canvas) FillPolygonStyle(clr color.Color, fs vg.FillStyle, pts []vg.Point) {
+	if len(pts) == 0 {
+		return
+	}
+
+	var p vg.Path
+	p.Move(pts[0])
+	for _, pt := range pts[1:] {
+		p.Line(pt)
+	}
+	p.Close()
+	c.FillPath(clr, fs, p)
+}
+
+// FillPath fills a path with the given fill style.
+// If the fill style is nil, the path is filled with the
+// given color.  The fill style of the canvas is left
+// unchanged.
+func (c *Canvas) FillPath(clr color.Color, fs vg.FillStyle, p vg.Path) {
+	c.SetColor(clr)
+	if fs == nil {
+		c.Fill(p)
+		return
+	}
+	c.Push()
+	c.SetFillStyle(fs)
+	c.Fill(p)
+	c.Pop()
+}
+
 // ClipPolygonXY returns a slice of lines that
 // represent the given polygon clipped in both
 // X and Y directions.
@@ -630,13 +654,14 @@ func (c *Canvas) FillText(sty TextStyle, pt vg.Point, txt string) {
 	sin := vg.Length(math.Sin(sty.Rotation))
 	pt.X, pt.Y = pt.Y*sin+pt.X*cos, pt.Y*cos-pt.X*sin
 
+	fnt := sty.SelectedFont()
 	nl := textNLines(txt)
 	ht := sty.Height(txt)
-	pt.Y += ht*vg.Length(sty.YAlign) - sty.Font.Extents().Ascent
+	pt.Y += ht*vg.Length(sty.YAlign) - fnt.Extents().Ascent
 	for i, line := range strings.Split(txt, "\n") {
-		xoffs := vg.Length(sty.XAlign) * sty.Font.Width(line)
+		xoffs := vg.Length(sty.XAlign) * fnt.Width(line)
 		n := vg.Length(nl - i)
-		c.FillString(sty.Font, pt.Add(vg.Point{X: xoffs, Y: n * sty.Font.Size}), line)
+		c.FillString(fnt, pt.Add(vg.Point{X: xoffs, Y: n * fnt.Size}), line)
 	}
 
 	if sty.Rotation != 0 {
@@ -658,12 +683,26 @@ func rotatePoint(theta float64, p vg.Point) vg.Point {
 	}
 }
 
+// SelectedFont returns the font of the style, which is Font
+// with the face selected by Typeface.
+func (sty TextStyle) SelectedFont() vg.Font {
+	if sty.Typeface.Family == "" {
+		return sty.Font
+	}
+	fnt := sty.Font
+	if err := fnt.SetTypeface(sty.Typeface); err != nil {
+		return sty.Font
+	}
+	return fnt
+}
+
 // Width returns the width of lines of text
 // when using the given font before any text rotation is applied.
 func (sty TextStyle) Width(txt string) (max vg.Length) {
+	fnt := sty.SelectedFont()
 	txt = strings.TrimRight(txt, "\n")
 	for _, line := range strings.Split(txt, "\n") {
-		if w := sty.Font.Width(line); w > max {
+		if w := fnt.Width(line); w > max {
 			max = w
 		}
 	}
@@ -677,7 +716,8 @@ func (sty TextStyle) Height(txt string) vg.Length {
 	if nl == 0 {
 		return vg.Length(0)
 	}
-	e := sty.Font.Extents()
+	fnt := sty.SelectedFont()
+	e := fnt.Extents()
 	return e.Height*vg.Length(nl-1) + e.Ascent
 }
 
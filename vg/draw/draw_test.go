@@ -115,3 +115,37 @@ func TestTile(t *testing.T) {
 		}
 	}
 }
+
+func TestTextStyleTypeface(t *testing.T) {
+	fnt, err := vg.MakeFont("Helvetica", 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		face vg.Typeface
+		want string
+	}{
+		{want: "Helvetica"},
+		{face: vg.Typeface{Family: "Courier", Weight: vg.WeightBold}, want: "Courier-Bold"},
+		{face: vg.Typeface{Family: "Times", Style: vg.StyleItalic}, want: "Times-Italic"},
+		{face: vg.Typeface{Family: "no such family"}, want: "Helvetica"},
+	} {
+		sty := TextStyle{Color: color.Black, Font: fnt, Typeface: test.face}
+		if got := sty.SelectedFont(); got.Name() != test.want || got.Size != 12 {
+			t.Errorf("unexpected font for %+v: got:%s %v want:%s 12", test.face, got.Name(), got.Size, test.want)
+		}
+
+		var rec recorder.Canvas
+		c := NewCanvas(&rec, 100, 100)
+		c.FillText(sty, vg.Point{X: 10, Y: 10}, "text")
+		var got []string
+		for _, a := range rec.Actions {
+			if a, ok := a.(*recorder.FillString); ok {
+				got = append(got, a.Font)
+			}
+		}
+		if len(got) != 1 || got[0] != test.want {
+			t.Errorf("unexpected fonts of text for %+v: got:%v want:[%s]", test.face, got, test.want)
+		}
+	}
+}
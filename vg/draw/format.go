@@ -0,0 +1,242 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"fmt"
+	"image/png"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/vgeps"
+	"github.com/hneemann/nplot/vg/vghtml"
+	"github.com/hneemann/nplot/vg/vgimg"
+	"github.com/hneemann/nplot/vg/vgpdf"
+	"github.com/hneemann/nplot/vg/vgsvg"
+	"github.com/hneemann/nplot/vg/vgterm"
+	"github.com/hneemann/nplot/vg/vgtex"
+)
+
+// FontEmbedding specifies whether a format embeds fonts.
+type FontEmbedding int
+
+const (
+	// FontsDefault uses the default of the format.
+	FontsDefault FontEmbedding = iota
+
+	// FontsEmbedded embeds the fonts if the format supports it.
+	FontsEmbedded
+
+	// FontsNotEmbedded references fonts by name only if the
+	// format supports it.
+	FontsNotEmbedded
+)
+
+// FormatOptions holds the options of a canvas created by
+// NewFormattedCanvas.  Formats ignore options that do not
+// apply to them, and use their defaults for zero values.
+type FormatOptions struct {
+	// DPI is the resolution of raster formats
+	// in dots per inch.
+	DPI int
+
+	// JPEGQuality is the quality of JPEG images
+	// in the range 1 to 100.
+	JPEGQuality int
+
+	// PNGCompression is the compression level
+	// of PNG images.
+	PNGCompression png.CompressionLevel
+
+	// EmbedFonts specifies whether vector formats
+	// embed the used fonts.
+	EmbedFonts FontEmbedding
+
+	// Title is the title of document formats
+	// that store one, such as PDF.
+	Title string
+}
+
+// A FormatOption sets an option of a formatted canvas.
+type FormatOption func(*FormatOptions)
+
+// WithDPI sets the resolution of raster formats.
+func WithDPI(dpi int) FormatOption {
+	return func(o *FormatOptions) {
+		o.DPI = dpi
+	}
+}
+
+// WithJPEGQuality sets the quality of JPEG images.
+func WithJPEGQuality(q int) FormatOption {
+	return func(o *FormatOptions) {
+		o.JPEGQuality = q
+	}
+}
+
+// WithPNGCompression sets the compression level of PNG images.
+func WithPNGCompression(l png.CompressionLevel) FormatOption {
+	return func(o *FormatOptions) {
+		o.PNGCompression = l
+	}
+}
+
+// WithEmbeddedFonts sets whether vector formats embed fonts.
+func WithEmbeddedFonts(embed bool) FormatOption {
+	return func(o *FormatOptions) {
+		if embed {
+			o.EmbedFonts = FontsEmbedded
+		} else {
+			o.EmbedFonts = FontsNotEmbedded
+		}
+	}
+}
+
+// WithTitle sets the title of document formats.
+func WithTitle(title string) FormatOption {
+	return func(o *FormatOptions) {
+		o.Title = title
+	}
+}
+
+// A CanvasFunc returns a new canvas of the given size for
+// an output format.
+type CanvasFunc func(w, h vg.Length, opts FormatOptions) (vg.CanvasWriterTo, error)
+
+var formats = struct {
+	sync.RWMutex
+	m map[string]CanvasFunc
+}{m: make(map[string]CanvasFunc)}
+
+// RegisterFormat registers the canvas constructor fn for the
+// given format names.  The names are also the file extensions
+// used by nplot.Plot.Save, and are case insensitive.
+// Registering a name again replaces its constructor.
+func RegisterFormat(fn CanvasFunc, names ...string) {
+	if fn == nil {
+		panic("draw: nil canvas constructor")
+	}
+	formats.Lock()
+	defer formats.Unlock()
+	for _, n := range names {
+		formats.m[strings.ToLower(n)] = fn
+	}
+}
+
+// Formats returns the sorted names of the registered formats.
+func Formats() []string {
+	formats.RLock()
+	defer formats.RUnlock()
+	names := make([]string, 0, len(formats.m))
+	for n := range formats.m {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewFormattedCanvas creates a new vg.CanvasWriterTo with the specified
+// image format and options.
+//
+// Formats are added by RegisterFormat.  The formats registered
+// by default are:
+//
+//	eps, html, jpg|jpeg, js, pdf, png, svg, tex and tif|tiff,
+//	and for terminals ansi, sixel and txt.
+func NewFormattedCanvas(w, h vg.Length, format string, opts ...FormatOption) (vg.CanvasWriterTo, error) {
+	formats.RLock()
+	fn, ok := formats.m[strings.ToLower(format)]
+	formats.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported format: %q", format)
+	}
+	var o FormatOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return fn(w, h, o)
+}
+
+func init() {
+	RegisterFormat(func(w, h vg.Length, o FormatOptions) (vg.CanvasWriterTo, error) {
+		return vgeps.New(w, h), nil
+	}, "eps")
+
+	RegisterFormat(func(w, h vg.Length, o FormatOptions) (vg.CanvasWriterTo, error) {
+		return vghtml.New(w, h), nil
+	}, "html")
+
+	RegisterFormat(func(w, h vg.Length, o FormatOptions) (vg.CanvasWriterTo, error) {
+		return vghtml.ScriptCanvas{Canvas: vghtml.New(w, h)}, nil
+	}, "js")
+
+	RegisterFormat(func(w, h vg.Length, o FormatOptions) (vg.CanvasWriterTo, error) {
+		return vgimg.JpegCanvas{Canvas: newImage(w, h, o), Quality: o.JPEGQuality}, nil
+	}, "jpg", "jpeg")
+
+	RegisterFormat(func(w, h vg.Length, o FormatOptions) (vg.CanvasWriterTo, error) {
+		c := vgpdf.New(w, h)
+		if o.EmbedFonts != FontsDefault {
+			c.EmbedFonts(o.EmbedFonts == FontsEmbedded)
+		}
+		if o.Title != "" {
+			info := c.Info()
+			info.Title = o.Title
+			c.SetInfo(info)
+		}
+		return c, nil
+	}, "pdf")
+
+	RegisterFormat(func(w, h vg.Length, o FormatOptions) (vg.CanvasWriterTo, error) {
+		return vgimg.PngCanvas{Canvas: newImage(w, h, o), Compression: o.PNGCompression}, nil
+	}, "png")
+
+	RegisterFormat(func(w, h vg.Length, o FormatOptions) (vg.CanvasWriterTo, error) {
+		if o.EmbedFonts == FontsEmbedded {
+			return vgsvg.NewWith(vgsvg.UseWH(w, h), vgsvg.UseTextMode(vgsvg.TextEmbedFonts)), nil
+		}
+		return vgsvg.New(w, h), nil
+	}, "svg")
+
+	RegisterFormat(func(w, h vg.Length, o FormatOptions) (vg.CanvasWriterTo, error) {
+		return vgtex.NewDocument(w, h), nil
+	}, "tex")
+
+	RegisterFormat(func(w, h vg.Length, o FormatOptions) (vg.CanvasWriterTo, error) {
+		return vgimg.TiffCanvas{Canvas: newImage(w, h, o)}, nil
+	}, "tif", "tiff")
+
+	RegisterFormat(func(w, h vg.Length, o FormatOptions) (vg.CanvasWriterTo, error) {
+		return newTerm(w, h, o, vgterm.Braille, false), nil
+	}, "txt")
+
+	RegisterFormat(func(w, h vg.Length, o FormatOptions) (vg.CanvasWriterTo, error) {
+		return newTerm(w, h, o, vgterm.Braille, true), nil
+	}, "ansi")
+
+	RegisterFormat(func(w, h vg.Length, o FormatOptions) (vg.CanvasWriterTo, error) {
+		return newTerm(w, h, o, vgterm.Sixel, false), nil
+	}, "sixel")
+}
+
+// newImage returns a new image canvas with the
+// resolution of the options.
+func newImage(w, h vg.Length, o FormatOptions) *vgimg.Canvas {
+	if o.DPI <= 0 {
+		return vgimg.New(w, h)
+	}
+	return vgimg.NewWith(vgimg.UseWH(w, h), vgimg.UseDPI(o.DPI))
+}
+
+// newTerm returns a new terminal canvas with the
+// resolution of the options.
+func newTerm(w, h vg.Length, o FormatOptions, m vgterm.Mode, colors bool) *vgterm.Canvas {
+	if o.DPI <= 0 {
+		return vgterm.NewWith(vgterm.UseWH(w, h), vgterm.UseMode(m), vgterm.UseColors(colors))
+	}
+	return vgterm.NewWith(vgterm.UseWH(w, h), vgterm.UseMode(m), vgterm.UseColors(colors), vgterm.UseDPI(o.DPI))
+}
@@ -0,0 +1,91 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"bytes"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/recorder"
+	"github.com/hneemann/nplot/vg/vgimg"
+	"github.com/hneemann/nplot/vg/vgtex"
+)
+
+type recorderCanvas struct {
+	recorder.Canvas
+	w, h vg.Length
+	opts FormatOptions
+}
+
+func (c *recorderCanvas) Size() (w, h vg.Length) { return c.w, c.h }
+
+func (c *recorderCanvas) WriteTo(w io.Writer) (int64, error) { return 0, nil }
+
+func TestRegisterFormat(t *testing.T) {
+	RegisterFormat(func(w, h vg.Length, o FormatOptions) (vg.CanvasWriterTo, error) {
+		return &recorderCanvas{w: w, h: h, opts: o}, nil
+	}, "Test-Rec", "trec")
+
+	var found int
+	for _, f := range Formats() {
+		if f == "test-rec" || f == "trec" {
+			found++
+		}
+	}
+	if found != 2 {
+		t.Errorf("registered formats missing in %v", Formats())
+	}
+
+	c, err := NewFormattedCanvas(2, 3, "TREC", WithDPI(150), WithJPEGQuality(80),
+		WithPNGCompression(png.BestSpeed), WithEmbeddedFonts(false), WithTitle("Title"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := FormatOptions{DPI: 150, JPEGQuality: 80, PNGCompression: png.BestSpeed, EmbedFonts: FontsNotEmbedded, Title: "Title"}
+	if rc := c.(*recorderCanvas); rc.w != 2 || rc.h != 3 || rc.opts != want {
+		t.Errorf("unexpected canvas: got:%+v want options:%+v", rc, want)
+	}
+
+	if _, err := NewFormattedCanvas(2, 3, "no such format"); err == nil {
+		t.Errorf("expected error for unknown format")
+	}
+}
+
+func TestFormattedCanvas(t *testing.T) {
+	c, err := NewFormattedCanvas(vg.Inch, vg.Inch/2, "png", WithDPI(50))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := img.Bounds().Size(); got.X != 50 || got.Y != 25 {
+		t.Errorf("unexpected image size: got:%v want:(50,25)", got)
+	}
+
+	c, err = NewFormattedCanvas(vg.Inch, vg.Inch, "jpeg", WithJPEGQuality(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q := c.(vgimg.JpegCanvas).Quality; q != 10 {
+		t.Errorf("unexpected JPEG quality: got:%d want:10", q)
+	}
+
+	c, err = NewFormattedCanvas(vg.Inch, vg.Inch, "tex")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := c.(*vgtex.Canvas); !ok {
+		t.Errorf("unexpected canvas type for tex: %T", c)
+	}
+}
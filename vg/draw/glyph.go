@@ -0,0 +1,405 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image"
+	"math"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+// A GlyphRectangler is a GlyphDrawer whose glyphs do not
+// fit into the square spanned by the glyph radius.
+type GlyphRectangler interface {
+	// GlyphRectangle returns the rectangle covered by
+	// a glyph of the given style drawn centered at 0,0.
+	GlyphRectangle(GlyphStyle) vg.Rectangle
+}
+
+// RegularPolygon returns the closed path of a regular polygon
+// with n corners inscribed in the unit circle.  The first corner
+// lies in the direction of the given angle, in radians.
+func RegularPolygon(n int, angle float64) vg.Path {
+	var p vg.Path
+	for i := 0; i < n; i++ {
+		a := angle + 2*math.Pi*float64(i)/float64(n)
+		pt := vg.Point{X: vg.Length(math.Cos(a)), Y: vg.Length(math.Sin(a))}
+		if i == 0 {
+			p.Move(pt)
+		} else {
+			p.Line(pt)
+		}
+	}
+	p.Close()
+	return p
+}
+
+// unitCircle returns the closed path of the unit circle.
+func unitCircle() vg.Path {
+	var p vg.Path
+	p.Move(vg.Point{X: 1})
+	p.Arc(vg.Point{}, 1, 0, 2*math.Pi)
+	p.Close()
+	return p
+}
+
+// glyphPath returns the path p given in units of the glyph
+// radius, scaled by the radius r and moved to the point pt.
+func glyphPath(p vg.Path, r vg.Length, pt vg.Point) vg.Path {
+	q := make(vg.Path, len(p))
+	for i, comp := range p {
+		comp.Pos = pt.Add(comp.Pos.Scale(r))
+		comp.Radius *= r
+		if comp.Control != nil {
+			ctrl := make([]vg.Point, len(comp.Control))
+			for j, c := range comp.Control {
+				ctrl[j] = pt.Add(c.Scale(r))
+			}
+			comp.Control = ctrl
+		}
+		q[i] = comp
+	}
+	return q
+}
+
+// drawOutline strokes or fills the path p given in units of
+// the glyph radius, as done by the outlined and filled glyphs.
+func drawOutline(c *Canvas, sty GlyphStyle, pt vg.Point, p vg.Path, filled bool) {
+	p = glyphPath(p, sty.Radius, pt)
+	if filled {
+		c.Fill(p)
+		return
+	}
+	c.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
+	c.Stroke(p)
+}
+
+// DiamondGlyph is a glyph that draws a square
+// standing on one of its corners.
+type DiamondGlyph struct {
+	// Filled specifies whether the glyph is filled
+	// instead of outlined.
+	Filled bool
+}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g DiamondGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	drawOutline(c, sty, pt, RegularPolygon(4, math.Pi/2), g.Filled)
+}
+
+// PentagonGlyph is a glyph that draws a regular
+// pentagon standing on one of its sides.
+type PentagonGlyph struct {
+	// Filled specifies whether the glyph is filled
+	// instead of outlined.
+	Filled bool
+}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g PentagonGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	drawOutline(c, sty, pt, RegularPolygon(5, math.Pi/2), g.Filled)
+}
+
+// HexagonGlyph is a glyph that draws a regular
+// hexagon standing on one of its sides.
+type HexagonGlyph struct {
+	// Filled specifies whether the glyph is filled
+	// instead of outlined.
+	Filled bool
+}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g HexagonGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	drawOutline(c, sty, pt, RegularPolygon(6, 0), g.Filled)
+}
+
+// StarGlyph is a glyph that draws a five-pointed star.
+type StarGlyph struct {
+	// Filled specifies whether the glyph is filled
+	// instead of outlined.
+	Filled bool
+}
+
+// starInner is the ratio of the inner to the outer radius
+// of a regular five-pointed star.
+const starInner = 0.381966011250105
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g StarGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	var p vg.Path
+	for i := 0; i < 10; i++ {
+		r := vg.Length(1)
+		if i%2 == 1 {
+			r = starInner
+		}
+		a := math.Pi/2 + math.Pi*float64(i)/5
+		v := vg.Point{X: r * vg.Length(math.Cos(a)), Y: r * vg.Length(math.Sin(a))}
+		if i == 0 {
+			p.Move(v)
+		} else {
+			p.Line(v)
+		}
+	}
+	p.Close()
+	drawOutline(c, sty, pt, p, g.Filled)
+}
+
+// ArrowGlyph is a glyph that draws an arrow
+// through the center of the glyph.
+type ArrowGlyph struct {
+	// Angle is the direction of the arrow in radians,
+	// measured counter-clockwise from the positive
+	// X direction.
+	Angle float64
+}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g ArrowGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	const (
+		headLen   = 0.6
+		headWidth = 0.4
+	)
+	sin, cos := math.Sincos(g.Angle)
+	rot := func(x, y float64) vg.Point {
+		return vg.Point{
+			X: pt.X + sty.Radius*vg.Length(x*cos-y*sin),
+			Y: pt.Y + sty.Radius*vg.Length(x*sin+y*cos),
+		}
+	}
+
+	var head vg.Path
+	head.Move(rot(1, 0))
+	head.Line(rot(1-headLen, headWidth))
+	head.Line(rot(1-headLen, -headWidth))
+	head.Close()
+	c.Fill(head)
+
+	c.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
+	var shaft vg.Path
+	shaft.Move(rot(-1, 0))
+	shaft.Line(rot(1-headLen, 0))
+	c.Stroke(shaft)
+}
+
+// HalfFilledGlyph is a glyph that draws the outline
+// of a shape with one half of the shape filled.
+type HalfFilledGlyph struct {
+	// Outline is the closed path of the shape, given in
+	// units of the glyph radius around the center of the
+	// glyph.  If Outline is nil, a circle is drawn.
+	Outline vg.Path
+
+	// Angle is the direction of the filled half in radians,
+	// measured counter-clockwise from the positive X
+	// direction.  The zero value fills the right half.
+	Angle float64
+}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g HalfFilledGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	outline := g.Outline
+	if outline == nil {
+		outline = unitCircle()
+	}
+	p := glyphPath(outline, sty.Radius, pt)
+
+	sin, cos := math.Sincos(g.Angle)
+	dir := vg.Point{X: vg.Length(cos), Y: vg.Length(sin)}
+	var half vg.Path
+	for _, ring := range flatten(p) {
+		ring = clipHalfPlane(ring, pt, dir)
+		if len(ring) < 3 {
+			continue
+		}
+		half.Move(ring[0])
+		for _, v := range ring[1:] {
+			half.Line(v)
+		}
+		half.Close()
+	}
+	if len(half) > 0 {
+		c.Fill(half)
+	}
+
+	c.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
+	c.Stroke(p)
+}
+
+// flatten returns the subpaths of p as polygons,
+// approximating arcs and curves by line segments.
+func flatten(p vg.Path) [][]vg.Point {
+	const n = 32
+	var (
+		rings [][]vg.Point
+		ring  []vg.Point
+	)
+	last := func() vg.Point {
+		if len(ring) == 0 {
+			return vg.Point{}
+		}
+		return ring[len(ring)-1]
+	}
+	for _, comp := range p {
+		switch comp.Type {
+		case vg.MoveComp:
+			if len(ring) > 0 {
+				rings = append(rings, ring)
+			}
+			ring = []vg.Point{comp.Pos}
+		case vg.LineComp:
+			ring = append(ring, comp.Pos)
+		case vg.ArcComp:
+			for i := 0; i <= n; i++ {
+				a := comp.Start + comp.Angle*float64(i)/n
+				ring = append(ring, vg.Point{
+					X: comp.Pos.X + comp.Radius*vg.Length(math.Cos(a)),
+					Y: comp.Pos.Y + comp.Radius*vg.Length(math.Sin(a)),
+				})
+			}
+		case vg.CurveComp:
+			ctrl := append([]vg.Point{last()}, comp.Control...)
+			ctrl = append(ctrl, comp.Pos)
+			for i := 1; i <= n; i++ {
+				ring = append(ring, bezierPoint(ctrl, float64(i)/n))
+			}
+		case vg.CloseComp:
+			if len(ring) > 0 {
+				rings = append(rings, ring)
+			}
+			ring = nil
+		}
+	}
+	if len(ring) > 0 {
+		rings = append(rings, ring)
+	}
+	return rings
+}
+
+// bezierPoint returns the point at t of the Bézier
+// curve with the control points ctrl.
+func bezierPoint(ctrl []vg.Point, t float64) vg.Point {
+	pts := append([]vg.Point(nil), ctrl...)
+	for n := len(pts) - 1; n > 0; n-- {
+		for i := 0; i < n; i++ {
+			pts[i] = pts[i].Add(pts[i+1].Sub(pts[i]).Scale(vg.Length(t)))
+		}
+	}
+	return pts[0]
+}
+
+// clipHalfPlane returns the part of the polygon lying in the
+// half plane through the point o in the direction dir.
+func clipHalfPlane(poly []vg.Point, o, dir vg.Point) []vg.Point {
+	var out []vg.Point
+	side := func(p vg.Point) vg.Length { return p.Sub(o).Dot(dir) }
+	for i, cur := range poly {
+		prev := poly[(i+len(poly)-1)%len(poly)]
+		sc, sp := side(cur), side(prev)
+		if (sc >= 0) != (sp >= 0) {
+			t := sp / (sp - sc)
+			out = append(out, prev.Add(cur.Sub(prev).Scale(t)))
+		}
+		if sc >= 0 {
+			out = append(out, cur)
+		}
+	}
+	return out
+}
+
+// PathGlyph is a glyph that draws an arbitrary path.
+type PathGlyph struct {
+	// Path is the path of the glyph, given in units of
+	// the glyph radius around the center of the glyph.
+	// The point (1, 0) lies one radius to the right of
+	// the center.
+	Path vg.Path
+
+	// Filled specifies whether the path is filled
+	// instead of stroked.
+	Filled bool
+}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g PathGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	drawOutline(c, sty, pt, g.Path, g.Filled)
+}
+
+// GlyphRectangle implements the GlyphRectangler interface.
+func (g PathGlyph) GlyphRectangle(sty GlyphStyle) vg.Rectangle {
+	b := g.Path.Bounds()
+	return vg.Rectangle{Min: b.Min.Scale(sty.Radius), Max: b.Max.Scale(sty.Radius)}
+}
+
+// TextGlyph is a glyph that draws a text, typically
+// a single Unicode symbol, centered on the glyph.
+type TextGlyph struct {
+	// Text is the text of the glyph.
+	Text string
+
+	// Font is the font of the text.  The size of the
+	// font is replaced by twice the glyph radius.
+	Font vg.Font
+}
+
+// style returns the text style of the glyph.
+func (g TextGlyph) style(sty GlyphStyle) TextStyle {
+	fnt := g.Font
+	fnt.Size = 2 * sty.Radius
+	return TextStyle{
+		Color:  sty.Color,
+		Font:   fnt,
+		XAlign: XCenter,
+		YAlign: YCenter,
+	}
+}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g TextGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	c.FillText(g.style(sty), pt, g.Text)
+}
+
+// GlyphRectangle implements the GlyphRectangler interface.
+func (g TextGlyph) GlyphRectangle(sty GlyphStyle) vg.Rectangle {
+	return g.style(sty).Rectangle(g.Text)
+}
+
+// ImageGlyph is a glyph that draws an image, scaled to fit
+// into the square spanned by the glyph radius while keeping
+// its aspect ratio.
+type ImageGlyph struct {
+	Image image.Image
+}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g ImageGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	if g.Image == nil {
+		return
+	}
+	r := g.GlyphRectangle(sty)
+	c.DrawImage(vg.Rectangle{Min: pt.Add(r.Min), Max: pt.Add(r.Max)}, g.Image)
+}
+
+// GlyphRectangle implements the GlyphRectangler interface.
+func (g ImageGlyph) GlyphRectangle(sty GlyphStyle) vg.Rectangle {
+	if g.Image == nil {
+		return vg.Rectangle{}
+	}
+	b := g.Image.Bounds()
+	w, h := vg.Length(b.Dx()), vg.Length(b.Dy())
+	if w <= 0 || h <= 0 {
+		return vg.Rectangle{}
+	}
+	sx, sy := sty.Radius, sty.Radius
+	if w > h {
+		sy *= h / w
+	} else {
+		sx *= w / h
+	}
+	return vg.Rectangle{
+		Min: vg.Point{X: -sx, Y: -sy},
+		Max: vg.Point{X: sx, Y: sy},
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/recorder"
+)
+
+func TestGlyphRectangle(t *testing.T) {
+	var tri vg.Path
+	tri.Move(vg.Point{X: -1, Y: 0})
+	tri.Line(vg.Point{X: 2, Y: 0})
+	tri.Line(vg.Point{X: 0, Y: 1})
+	tri.Close()
+
+	for i, test := range []struct {
+		shape GlyphDrawer
+		want  vg.Rectangle
+	}{
+		{
+			shape: StarGlyph{},
+			want:  vg.Rectangle{Min: vg.Point{X: -2, Y: -2}, Max: vg.Point{X: 2, Y: 2}},
+		},
+		{
+			shape: PathGlyph{Path: tri},
+			want:  vg.Rectangle{Min: vg.Point{X: -2, Y: 0}, Max: vg.Point{X: 4, Y: 2}},
+		},
+		{
+			shape: ImageGlyph{Image: image.NewRGBA(image.Rect(0, 0, 20, 10))},
+			want:  vg.Rectangle{Min: vg.Point{X: -2, Y: -1}, Max: vg.Point{X: 2, Y: 1}},
+		},
+		{
+			shape: ImageGlyph{},
+			want:  vg.Rectangle{},
+		},
+	} {
+		sty := GlyphStyle{Shape: test.shape, Radius: 2}
+		got := sty.Rectangle()
+		if got != test.want {
+			t.Errorf("unexpected rectangle for test %d: got:%+v want:%+v", i, got, test.want)
+		}
+	}
+}
+
+func TestGlyphActions(t *testing.T) {
+	for _, test := range []struct {
+		shape      GlyphDrawer
+		fill, line int
+	}{
+		{shape: DiamondGlyph{}, line: 1},
+		{shape: DiamondGlyph{Filled: true}, fill: 1},
+		{shape: PentagonGlyph{}, line: 1},
+		{shape: HexagonGlyph{Filled: true}, fill: 1},
+		{shape: StarGlyph{}, line: 1},
+		{shape: ArrowGlyph{Angle: math.Pi / 4}, fill: 1, line: 1},
+		{shape: HalfFilledGlyph{}, fill: 1, line: 1},
+		{shape: HalfFilledGlyph{Outline: RegularPolygon(4, 0), Angle: math.Pi}, fill: 1, line: 1},
+	} {
+		var rec recorder.Canvas
+		c := NewCanvas(&rec, 10, 10)
+		c.DrawGlyph(GlyphStyle{Color: color.Black, Shape: test.shape, Radius: 2}, vg.Point{X: 5, Y: 5})
+
+		var fill, line int
+		for _, a := range rec.Actions {
+			switch a.(type) {
+			case *recorder.Fill:
+				fill++
+			case *recorder.Stroke:
+				line++
+			}
+		}
+		if fill != test.fill || line != test.line {
+			t.Errorf("unexpected actions for %T: got %d fills and %d strokes, want %d fills and %d strokes",
+				test.shape, fill, line, test.fill, test.line)
+		}
+	}
+}
+
+func TestClipHalfPlane(t *testing.T) {
+	square := []vg.Point{{X: -1, Y: -1}, {X: 1, Y: -1}, {X: 1, Y: 1}, {X: -1, Y: 1}}
+	got := clipHalfPlane(square, vg.Point{}, vg.Point{X: 1})
+	var area vg.Length
+	for i, p := range got {
+		q := got[(i+1)%len(got)]
+		area += p.X*q.Y - q.X*p.Y
+	}
+	area /= 2
+	if len(got) != 4 || math.Abs(float64(area-2)) > 1e-12 {
+		t.Errorf("unexpected clipped polygon: got:%v with area %v, want area 2", got, area)
+	}
+}
+
+func TestTextGlyph(t *testing.T) {
+	fnt, err := vg.MakeFont("Helvetica", 40)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	g := TextGlyph{Text: "Ab", Font: fnt}
+	sty := GlyphStyle{Color: color.Black, Shape: g, Radius: 5}
+
+	r := sty.Rectangle()
+	small := fnt
+	small.Size = 10
+	if w := small.Width("Ab"); math.Abs(float64(r.Max.X-r.Min.X-w)) > 1e-9 || math.Abs(float64(r.Min.X+r.Max.X)) > 1e-9 {
+		t.Errorf("unexpected rectangle: got:%+v want width %v centered on the glyph", r, w)
+	}
+
+	var rec recorder.Canvas
+	c := NewCanvas(&rec, 100, 100)
+	c.DrawGlyph(sty, vg.Point{X: 50, Y: 50})
+	var texts []*recorder.FillString
+	for _, a := range rec.Actions {
+		if a, ok := a.(*recorder.FillString); ok {
+			texts = append(texts, a)
+		}
+	}
+	if len(texts) != 1 {
+		t.Fatalf("unexpected number of texts: got:%d want:1", len(texts))
+	}
+	if got := texts[0]; got.String != "Ab" || got.Size != 10 || math.Abs(float64(got.Point.X-(50+r.Min.X))) > 1e-9 {
+		t.Errorf("unexpected text: got:%q of size %v at %v want:%q of size 10 at x=%v", got.String, got.Size, got.Point, "Ab", 50+r.Min.X)
+	}
+}
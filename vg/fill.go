@@ -0,0 +1,243 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg
+
+import (
+	"image/color"
+	"math"
+)
+
+// A FillStyle describes how the interior of a filled path is
+// painted when it is not painted with the current color.
+// The implementations provided by this package are
+// LinearGradient, RadialGradient and HatchPattern.
+type FillStyle interface {
+	isFillStyle()
+}
+
+// A GradientStop is a color at a position along a gradient.
+type GradientStop struct {
+	// Offset is the position of the stop in the range [0, 1].
+	Offset float64
+
+	// Color is the color at the stop.
+	Color color.Color
+}
+
+// LinearGradient is a FillStyle blending colors along a line.
+//
+// The start and end points are given relative to the bounding
+// box of the filled path: (0, 0) is the bottom left corner and
+// (1, 1) the top right corner.  Before the start point the first
+// stop color is used, beyond the end point the last stop color
+// is used.
+type LinearGradient struct {
+	X0, Y0 float64
+	X1, Y1 float64
+
+	// Stops holds the colors of the gradient, sorted by
+	// increasing Offset.  A gradient without stops
+	// paints nothing.
+	Stops []GradientStop
+}
+
+func (LinearGradient) isFillStyle() {}
+
+// Points returns the start and end point of the gradient
+// in canvas coordinates for a path with the bounding box r.
+func (g LinearGradient) Points(r Rectangle) (p0, p1 Point) {
+	return relPoint(r, g.X0, g.Y0), relPoint(r, g.X1, g.Y1)
+}
+
+// RadialGradient is a FillStyle blending colors from the center
+// of a circle to its circumference.
+//
+// The center is given relative to the bounding box of the filled
+// path as for LinearGradient, and the radius relative to the larger
+// of the width and height of the bounding box.  Outside of the
+// circle the last stop color is used.
+type RadialGradient struct {
+	CX, CY float64
+	R      float64
+
+	// Stops holds the colors of the gradient, sorted by
+	// increasing Offset.  A gradient without stops
+	// paints nothing.
+	Stops []GradientStop
+}
+
+func (RadialGradient) isFillStyle() {}
+
+// Circle returns the center and radius of the gradient
+// in canvas coordinates for a path with the bounding box r.
+func (g RadialGradient) Circle(r Rectangle) (c Point, rad Length) {
+	sz := r.Size()
+	ext := sz.X
+	if sz.Y > ext {
+		ext = sz.Y
+	}
+	return relPoint(r, g.CX, g.CY), Length(g.R) * ext
+}
+
+// PaddedStops returns the stops extended by copies of the first
+// and the last stop, so that the offsets span the range [0, 1].
+// It is intended for backends which need a color defined over
+// the whole gradient.
+func PaddedStops(stops []GradientStop) []GradientStop {
+	if len(stops) == 0 {
+		return nil
+	}
+	var padded []GradientStop
+	if first := stops[0]; first.Offset > 0 {
+		padded = append(padded, GradientStop{Offset: 0, Color: first.Color})
+	}
+	padded = append(padded, stops...)
+	if last := stops[len(stops)-1]; last.Offset < 1 || len(padded) == 1 {
+		padded = append(padded, GradientStop{Offset: 1, Color: last.Color})
+	}
+	return padded
+}
+
+func relPoint(r Rectangle, x, y float64) Point {
+	sz := r.Size()
+	return Point{
+		X: r.Min.X + Length(x)*sz.X,
+		Y: r.Min.Y + Length(y)*sz.Y,
+	}
+}
+
+// HatchKind is the kind of lines or dots drawn by a HatchPattern.
+type HatchKind int
+
+const (
+	// DiagonalHatch draws lines from bottom left to top right.
+	DiagonalHatch HatchKind = iota
+	// BackDiagonalHatch draws lines from top left to bottom right.
+	BackDiagonalHatch
+	// HorizontalHatch draws horizontal lines.
+	HorizontalHatch
+	// VerticalHatch draws vertical lines.
+	VerticalHatch
+	// CrossHatch draws horizontal and vertical lines.
+	CrossHatch
+	// DiagonalCrossHatch draws lines in both diagonal directions.
+	DiagonalCrossHatch
+	// DotHatch draws a regular grid of filled dots.
+	DotHatch
+)
+
+// HatchPattern is a FillStyle painting a repeating pattern
+// of lines or dots.  Patterns are anchored at the origin of
+// the canvas so that adjacent filled paths line up.
+type HatchPattern struct {
+	Kind HatchKind
+
+	// Color is the color of the lines or dots.
+	Color color.Color
+
+	// Background is the color painted below the lines or dots.
+	// If Background is nil, the background is left unpainted.
+	Background color.Color
+
+	// Spacing is the distance between neighbouring lines or dots.
+	// If Spacing is not positive, 4 points are used.
+	Spacing Length
+
+	// Width is the width of the lines or the radius of the dots.
+	// If Width is not positive, 0.5 points are used.
+	Width Length
+}
+
+func (HatchPattern) isFillStyle() {}
+
+// LineWidth returns the width of the pattern lines
+// or the radius of the pattern dots.
+func (h HatchPattern) LineWidth() Length {
+	if h.Width <= 0 {
+		return Points(0.5)
+	}
+	return h.Width
+}
+
+// LineSpacing returns the distance between neighbouring
+// lines or dots of the pattern.
+func (h HatchPattern) LineSpacing() Length {
+	if h.Spacing <= 0 {
+		return Points(4)
+	}
+	return h.Spacing
+}
+
+// Lines returns the line segments of the pattern covering
+// the rectangle r.  The segments are meant to be stroked with
+// the pattern's LineWidth.  Lines returns an empty path for
+// a DotHatch.
+func (h HatchPattern) Lines(r Rectangle) Path {
+	var p Path
+	switch h.Kind {
+	case HorizontalHatch:
+		hatchLines(&p, r, h.LineSpacing(), 0)
+	case VerticalHatch:
+		hatchLines(&p, r, h.LineSpacing(), math.Pi/2)
+	case CrossHatch:
+		hatchLines(&p, r, h.LineSpacing(), 0)
+		hatchLines(&p, r, h.LineSpacing(), math.Pi/2)
+	case DiagonalHatch:
+		hatchLines(&p, r, h.LineSpacing(), math.Pi/4)
+	case BackDiagonalHatch:
+		hatchLines(&p, r, h.LineSpacing(), -math.Pi/4)
+	case DiagonalCrossHatch:
+		hatchLines(&p, r, h.LineSpacing(), math.Pi/4)
+		hatchLines(&p, r, h.LineSpacing(), -math.Pi/4)
+	}
+	return p
+}
+
+// Dots returns the dots of the pattern covering the
+// rectangle r.  The dots are meant to be filled.  Dots
+// returns an empty path unless the pattern is a DotHatch.
+func (h HatchPattern) Dots(r Rectangle) Path {
+	var p Path
+	if h.Kind != DotHatch {
+		return p
+	}
+	s := h.LineSpacing()
+	rad := h.LineWidth()
+	x0 := Length(math.Ceil(float64((r.Min.X-rad)/s))) * s
+	y0 := Length(math.Ceil(float64((r.Min.Y-rad)/s))) * s
+	for y := y0; y <= r.Max.Y+rad; y += s {
+		for x := x0; x <= r.Max.X+rad; x += s {
+			p.Move(Point{X: x + rad, Y: y})
+			p.Arc(Point{X: x, Y: y}, rad, 0, 2*math.Pi)
+			p.Close()
+		}
+	}
+	return p
+}
+
+// hatchLines appends parallel lines with the given spacing and
+// direction to p, so that they cover the rectangle r.  The lines
+// are placed on multiples of the spacing measured from the origin.
+func hatchLines(p *Path, r Rectangle, s Length, angle float64) {
+	dir := Point{X: Length(math.Cos(angle)), Y: Length(math.Sin(angle))}
+	norm := Point{X: -dir.Y, Y: dir.X}
+	corners := []Point{
+		r.Min,
+		{X: r.Max.X, Y: r.Min.Y},
+		r.Max,
+		{X: r.Min.X, Y: r.Max.Y},
+	}
+	nmin, nmax := Length(math.Inf(1)), Length(math.Inf(-1))
+	dmin, dmax := Length(math.Inf(1)), Length(math.Inf(-1))
+	for _, c := range corners {
+		n, d := c.Dot(norm), c.Dot(dir)
+		nmin, nmax = Length(math.Min(float64(nmin), float64(n))), Length(math.Max(float64(nmax), float64(n)))
+		dmin, dmax = Length(math.Min(float64(dmin), float64(d))), Length(math.Max(float64(dmax), float64(d)))
+	}
+	for n := Length(math.Ceil(float64(nmin/s))) * s; n <= nmax; n += s {
+		p.Move(norm.Scale(n).Add(dir.Scale(dmin)))
+		p.Line(norm.Scale(n).Add(dir.Scale(dmax)))
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg_test
+
+import (
+	"bytes"
+	"image/color"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/draw"
+	"github.com/hneemann/nplot/vg/recorder"
+)
+
+func TestPathBounds(t *testing.T) {
+	for i, test := range []struct {
+		path func() vg.Path
+		want vg.Rectangle
+	}{
+		{
+			path: func() vg.Path { return nil },
+			want: vg.Rectangle{},
+		},
+		{
+			path: func() vg.Path {
+				var p vg.Path
+				p.Move(vg.Point{X: 1, Y: 2})
+				p.Line(vg.Point{X: -3, Y: 5})
+				p.Close()
+				return p
+			},
+			want: vg.Rectangle{Min: vg.Point{X: -3, Y: 2}, Max: vg.Point{X: 1, Y: 5}},
+		},
+		{
+			path: func() vg.Path {
+				var p vg.Path
+				p.Move(vg.Point{X: 2, Y: 0})
+				p.Arc(vg.Point{}, 2, 0, math.Pi)
+				return p
+			},
+			want: vg.Rectangle{Min: vg.Point{X: -2, Y: 0}, Max: vg.Point{X: 2, Y: 2}},
+		},
+		{
+			path: func() vg.Path {
+				var p vg.Path
+				p.Move(vg.Point{})
+				p.QuadTo(vg.Point{X: 1, Y: 4}, vg.Point{X: 2, Y: 0})
+				return p
+			},
+			want: vg.Rectangle{Min: vg.Point{}, Max: vg.Point{X: 2, Y: 4}},
+		},
+	} {
+		got := test.path().Bounds()
+		if !rectEqual(got, test.want, 1e-9) {
+			t.Errorf("unexpected bounds for test %d: got:%+v want:%+v", i, got, test.want)
+		}
+	}
+}
+
+func rectEqual(a, b vg.Rectangle, tol vg.Length) bool {
+	eq := func(x, y vg.Length) bool { return math.Abs(float64(x-y)) <= float64(tol) }
+	return eq(a.Min.X, b.Min.X) && eq(a.Min.Y, b.Min.Y) && eq(a.Max.X, b.Max.X) && eq(a.Max.Y, b.Max.Y)
+}
+
+func TestPaddedStops(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	for i, test := range []struct {
+		stops []vg.GradientStop
+		want  []vg.GradientStop
+	}{
+		{
+			stops: nil,
+			want:  nil,
+		},
+		{
+			stops: []vg.GradientStop{{Offset: 0.5, Color: red}},
+			want:  []vg.GradientStop{{Offset: 0, Color: red}, {Offset: 0.5, Color: red}, {Offset: 1, Color: red}},
+		},
+		{
+			stops: []vg.GradientStop{{Offset: 0, Color: red}, {Offset: 1, Color: blue}},
+			want:  []vg.GradientStop{{Offset: 0, Color: red}, {Offset: 1, Color: blue}},
+		},
+		{
+			stops: []vg.GradientStop{{Offset: 0.25, Color: red}, {Offset: 0.75, Color: blue}},
+			want: []vg.GradientStop{
+				{Offset: 0, Color: red}, {Offset: 0.25, Color: red},
+				{Offset: 0.75, Color: blue}, {Offset: 1, Color: blue},
+			},
+		},
+	} {
+		got := vg.PaddedStops(test.stops)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected stops for test %d: got:%v want:%v", i, got, test.want)
+		}
+	}
+}
+
+func TestHatchPattern(t *testing.T) {
+	r := vg.Rectangle{Max: vg.Point{X: 10, Y: 10}}
+	for _, test := range []struct {
+		kind      vg.HatchKind
+		wantLines int
+		wantDots  int
+	}{
+		{kind: vg.HorizontalHatch, wantLines: 3},
+		{kind: vg.VerticalHatch, wantLines: 3},
+		{kind: vg.CrossHatch, wantLines: 6},
+		{kind: vg.DotHatch, wantDots: 9},
+	} {
+		h := vg.HatchPattern{Kind: test.kind, Spacing: 5}
+		var lines, dots int
+		for _, c := range h.Lines(r) {
+			if c.Type == vg.MoveComp {
+				lines++
+			}
+		}
+		for _, c := range h.Dots(r) {
+			if c.Type == vg.ArcComp {
+				dots++
+			}
+		}
+		if lines != test.wantLines || dots != test.wantDots {
+			t.Errorf("unexpected pattern for kind %d: got %d lines and %d dots, want %d lines and %d dots",
+				test.kind, lines, dots, test.wantLines, test.wantDots)
+		}
+	}
+}
+
+func TestFillPathStyle(t *testing.T) {
+	var rec recorder.Canvas
+	c := draw.NewCanvas(&rec, 10, 10)
+	fs := vg.HatchPattern{Kind: vg.DiagonalHatch}
+	c.FillPolygonStyle(color.Black, fs, []vg.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}})
+
+	var got bytes.Buffer
+	for _, a := range rec.Actions {
+		switch a.(type) {
+		case *recorder.SetFillStyle:
+			got.WriteString("SetFillStyle ")
+		case *recorder.Push:
+			got.WriteString("Push ")
+		case *recorder.Pop:
+			got.WriteString("Pop ")
+		case *recorder.Fill:
+			got.WriteString("Fill ")
+		}
+	}
+	const want = "Push SetFillStyle Fill Pop "
+	if got.String() != want {
+		t.Errorf("unexpected actions: got:%q want:%q", got.String(), want)
+	}
+}
@@ -12,8 +12,11 @@ package vg
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/hneemann/nplot/vg/fonts"
@@ -88,7 +91,8 @@ type Font struct {
 }
 
 // MakeFont returns a font object.  The name of the font must
-// be a key of the FontMap.  The font file is located by searching
+// be a key of the FontMap or the name of a font added by
+// AddFont or RegisterFont.  The font file is located by searching
 // the FontDirs slice for a directory containing the relevant font
 // file.  The font file name is name mapped by FontMap with the
 // .ttf extension.  For example, the font file for the font name
@@ -130,6 +134,41 @@ func (f *Font) SetName(name string) error {
 	return nil
 }
 
+// MarshalText implements the encoding.TextMarshaler interface.
+// The font is encoded as its name followed by its size in points,
+// for example "Times-Roman 12".  The zero Font is encoded as the
+// empty string.
+func (f *Font) MarshalText() ([]byte, error) {
+	if f.name == "" && f.Size == 0 {
+		return nil, nil
+	}
+	return []byte(f.name + " " + strconv.FormatFloat(f.Size.Points(), 'g', -1, 64)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It returns an error if the font is not available.
+func (f *Font) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*f = Font{}
+		return nil
+	}
+	s := string(text)
+	i := strings.LastIndex(s, " ")
+	if i < 0 {
+		return fmt.Errorf("vg: invalid font %q", s)
+	}
+	size, err := strconv.ParseFloat(s[i+1:], 64)
+	if err != nil {
+		return fmt.Errorf("vg: invalid font size in %q", s)
+	}
+	fnt, err := MakeFont(s[:i], Points(size))
+	if err != nil {
+		return err
+	}
+	*f = fnt
+	return nil
+}
+
 // FontExtents contains font metric information.
 type FontExtents struct {
 	// Ascent is the distance that the text
@@ -158,8 +197,22 @@ func (f *Font) Extents() FontExtents {
 	}
 }
 
-// Width returns width of a string when drawn using the font.
+// Width returns width of a string when drawn using the font
+// and the FontFallbacks.
 func (f *Font) Width(s string) Length {
+	if len(fallbacks()) == 0 {
+		return f.width(s)
+	}
+	var w Length
+	for _, r := range f.Runs(s) {
+		w += r.Font.width(r.Text)
+	}
+	return w
+}
+
+// width returns width of a string when drawn using
+// only the font itself.
+func (f *Font) width(s string) Length {
 	// scale converts truetype.FUnit to float64
 	scale := f.Size / Points(float64(f.font.FUnitsPerEm()))
 
@@ -198,15 +251,18 @@ func getFont(name string) (*truetype.Font, error) {
 	}
 
 	font, err := truetype.Parse(bytes)
-	if err == nil {
-		fontLock.Lock()
-		loadedFonts[name] = font
-		fontLock.Unlock()
-	} else {
-		err = errors.New("Failed to parse font file: " + err.Error())
+	if err != nil {
+		return nil, errors.New("Failed to parse font file: " + err.Error())
 	}
 
-	return font, err
+	fontLock.Lock()
+	defer fontLock.Unlock()
+	if f, ok := loadedFonts[name]; ok {
+		// The font was loaded by another goroutine meanwhile.
+		return f, nil
+	}
+	loadedFonts[name] = font
+	return font, nil
 }
 
 // fontData returns the []byte data for a font name or an error if it is not found.
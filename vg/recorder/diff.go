@@ -0,0 +1,575 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+// Tolerance holds the tolerances used by Diff to compare
+// drawing actions.
+type Tolerance struct {
+	// Length is the maximum distance of two points,
+	// and the maximum difference of line widths and
+	// font sizes, that are considered equal.
+	Length vg.Length
+
+	// Color is the maximum difference of the color
+	// channels, in the range [0, 1], of two colors
+	// that are considered equal.
+	Color float64
+}
+
+// ChangeKind is the kind of a Change.
+type ChangeKind int
+
+const (
+	// Added is a drawing action only present in the
+	// new recording.
+	Added ChangeKind = iota
+	// Removed is a drawing action only present in the
+	// old recording.
+	Removed
+	// Changed is a drawing action present in both
+	// recordings with different properties.
+	Changed
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+}
+
+// Change is a difference of two recordings found by Diff.
+type Change struct {
+	Kind ChangeKind
+
+	// Old and New are the drawing actions in the old and the
+	// new recording.  Old is nil for added actions and New is
+	// nil for removed actions.
+	Old, New Action
+
+	// OldIndex and NewIndex are the indices of Old and New
+	// in the Actions of the recordings, or -1 if the
+	// action is nil.
+	OldIndex, NewIndex int
+
+	// Fields holds the names of the properties that differ
+	// for changed actions, for example "path" or "color".
+	Fields []string
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("added %d: %s", c.NewIndex, c.New.Call())
+	case Removed:
+		return fmt.Sprintf("removed %d: %s", c.OldIndex, c.Old.Call())
+	default:
+		return fmt.Sprintf("changed %d->%d (%s): %s -> %s",
+			c.OldIndex, c.NewIndex, strings.Join(c.Fields, ", "), c.Old.Call(), c.New.Call())
+	}
+}
+
+// Diff returns the structural differences between the drawing
+// actions recorded in a and b.
+//
+// Diff compares the strokes, fills, text and images drawn by
+// the recordings, rather than the recorded actions.  The state
+// set by the other actions, such as the color or the current
+// transformation, is applied to the drawing actions before
+// comparison, so recordings that draw the same shapes by
+// different sequences of state changes have no differences.
+// Comments are ignored.
+//
+// The drawing actions are aligned by a shortest edit script.
+// Unaligned removed and added actions of the same kind between
+// two aligned actions are reported as changes.
+func Diff(a, b *Canvas, tol Tolerance) []Change {
+	ea, eb := elements(a.Actions), elements(b.Actions)
+	cache := make(map[[2]int]bool)
+	equal := func(i, j int) bool {
+		k := [2]int{i, j}
+		eq, ok := cache[k]
+		if !ok {
+			eq = len(ea[i].diff(eb[j], tol)) == 0
+			cache[k] = eq
+		}
+		return eq
+	}
+
+	var (
+		changes        []Change
+		removed, added []*element
+	)
+	i, j := 0, 0
+	for _, m := range align(len(ea), len(eb), equal) {
+		for ; i < m[0]; i++ {
+			removed = append(removed, &ea[i])
+		}
+		for ; j < m[1]; j++ {
+			added = append(added, &eb[j])
+		}
+		changes = append(changes, pair(removed, added, tol)...)
+		removed, added = removed[:0], added[:0]
+		i, j = m[0]+1, m[1]+1
+	}
+	for ; i < len(ea); i++ {
+		removed = append(removed, &ea[i])
+	}
+	for ; j < len(eb); j++ {
+		added = append(added, &eb[j])
+	}
+	return append(changes, pair(removed, added, tol)...)
+}
+
+// align returns the index pairs of the aligned elements of
+// sequences of the lengths n and m, in increasing order.  It
+// uses the O(ND) difference algorithm of Myers, which takes
+// time and space proportional to the number of differences
+// for similar sequences, rather than to n*m.
+func align(n, m int, equal func(i, j int) bool) [][2]int {
+	max := n + m
+	off := max + 1
+	v := make([]int, 2*max+3)
+
+	// trace[d] holds the furthest reaching x of the
+	// diagonals -d..d before step d.
+	var trace [][]int
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v[off-d:off+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && equal(x, y) {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, n, m)
+			}
+		}
+	}
+	panic("recorder: unreachable")
+}
+
+// backtrack returns the aligned index pairs of the
+// furthest reaching paths of align.
+func backtrack(trace [][]int, x, y int) [][2]int {
+	var pairs [][2]int
+	for d := len(trace) - 1; d > 0; d-- {
+		vd := trace[d]
+		at := func(k int) int { return vd[k+d] }
+		k := x - y
+		var pk int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			pk = k + 1
+		} else {
+			pk = k - 1
+		}
+		// The snake of step d starts after the move
+		// from the furthest reaching x of diagonal pk.
+		px := at(pk)
+		sx := px
+		if pk == k-1 {
+			sx++
+		}
+		for x > sx {
+			pairs = append(pairs, [2]int{x - 1, y - 1})
+			x--
+			y--
+		}
+		x, y = px, px-pk
+	}
+	for x > 0 && y > 0 {
+		pairs = append(pairs, [2]int{x - 1, y - 1})
+		x--
+		y--
+	}
+	for i, j := 0, len(pairs)-1; i < j; i, j = i+1, j-1 {
+		pairs[i], pairs[j] = pairs[j], pairs[i]
+	}
+	return pairs
+}
+
+// pair returns the changes for the removed and added elements
+// between two aligned elements.  Removed and added elements of
+// the same kind are paired in order to changes.
+func pair(removed, added []*element, tol Tolerance) []Change {
+	var changes []Change
+	used := make([]bool, len(added))
+	for _, r := range removed {
+		c := Change{Kind: Removed, Old: r.action, OldIndex: r.index, NewIndex: -1}
+		for k, a := range added {
+			if !used[k] && a.kind == r.kind {
+				used[k] = true
+				c = Change{Kind: Changed, Old: r.action, New: a.action,
+					OldIndex: r.index, NewIndex: a.index, Fields: r.diff(*a, tol)}
+				break
+			}
+		}
+		changes = append(changes, c)
+	}
+	for k, a := range added {
+		if !used[k] {
+			changes = append(changes, Change{Kind: Added, New: a.action, OldIndex: -1, NewIndex: a.index})
+		}
+	}
+	return changes
+}
+
+type elementKind int
+
+const (
+	strokeElement elementKind = iota
+	fillElement
+	textElement
+	imageElement
+)
+
+// element is a drawing action with the state
+// applied to it.
+type element struct {
+	kind   elementKind
+	action Action
+	index  int
+
+	// path holds the transformed path of strokes and fills,
+	// and the transformed corners of images.
+	path vg.Path
+
+	// color is the color of strokes, fills and text.
+	color color.NRGBA
+
+	// width, dashes and offset are the transformed line
+	// style of strokes, and width the font size of text.
+	width  vg.Length
+	dashes []vg.Length
+	offset vg.Length
+	cap    vg.LineCap
+	join   vg.LineJoin
+	limit  float64
+
+	// style is the encoded fill style of fills.
+	style string
+
+	// font and text are the font name and the string of text.
+	font, text string
+
+	img image.Image
+}
+
+// state is the graphics state of a replayed recording.
+type state struct {
+	m      affine
+	color  color.Color
+	width  vg.Length
+	dashes []vg.Length
+	offset vg.Length
+	cap    vg.LineCap
+	join   vg.LineJoin
+	limit  float64
+	style  vg.FillStyle
+}
+
+// elements replays the actions and returns their
+// drawing actions.
+func elements(actions []Action) []element {
+	var (
+		elems []element
+		stack []state
+	)
+	s := state{m: identity, limit: vg.DefaultMiterLimit}
+	for i, a := range actions {
+		e := element{action: a, index: i}
+		switch a := a.(type) {
+		case *SetLineWidth:
+			s.width = a.Width
+		case *SetLineDash:
+			s.dashes, s.offset = a.Dashes, a.Offsets
+		case *SetLineCap:
+			s.cap = a.Cap
+		case *SetLineJoin:
+			s.join = a.Join
+		case *SetMiterLimit:
+			s.limit = a.Limit
+		case *SetColor:
+			s.color = a.Color
+		case *SetFillStyle:
+			s.style = a.Style
+		case *Rotate:
+			s.m = s.m.rotate(a.Angle)
+		case *Translate:
+			s.m = s.m.translate(a.Point)
+		case *Scale:
+			s.m = s.m.scale(a.X, a.Y)
+		case *Push:
+			stack = append(stack, s)
+		case *Pop:
+			if len(stack) > 0 {
+				s = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+		case *Stroke:
+			e.kind = strokeElement
+			e.path = s.m.path(a.Path)
+			e.color = nrgba(s.color)
+			f := vg.Length(s.m.factor())
+			e.width = s.width * f
+			for _, d := range s.dashes {
+				e.dashes = append(e.dashes, d*f)
+			}
+			e.offset = s.offset * f
+			e.cap, e.join, e.limit = s.cap, s.join, s.limit
+			elems = append(elems, e)
+		case *Fill:
+			e.kind = fillElement
+			e.path = s.m.path(a.Path)
+			e.color = nrgba(s.color)
+			if st := encodeStyle(s.style); st != nil {
+				b, _ := json.Marshal(st)
+				e.style = string(b)
+			}
+			elems = append(elems, e)
+		case *FillString:
+			e.kind = textElement
+			e.path = vg.Path{{Type: vg.MoveComp, Pos: s.m.point(a.Point)}}
+			e.color = nrgba(s.color)
+			e.width = a.Size * vg.Length(s.m.factor())
+			e.font, e.text = a.Font, a.String
+			elems = append(elems, e)
+		case *DrawImage:
+			e.kind = imageElement
+			r := a.Rectangle
+			e.path = s.m.path(vg.Path{
+				{Type: vg.MoveComp, Pos: r.Min},
+				{Type: vg.LineComp, Pos: vg.Point{X: r.Max.X, Y: r.Min.Y}},
+				{Type: vg.LineComp, Pos: r.Max},
+				{Type: vg.LineComp, Pos: vg.Point{X: r.Min.X, Y: r.Max.Y}},
+			})
+			e.img = a.Image
+			elems = append(elems, e)
+		}
+	}
+	return elems
+}
+
+// diff returns the names of the properties of e
+// and o that differ.
+func (e element) diff(o element, tol Tolerance) []string {
+	if e.kind != o.kind {
+		return []string{"kind"}
+	}
+	var fields []string
+	add := func(differs bool, name string) {
+		if differs {
+			fields = append(fields, name)
+		}
+	}
+	length := func(a, b vg.Length) bool {
+		return math.Abs(float64(a-b)) > float64(tol.Length)
+	}
+
+	switch e.kind {
+	case strokeElement, fillElement:
+		add(!equalPaths(e.path, o.path, tol), "path")
+	case textElement, imageElement:
+		add(!equalPaths(e.path, o.path, tol), "position")
+	}
+	if e.kind != imageElement {
+		add(!equalColors(e.color, o.color, tol), "color")
+	}
+	switch e.kind {
+	case strokeElement:
+		add(length(e.width, o.width), "width")
+		dashes := len(e.dashes) != len(o.dashes) || length(e.offset, o.offset)
+		for i := 0; !dashes && i < len(e.dashes); i++ {
+			dashes = length(e.dashes[i], o.dashes[i])
+		}
+		add(dashes, "dashes")
+		add(e.cap != o.cap, "cap")
+		add(e.join != o.join, "join")
+		add(e.join == vg.MiterJoin && e.limit != o.limit, "limit")
+	case fillElement:
+		add(e.style != o.style, "style")
+	case textElement:
+		add(e.text != o.text, "text")
+		add(e.font != o.font, "font")
+		add(length(e.width, o.width), "size")
+	case imageElement:
+		add(!equalImages(e.img, o.img, tol), "image")
+	}
+	return fields
+}
+
+func equalPaths(a, b vg.Path, tol Tolerance) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	l := float64(tol.Length)
+	near := func(p, q vg.Point) bool {
+		return math.Hypot(float64(p.X-q.X), float64(p.Y-q.Y)) <= l
+	}
+	for i, ca := range a {
+		cb := b[i]
+		if ca.Type != cb.Type || !near(ca.Pos, cb.Pos) || len(ca.Control) != len(cb.Control) {
+			return false
+		}
+		for k := range ca.Control {
+			if !near(ca.Control[k], cb.Control[k]) {
+				return false
+			}
+		}
+		if ca.Type == vg.ArcComp {
+			if math.Abs(float64(ca.Radius-cb.Radius)) > l {
+				return false
+			}
+			// Compare the arc end points, as the angles
+			// of small arcs do not matter.
+			for _, t := range []float64{0, 1} {
+				if !near(arcPoint(ca, t), arcPoint(cb, t)) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func arcPoint(c vg.PathComp, t float64) vg.Point {
+	a := c.Start + t*c.Angle
+	return vg.Point{
+		X: c.Pos.X + c.Radius*vg.Length(math.Cos(a)),
+		Y: c.Pos.Y + c.Radius*vg.Length(math.Sin(a)),
+	}
+}
+
+func equalColors(a, b color.Color, tol Tolerance) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	near := func(x, y uint32) bool {
+		return math.Abs(float64(x)-float64(y))/math.MaxUint16 <= tol.Color
+	}
+	return near(ar, br) && near(ag, bg) && near(ab, bb) && near(aa, ba)
+}
+
+func equalImages(a, b image.Image, tol Tolerance) bool {
+	ra, rb := a.Bounds(), b.Bounds()
+	if ra.Dx() != rb.Dx() || ra.Dy() != rb.Dy() {
+		return false
+	}
+	for y := 0; y < ra.Dy(); y++ {
+		for x := 0; x < ra.Dx(); x++ {
+			if !equalColors(a.At(ra.Min.X+x, ra.Min.Y+y), b.At(rb.Min.X+x, rb.Min.Y+y), tol) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// nrgba returns the non-premultiplied color of clr.
+// A nil color is black.
+func nrgba(clr color.Color) color.NRGBA {
+	if clr == nil {
+		return color.NRGBA{A: 0xff}
+	}
+	return color.NRGBAModel.Convert(clr).(color.NRGBA)
+}
+
+// affine is the affine transformation mapping (x, y) to
+// (a*x + c*y + e, b*x + d*y + f).
+type affine struct {
+	a, b, c, d, e, f float64
+}
+
+var identity = affine{a: 1, d: 1}
+
+func (m affine) translate(p vg.Point) affine {
+	x, y := p.X.Points(), p.Y.Points()
+	m.e += m.a*x + m.c*y
+	m.f += m.b*x + m.d*y
+	return m
+}
+
+func (m affine) scale(x, y float64) affine {
+	m.a *= x
+	m.b *= x
+	m.c *= y
+	m.d *= y
+	return m
+}
+
+func (m affine) rotate(angle float64) affine {
+	sin, cos := math.Sincos(angle)
+	return affine{
+		a: m.a*cos + m.c*sin,
+		b: m.b*cos + m.d*sin,
+		c: m.c*cos - m.a*sin,
+		d: m.d*cos - m.b*sin,
+		e: m.e,
+		f: m.f,
+	}
+}
+
+// factor returns the mean scale factor of lengths.
+func (m affine) factor() float64 {
+	return math.Sqrt(math.Abs(m.a*m.d - m.b*m.c))
+}
+
+func (m affine) point(p vg.Point) vg.Point {
+	x, y := p.X.Points(), p.Y.Points()
+	return vg.Point{
+		X: vg.Length(m.a*x + m.c*y + m.e),
+		Y: vg.Length(m.b*x + m.d*y + m.f),
+	}
+}
+
+// path returns the transformed path.  Arcs are transformed
+// by their center, their radius by the mean scale factor and
+// their start angle by the rotation of the transformation.
+func (m affine) path(p vg.Path) vg.Path {
+	if len(p) == 0 {
+		return nil
+	}
+	rot := math.Atan2(m.b, m.a)
+	mirror := m.a*m.d-m.b*m.c < 0
+	t := make(vg.Path, len(p))
+	for i, c := range p {
+		t[i] = vg.PathComp{Type: c.Type, Pos: m.point(c.Pos)}
+		for _, ctrl := range c.Control {
+			t[i].Control = append(t[i].Control, m.point(ctrl))
+		}
+		if c.Type == vg.ArcComp {
+			t[i].Radius = c.Radius * vg.Length(m.factor())
+			t[i].Start, t[i].Angle = c.Start+rot, c.Angle
+			if mirror {
+				t[i].Start, t[i].Angle = rot-c.Start, -c.Angle
+			}
+		}
+	}
+	return t
+}
@@ -0,0 +1,92 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package recorder
+
+import (
+	"image/color"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+func TestDiff(t *testing.T) {
+	line := func(x0, y0, x1, y1 vg.Length) vg.Path {
+		var p vg.Path
+		p.Move(vg.Point{X: x0, Y: y0})
+		p.Line(vg.Point{X: x1, Y: y1})
+		return p
+	}
+
+	var a Canvas
+	a.SetColor(color.Black)
+	a.Stroke(line(0, 0, 10, 10))
+	a.Comment("box")
+	a.Fill(line(0, 0, 5, 5))
+	a.Stroke(line(10, 10, 20, 20))
+	a.Actions = append(a.Actions, &FillString{Font: "Times-Roman", Size: 12, Point: vg.Point{X: 1, Y: 2}, String: "a"})
+	a.Stroke(line(0, 0, 1, 1))
+
+	var b Canvas
+	b.Stroke(line(0, 0, 10, 10.01))
+	b.Push()
+	b.Translate(vg.Point{X: 5, Y: 5})
+	b.Scale(2, 2)
+	b.Rotate(math.Pi / 2)
+	b.Fill(line(-2.5, 2.5, 0, 0))
+	b.Pop()
+	b.SetColor(color.NRGBA{R: 255, A: 255})
+	b.Stroke(line(10, 10, 20, 20))
+	b.Actions = append(b.Actions, &FillString{Font: "Times-Roman", Size: 12, Point: vg.Point{X: 1, Y: 2}, String: "b"})
+	b.Stroke(line(0, 0, 1, 1))
+	b.Stroke(line(0, 0, 2, 2))
+
+	got := Diff(&a, &b, Tolerance{Length: 0.1})
+	want := []Change{
+		{Kind: Changed, Old: a.Actions[4], New: b.Actions[8], OldIndex: 4, NewIndex: 8, Fields: []string{"color"}},
+		{Kind: Changed, Old: a.Actions[5], New: b.Actions[9], OldIndex: 5, NewIndex: 9, Fields: []string{"color", "text"}},
+		{Kind: Changed, Old: a.Actions[6], New: b.Actions[10], OldIndex: 6, NewIndex: 10, Fields: []string{"color"}},
+		{Kind: Added, New: b.Actions[11], OldIndex: -1, NewIndex: 11},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected differences:\ngot:\n%v\nwant:\n%v", got, want)
+	}
+
+	if got := Diff(&b, &a, Tolerance{Length: 0.1, Color: 1}); len(got) != 2 || got[0].Kind != Changed || got[1].Kind != Removed {
+		t.Errorf("unexpected differences: %v", got)
+	}
+	if got := Diff(&a, &b, Tolerance{}); len(got) != 5 || !reflect.DeepEqual(got[0].Fields, []string{"path"}) {
+		t.Errorf("unexpected differences without tolerance: %v", got)
+	}
+	if got := Diff(&a, &a, Tolerance{}); len(got) != 0 {
+		t.Errorf("unexpected differences of equal recordings: %v", got)
+	}
+}
+
+func TestDiffLarge(t *testing.T) {
+	const n = 20000
+	var a, b Canvas
+	for i := 0; i < n; i++ {
+		var p vg.Path
+		p.Move(vg.Point{X: vg.Length(i), Y: 0})
+		p.Line(vg.Point{X: vg.Length(i), Y: 1})
+		a.Stroke(p)
+		if i == n/2 {
+			b.Fill(p)
+		}
+		if i != n/4 {
+			b.Stroke(p)
+		}
+	}
+	got := Diff(&a, &b, Tolerance{})
+	want := []Change{
+		{Kind: Removed, Old: a.Actions[n/4], OldIndex: n / 4, NewIndex: -1},
+		{Kind: Added, New: b.Actions[n/2-1], OldIndex: -1, NewIndex: n/2 - 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected differences:\ngot:\n%v\nwant:\n%v", got, want)
+	}
+}
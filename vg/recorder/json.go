@@ -0,0 +1,552 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package recorder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strconv"
+	"strings"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+// Version is the version of the JSON encoding of recordings
+// written by MarshalJSON.
+const Version = 1
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// A recording is encoded as an object holding the encoding
+// version and the list of actions.  Each action is an object
+// with the name of its method in the field "op" and its
+// arguments in lower case fields.  Lengths are given in points,
+// colors as "#rrggbbaa" strings of non-premultiplied values and
+// paths as strings of the path components, for example:
+//
+//	{"version":1,"actions":[
+//	{"op":"SetColor","color":"#ff0000ff"},
+//	{"op":"Stroke","path":"M 0 0 L 10 0 A 10 10 10 -1.5708 3.1416 Z"},
+//	{"op":"FillString","font":"Times-Roman","size":12,"x":0,"y":10,"text":"Text"}]}
+//
+// Curves are written as Q or C followed by the control
+// points and the end point.  Line caps, line joins and
+// hatch kinds are written by their names, for example
+// "round" or "diagonalcross".  Images are encoded as
+// base64 PNG data.  The caller locations of the actions
+// are not encoded.
+func (c *Canvas) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "{\"version\":%d,\"actions\":[", Version)
+	for i, a := range c.Actions {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+		j, err := encodeAction(a)
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(j)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+	buf.WriteString("]}")
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It replaces the actions of the canvas by the decoded
+// actions.
+func (c *Canvas) UnmarshalJSON(data []byte) error {
+	var rec struct {
+		Version int               `json:"version"`
+		Actions []json.RawMessage `json:"actions"`
+	}
+	err := json.Unmarshal(data, &rec)
+	if err != nil {
+		return err
+	}
+	if rec.Version < 1 || rec.Version > Version {
+		return fmt.Errorf("recorder: unsupported encoding version %d", rec.Version)
+	}
+	actions := make([]Action, len(rec.Actions))
+	for i, raw := range rec.Actions {
+		actions[i], err = decodeAction(raw)
+		if err != nil {
+			return fmt.Errorf("recorder: action %d: %v", i, err)
+		}
+	}
+	c.Actions = actions
+	return nil
+}
+
+// jsonAction is the JSON encoding of an action.  Only the
+// fields of the action's method are set.
+type jsonAction struct {
+	Op     string        `json:"op"`
+	Width  *vg.Length    `json:"width,omitempty"`
+	Dashes []vg.Length   `json:"dashes,omitempty"`
+	Offset *vg.Length    `json:"offset,omitempty"`
+	Cap    string        `json:"cap,omitempty"`
+	Join   string        `json:"join,omitempty"`
+	Limit  *float64      `json:"limit,omitempty"`
+	Color  *string       `json:"color,omitempty"`
+	Style  *jsonStyle    `json:"style,omitempty"`
+	Angle  *float64      `json:"angle,omitempty"`
+	Path   *string       `json:"path,omitempty"`
+	Font   string        `json:"font,omitempty"`
+	Size   *vg.Length    `json:"size,omitempty"`
+	X      *float64      `json:"x,omitempty"`
+	Y      *float64      `json:"y,omitempty"`
+	Rect   *[4]vg.Length `json:"rect,omitempty"`
+	Image  string        `json:"image,omitempty"`
+	Text   *string       `json:"text,omitempty"`
+}
+
+// jsonStyle is the JSON encoding of a fill style.
+type jsonStyle struct {
+	Type       string     `json:"type"`
+	Coords     []float64  `json:"coords,omitempty"`
+	Stops      []jsonStop `json:"stops,omitempty"`
+	Kind       string     `json:"kind,omitempty"`
+	Color      string     `json:"color,omitempty"`
+	Background string     `json:"background,omitempty"`
+	Spacing    vg.Length  `json:"spacing,omitempty"`
+	Width      vg.Length  `json:"width,omitempty"`
+}
+
+type jsonStop struct {
+	Offset float64 `json:"offset"`
+	Color  string  `json:"color"`
+}
+
+var (
+	capNames  = []string{vg.ButtCap: "butt", vg.RoundCap: "round", vg.SquareCap: "square"}
+	joinNames = []string{vg.MiterJoin: "miter", vg.RoundJoin: "round", vg.BevelJoin: "bevel"}
+
+	hatchNames = []string{
+		vg.DiagonalHatch:      "diagonal",
+		vg.BackDiagonalHatch:  "backdiagonal",
+		vg.HorizontalHatch:    "horizontal",
+		vg.VerticalHatch:      "vertical",
+		vg.CrossHatch:         "cross",
+		vg.DiagonalCrossHatch: "diagonalcross",
+		vg.DotHatch:           "dot",
+	}
+)
+
+func encodeAction(a Action) (jsonAction, error) {
+	switch a := a.(type) {
+	case *SetLineWidth:
+		return jsonAction{Op: "SetLineWidth", Width: &a.Width}, nil
+	case *SetLineDash:
+		return jsonAction{Op: "SetLineDash", Dashes: a.Dashes, Offset: &a.Offsets}, nil
+	case *SetLineCap:
+		return jsonAction{Op: "SetLineCap", Cap: name(capNames, int(a.Cap))}, nil
+	case *SetLineJoin:
+		return jsonAction{Op: "SetLineJoin", Join: name(joinNames, int(a.Join))}, nil
+	case *SetMiterLimit:
+		return jsonAction{Op: "SetMiterLimit", Limit: &a.Limit}, nil
+	case *SetColor:
+		j := jsonAction{Op: "SetColor"}
+		if a.Color != nil {
+			clr := encodeColor(a.Color)
+			j.Color = &clr
+		}
+		return j, nil
+	case *SetFillStyle:
+		return jsonAction{Op: "SetFillStyle", Style: encodeStyle(a.Style)}, nil
+	case *Rotate:
+		return jsonAction{Op: "Rotate", Angle: &a.Angle}, nil
+	case *Translate:
+		x, y := a.Point.X.Points(), a.Point.Y.Points()
+		return jsonAction{Op: "Translate", X: &x, Y: &y}, nil
+	case *Scale:
+		return jsonAction{Op: "Scale", X: &a.X, Y: &a.Y}, nil
+	case *Push:
+		return jsonAction{Op: "Push"}, nil
+	case *Pop:
+		return jsonAction{Op: "Pop"}, nil
+	case *Stroke:
+		p := encodePath(a.Path)
+		return jsonAction{Op: "Stroke", Path: &p}, nil
+	case *Fill:
+		p := encodePath(a.Path)
+		return jsonAction{Op: "Fill", Path: &p}, nil
+	case *FillString:
+		x, y := a.Point.X.Points(), a.Point.Y.Points()
+		return jsonAction{Op: "FillString", Font: a.Font, Size: &a.Size, X: &x, Y: &y, Text: &a.String}, nil
+	case *DrawImage:
+		r := a.Rectangle
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, a.Image); err != nil {
+			return jsonAction{}, fmt.Errorf("recorder: error encoding image to PNG: %v", err)
+		}
+		return jsonAction{Op: "DrawImage", Rect: &[4]vg.Length{r.Min.X, r.Min.Y, r.Max.X, r.Max.Y},
+			Image: base64.StdEncoding.EncodeToString(buf.Bytes())}, nil
+	case *Comment:
+		return jsonAction{Op: "Comment", Text: &a.Text}, nil
+	default:
+		return jsonAction{}, fmt.Errorf("recorder: unknown action %T", a)
+	}
+}
+
+func decodeAction(data []byte) (Action, error) {
+	var j jsonAction
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&j); err != nil {
+		return nil, err
+	}
+
+	var missing bool
+	length := func(l *vg.Length) vg.Length {
+		if l == nil {
+			missing = true
+			return 0
+		}
+		return *l
+	}
+	float := func(f *float64) float64 {
+		if f == nil {
+			missing = true
+			return 0
+		}
+		return *f
+	}
+	path := func() (vg.Path, error) {
+		if j.Path == nil {
+			return nil, errors.New("missing path")
+		}
+		return decodePath(*j.Path)
+	}
+
+	var (
+		a   Action
+		err error
+	)
+	switch j.Op {
+	case "SetLineWidth":
+		a = &SetLineWidth{Width: length(j.Width)}
+	case "SetLineDash":
+		a = &SetLineDash{Dashes: j.Dashes, Offsets: length(j.Offset)}
+	case "SetLineCap":
+		var v int
+		v, err = value(capNames, j.Cap)
+		a = &SetLineCap{Cap: vg.LineCap(v)}
+	case "SetLineJoin":
+		var v int
+		v, err = value(joinNames, j.Join)
+		a = &SetLineJoin{Join: vg.LineJoin(v)}
+	case "SetMiterLimit":
+		a = &SetMiterLimit{Limit: float(j.Limit)}
+	case "SetColor":
+		var clr color.Color
+		if j.Color != nil {
+			clr, err = decodeColor(*j.Color)
+		}
+		a = &SetColor{Color: clr}
+	case "SetFillStyle":
+		var fs vg.FillStyle
+		fs, err = decodeStyle(j.Style)
+		a = &SetFillStyle{Style: fs}
+	case "Rotate":
+		a = &Rotate{Angle: float(j.Angle)}
+	case "Translate":
+		a = &Translate{Point: vg.Point{X: vg.Length(float(j.X)), Y: vg.Length(float(j.Y))}}
+	case "Scale":
+		a = &Scale{X: float(j.X), Y: float(j.Y)}
+	case "Push":
+		a = &Push{}
+	case "Pop":
+		a = &Pop{}
+	case "Stroke":
+		var p vg.Path
+		p, err = path()
+		a = &Stroke{Path: p}
+	case "Fill":
+		var p vg.Path
+		p, err = path()
+		a = &Fill{Path: p}
+	case "FillString":
+		if j.Text == nil {
+			missing = true
+		} else {
+			a = &FillString{
+				Font:   j.Font,
+				Size:   length(j.Size),
+				Point:  vg.Point{X: vg.Length(float(j.X)), Y: vg.Length(float(j.Y))},
+				String: *j.Text,
+			}
+		}
+	case "DrawImage":
+		if j.Rect == nil {
+			missing = true
+			break
+		}
+		var (
+			b   []byte
+			img image.Image
+		)
+		b, err = base64.StdEncoding.DecodeString(j.Image)
+		if err == nil {
+			img, err = png.Decode(bytes.NewReader(b))
+		}
+		r := *j.Rect
+		a = &DrawImage{Rectangle: vg.Rectangle{Min: vg.Point{X: r[0], Y: r[1]}, Max: vg.Point{X: r[2], Y: r[3]}}, Image: img}
+	case "Comment":
+		if j.Text == nil {
+			missing = true
+		} else {
+			a = &Comment{Text: *j.Text}
+		}
+	default:
+		return nil, fmt.Errorf("unknown op %q", j.Op)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if missing {
+		return nil, fmt.Errorf("missing argument of %s", j.Op)
+	}
+	return a, nil
+}
+
+func name(names []string, v int) string {
+	if v < 0 || v >= len(names) {
+		return strconv.Itoa(v)
+	}
+	return names[v]
+}
+
+func value(names []string, n string) (int, error) {
+	for v, s := range names {
+		if s == n {
+			return v, nil
+		}
+	}
+	v, err := strconv.Atoi(n)
+	if err != nil {
+		return 0, fmt.Errorf("invalid name %q", n)
+	}
+	return v, nil
+}
+
+// encodeColor returns the "#rrggbbaa" representation of
+// the non-premultiplied color values of clr.
+func encodeColor(clr color.Color) string {
+	c := color.NRGBAModel.Convert(clr).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+func decodeColor(s string) (color.Color, error) {
+	if len(s) != 9 || s[0] != '#' {
+		return nil, fmt.Errorf("invalid color %q", s)
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %q", s)
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+// optColor returns the encoding of an optional color.
+func optColor(clr color.Color) string {
+	if clr == nil {
+		return ""
+	}
+	return encodeColor(clr)
+}
+
+func decodeOptColor(s string) (color.Color, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return decodeColor(s)
+}
+
+func encodeStyle(fs vg.FillStyle) *jsonStyle {
+	stops := func(stops []vg.GradientStop) []jsonStop {
+		js := make([]jsonStop, len(stops))
+		for i, s := range stops {
+			js[i] = jsonStop{Offset: s.Offset, Color: encodeColor(s.Color)}
+		}
+		return js
+	}
+	switch fs := fs.(type) {
+	case nil:
+		return nil
+	case vg.LinearGradient:
+		return &jsonStyle{Type: "linear", Coords: []float64{fs.X0, fs.Y0, fs.X1, fs.Y1}, Stops: stops(fs.Stops)}
+	case vg.RadialGradient:
+		return &jsonStyle{Type: "radial", Coords: []float64{fs.CX, fs.CY, fs.R}, Stops: stops(fs.Stops)}
+	case vg.HatchPattern:
+		return &jsonStyle{Type: "hatch", Kind: name(hatchNames, int(fs.Kind)), Color: optColor(fs.Color),
+			Background: optColor(fs.Background), Spacing: fs.Spacing, Width: fs.Width}
+	default:
+		panic(fmt.Sprintf("recorder: unknown fill style %T", fs))
+	}
+}
+
+func decodeStyle(js *jsonStyle) (vg.FillStyle, error) {
+	if js == nil {
+		return nil, nil
+	}
+	stops := func() ([]vg.GradientStop, error) {
+		var stops []vg.GradientStop
+		for _, s := range js.Stops {
+			clr, err := decodeColor(s.Color)
+			if err != nil {
+				return nil, err
+			}
+			stops = append(stops, vg.GradientStop{Offset: s.Offset, Color: clr})
+		}
+		return stops, nil
+	}
+	switch js.Type {
+	case "linear":
+		if len(js.Coords) != 4 {
+			return nil, errors.New("invalid linear gradient")
+		}
+		s, err := stops()
+		c := js.Coords
+		return vg.LinearGradient{X0: c[0], Y0: c[1], X1: c[2], Y1: c[3], Stops: s}, err
+	case "radial":
+		if len(js.Coords) != 3 {
+			return nil, errors.New("invalid radial gradient")
+		}
+		s, err := stops()
+		c := js.Coords
+		return vg.RadialGradient{CX: c[0], CY: c[1], R: c[2], Stops: s}, err
+	case "hatch":
+		h := vg.HatchPattern{Spacing: js.Spacing, Width: js.Width}
+		if js.Kind != "" {
+			kind, err := value(hatchNames, js.Kind)
+			if err != nil {
+				return nil, err
+			}
+			h.Kind = vg.HatchKind(kind)
+		}
+		var err error
+		h.Color, err = decodeOptColor(js.Color)
+		if err != nil {
+			return nil, err
+		}
+		h.Background, err = decodeOptColor(js.Background)
+		return h, err
+	default:
+		return nil, fmt.Errorf("unknown fill style %q", js.Type)
+	}
+}
+
+// encodePath returns the string representation of the path.
+func encodePath(p vg.Path) string {
+	var buf bytes.Buffer
+	num := func(v ...float64) {
+		for _, v := range v {
+			buf.WriteByte(' ')
+			buf.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+		}
+	}
+	pt := func(p vg.Point) {
+		num(p.X.Points(), p.Y.Points())
+	}
+	for i, c := range p {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		switch c.Type {
+		case vg.MoveComp:
+			buf.WriteByte('M')
+			pt(c.Pos)
+		case vg.LineComp:
+			buf.WriteByte('L')
+			pt(c.Pos)
+		case vg.ArcComp:
+			buf.WriteByte('A')
+			pt(c.Pos)
+			num(c.Radius.Points(), c.Start, c.Angle)
+		case vg.CurveComp:
+			switch len(c.Control) {
+			case 1:
+				buf.WriteByte('Q')
+			case 2:
+				buf.WriteByte('C')
+			default:
+				panic("recorder: invalid number of control points")
+			}
+			for _, ctrl := range c.Control {
+				pt(ctrl)
+			}
+			pt(c.Pos)
+		case vg.CloseComp:
+			buf.WriteByte('Z')
+		default:
+			panic(fmt.Sprintf("recorder: unknown path component type: %d", c.Type))
+		}
+	}
+	return buf.String()
+}
+
+// decodePath parses the string representation of a path.
+func decodePath(s string) (vg.Path, error) {
+	f := strings.Fields(s)
+	var (
+		p   vg.Path
+		err error
+	)
+	nums := func(n int) []float64 {
+		if len(f) < n {
+			err = errors.New("invalid path: missing coordinates")
+			return make([]float64, n)
+		}
+		v := make([]float64, n)
+		for i := range v {
+			var e error
+			v[i], e = strconv.ParseFloat(f[i], 64)
+			if e != nil && err == nil {
+				err = fmt.Errorf("invalid path: %v", e)
+			}
+		}
+		f = f[n:]
+		return v
+	}
+	pt := func(v []float64) vg.Point {
+		return vg.Point{X: vg.Length(v[0]), Y: vg.Length(v[1])}
+	}
+	for len(f) > 0 && err == nil {
+		op := f[0]
+		f = f[1:]
+		switch op {
+		case "M":
+			p = append(p, vg.PathComp{Type: vg.MoveComp, Pos: pt(nums(2))})
+		case "L":
+			p = append(p, vg.PathComp{Type: vg.LineComp, Pos: pt(nums(2))})
+		case "A":
+			v := nums(5)
+			p = append(p, vg.PathComp{Type: vg.ArcComp, Pos: pt(v), Radius: vg.Length(v[2]), Start: v[3], Angle: v[4]})
+		case "Q":
+			v := nums(4)
+			p = append(p, vg.PathComp{Type: vg.CurveComp, Control: []vg.Point{pt(v)}, Pos: pt(v[2:])})
+		case "C":
+			v := nums(6)
+			p = append(p, vg.PathComp{Type: vg.CurveComp, Control: []vg.Point{pt(v), pt(v[2:])}, Pos: pt(v[4:])})
+		case "Z":
+			p = append(p, vg.PathComp{Type: vg.CloseComp})
+		default:
+			return nil, fmt.Errorf("invalid path component %q", op)
+		}
+	}
+	return p, err
+}
@@ -0,0 +1,119 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package recorder
+
+import (
+	"encoding/json"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+func TestJSON(t *testing.T) {
+	var p vg.Path
+	p.Move(vg.Point{X: 0, Y: 0})
+	p.Line(vg.Point{X: 10.5, Y: 0})
+	p.Arc(vg.Point{X: 10, Y: 10}, 10, -math.Pi/2, math.Pi)
+	p.QuadTo(vg.Point{X: 1, Y: 2}, vg.Point{X: 3, Y: 4})
+	p.CubeTo(vg.Point{X: 1, Y: 2}, vg.Point{X: 3, Y: 4}, vg.Point{X: 5, Y: 6})
+	p.Close()
+
+	var rec Canvas
+	rec.Comment("preamble")
+	rec.SetLineWidth(2)
+	rec.SetLineDash([]vg.Length{2, 5}, 6)
+	rec.SetLineCap(vg.RoundCap)
+	rec.SetLineJoin(vg.BevelJoin)
+	rec.SetMiterLimit(4)
+	rec.SetColor(color.RGBA{R: 0x65, G: 0x23, B: 0xf2, A: 0xff})
+	rec.SetColor(nil)
+	rec.SetFillStyle(vg.LinearGradient{X1: 1, Stops: []vg.GradientStop{{Offset: 0.5, Color: color.White}}})
+	rec.SetFillStyle(vg.RadialGradient{CX: 0.5, CY: 0.5, R: 1})
+	rec.SetFillStyle(vg.HatchPattern{Kind: vg.DotHatch, Color: color.Black, Spacing: 3})
+	rec.SetFillStyle(nil)
+	rec.Push()
+	rec.Rotate(0.72)
+	rec.Translate(vg.Point{X: 3, Y: 4})
+	rec.Scale(1, 2)
+	rec.Stroke(p)
+	rec.Fill(p)
+	rec.Pop()
+	rec.Actions = append(rec.Actions, &FillString{Font: "Times-Roman", Size: 12, Point: vg.Point{X: 0, Y: 10}, String: "Text \"quoted\""})
+	rec.DrawImage(vg.Rectangle{Max: vg.Point{X: 10, Y: 10}}, img)
+
+	b, err := json.Marshal(&rec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`{"version":1,"actions":[`,
+		`{"op":"SetLineCap","cap":"round"}`,
+		`{"op":"SetColor","color":"#6523f2ff"}`,
+		`{"op":"SetColor"}`,
+		`"path":"M 0 0 L 10.5 0 A 10 10 10 -1.5707963267948966 3.141592653589793 Q 1 2 3 4 C 1 2 3 4 5 6 Z"`,
+		`{"op":"SetFillStyle","style":{"type":"hatch","kind":"dot","color":"#000000ff","spacing":3}}`,
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("missing %q in encoding:\n%s", want, b)
+		}
+	}
+
+	var got Canvas
+	err = json.Unmarshal(b, &got)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Actions) != len(rec.Actions) {
+		t.Fatalf("unexpected number of actions: got:%d want:%d", len(got.Actions), len(rec.Actions))
+	}
+	for i, a := range rec.Actions {
+		switch a.(type) {
+		case *SetColor, *SetFillStyle:
+			// Colors are decoded as color.NRGBA.
+			continue
+		}
+		if got, want := got.Actions[i].Call(), a.Call(); got != want {
+			t.Errorf("unexpected action %d:\n\tgot: %s\n\twant: %s", i, got, want)
+		}
+	}
+	if d := Diff(&rec, &got, Tolerance{}); len(d) != 0 {
+		t.Errorf("unexpected differences after round trip: %v", d)
+	}
+
+	var replay Canvas
+	if err := got.ReplayOn(&replay); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(replay.Actions) != len(rec.Actions) {
+		t.Errorf("unexpected number of replayed actions: got:%d want:%d", len(replay.Actions), len(rec.Actions))
+	}
+
+	for _, test := range []struct {
+		data string
+		err  string
+	}{
+		{data: `{"version":2,"actions":[]}`, err: "unsupported encoding version 2"},
+		{data: `{"version":1,"actions":[{"op":"Foo"}]}`, err: `action 0: unknown op "Foo"`},
+		{data: `{"version":1,"actions":[{"op":"Rotate"}]}`, err: "action 0: missing argument of Rotate"},
+		{data: `{"version":1,"actions":[{"op":"Fill","path":"M 1"}]}`, err: "action 0: invalid path: missing coordinates"},
+		{data: `{"version":1,"actions":[{"op":"SetColor","color":"red"}]}`, err: `action 0: invalid color "red"`},
+		{data: `{"version":1,"actions":[{"op":"SetFillStyle","style":{"type":"hatch","kind":"wavy"}}]}`, err: `action 0: invalid name "wavy"`},
+	} {
+		err := json.Unmarshal([]byte(test.data), &got)
+		if err == nil || err.Error() != "recorder: "+test.err {
+			t.Errorf("unexpected error for %s: got:%v want:%s", test.data, err, test.err)
+		}
+	}
+
+	var bad Canvas
+	bad.DrawImage(vg.Rectangle{Max: vg.Point{X: 10, Y: 10}}, image.NewGray(image.Rect(0, 0, 0, 0)))
+	if _, err := json.Marshal(&bad); err == nil {
+		t.Errorf("expected error for image that cannot be encoded")
+	}
+}
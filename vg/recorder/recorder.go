@@ -168,6 +168,84 @@ func (a *SetLineDash) callerLocation() *callerLocation {
 	return &a.l
 }
 
+// SetLineCap corresponds to the vg.Canvas.SetLineCap method.
+type SetLineCap struct {
+	Cap vg.LineCap
+
+	l callerLocation
+}
+
+// SetLineCap implements the SetLineCap method of the vg.Canvas interface.
+func (c *Canvas) SetLineCap(lc vg.LineCap) {
+	c.append(&SetLineCap{Cap: lc})
+}
+
+// Call returns the method call that generated the action.
+func (a *SetLineCap) Call() string {
+	return fmt.Sprintf("%sSetLineCap(%v)", a.l, a.Cap)
+}
+
+// ApplyTo applies the action to the given vg.Canvas.
+func (a *SetLineCap) ApplyTo(c vg.Canvas) {
+	c.SetLineCap(a.Cap)
+}
+
+func (a *SetLineCap) callerLocation() *callerLocation {
+	return &a.l
+}
+
+// SetLineJoin corresponds to the vg.Canvas.SetLineJoin method.
+type SetLineJoin struct {
+	Join vg.LineJoin
+
+	l callerLocation
+}
+
+// SetLineJoin implements the SetLineJoin method of the vg.Canvas interface.
+func (c *Canvas) SetLineJoin(lj vg.LineJoin) {
+	c.append(&SetLineJoin{Join: lj})
+}
+
+// Call returns the method call that generated the action.
+func (a *SetLineJoin) Call() string {
+	return fmt.Sprintf("%sSetLineJoin(%v)", a.l, a.Join)
+}
+
+// ApplyTo applies the action to the given vg.Canvas.
+func (a *SetLineJoin) ApplyTo(c vg.Canvas) {
+	c.SetLineJoin(a.Join)
+}
+
+func (a *SetLineJoin) callerLocation() *callerLocation {
+	return &a.l
+}
+
+// SetMiterLimit corresponds to the vg.Canvas.SetMiterLimit method.
+type SetMiterLimit struct {
+	Limit float64
+
+	l callerLocation
+}
+
+// SetMiterLimit implements the SetMiterLimit method of the vg.Canvas interface.
+func (c *Canvas) SetMiterLimit(limit float64) {
+	c.append(&SetMiterLimit{Limit: limit})
+}
+
+// Call returns the method call that generated the action.
+func (a *SetMiterLimit) Call() string {
+	return fmt.Sprintf("%sSetMiterLimit(%v)", a.l, a.Limit)
+}
+
+// ApplyTo applies the action to the given vg.Canvas.
+func (a *SetMiterLimit) ApplyTo(c vg.Canvas) {
+	c.SetMiterLimit(a.Limit)
+}
+
+func (a *SetMiterLimit) callerLocation() *callerLocation {
+	return &a.l
+}
+
 // SetColor corresponds to the vg.Canvas.SetColor method.
 type SetColor struct {
 	Color color.Color
@@ -194,6 +272,32 @@ func (a *SetColor) callerLocation() *callerLocation {
 	return &a.l
 }
 
+// SetFillStyle corresponds to the vg.Canvas.SetFillStyle method.
+type SetFillStyle struct {
+	Style vg.FillStyle
+
+	l callerLocation
+}
+
+// SetFillStyle implements the SetFillStyle method of the vg.Canvas interface.
+func (c *Canvas) SetFillStyle(fs vg.FillStyle) {
+	c.append(&SetFillStyle{Style: fs})
+}
+
+// Call returns the method call that generated the action.
+func (a *SetFillStyle) Call() string {
+	return fmt.Sprintf("%sSetFillStyle(%#v)", a.l, a.Style)
+}
+
+// ApplyTo applies the action to the given vg.Canvas.
+func (a *SetFillStyle) ApplyTo(c vg.Canvas) {
+	c.SetFillStyle(a.Style)
+}
+
+func (a *SetFillStyle) callerLocation() *callerLocation {
+	return &a.l
+}
+
 // Rotate corresponds to the vg.Canvas.Rotate method.
 type Rotate struct {
 	Angle float64
@@ -0,0 +1,38 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg
+
+// LineCap is the shape drawn at the ends of stroked
+// open paths and of the dashes of dashed lines.
+type LineCap int
+
+const (
+	// ButtCap ends lines squarely at their end points.
+	ButtCap LineCap = iota
+	// RoundCap ends lines with a half circle whose
+	// diameter is the line width.
+	RoundCap
+	// SquareCap ends lines squarely, extended beyond
+	// their end points by half the line width.
+	SquareCap
+)
+
+// LineJoin is the shape drawn at the corners of stroked paths.
+type LineJoin int
+
+const (
+	// MiterJoin extends the outer edges of the lines until
+	// they meet.  Corners exceeding the miter limit are
+	// drawn as with BevelJoin.
+	MiterJoin LineJoin = iota
+	// RoundJoin rounds corners with a circle whose
+	// diameter is the line width.
+	RoundJoin
+	// BevelJoin cuts corners off squarely.
+	BevelJoin
+)
+
+// DefaultMiterLimit is the initial miter limit of a Canvas.
+const DefaultMiterLimit = 10
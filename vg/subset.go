@@ -0,0 +1,306 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang/freetype/truetype"
+)
+
+// subsetTables are the tables kept in a font subset.
+var subsetTables = map[string]bool{
+	"OS/2": true, "cmap": true, "cvt ": true, "fpgm": true,
+	"glyf": true, "head": true, "hhea": true, "hmtx": true,
+	"loca": true, "maxp": true, "name": true, "post": true,
+	"prep": true,
+}
+
+// SubsetFont returns the TrueType font data reduced to the
+// glyphs of the given runes, as needed for embedding the font
+// into documents.  Glyph indices are preserved, unused glyphs
+// are left empty, the character map is reduced to the given
+// runes and tables that are not needed for rendering are
+// dropped.
+func SubsetFont(data []byte, runes []rune) ([]byte, error) {
+	fnt, err := truetype.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	be := binary.BigEndian
+	if len(data) < 12 {
+		return nil, errors.New("vg: invalid TrueType data")
+	}
+	n := int(be.Uint16(data[4:]))
+	if len(data) < 12+16*n {
+		return nil, errors.New("vg: invalid TrueType table directory")
+	}
+	tables := make(map[string][]byte)
+	var tags []string
+	for i := 0; i < n; i++ {
+		rec := data[12+16*i:]
+		tag := string(rec[:4])
+		off, l := int(be.Uint32(rec[8:])), int(be.Uint32(rec[12:]))
+		if off < 0 || l < 0 || off+l > len(data) {
+			return nil, fmt.Errorf("vg: invalid TrueType table %q", tag)
+		}
+		if subsetTables[tag] {
+			tables[tag] = data[off : off+l]
+			tags = append(tags, tag)
+		}
+	}
+	head, loca, glyf, maxp := tables["head"], tables["loca"], tables["glyf"], tables["maxp"]
+	if len(head) < 54 || len(maxp) < 6 || glyf == nil {
+		return nil, errors.New("vg: missing TrueType tables")
+	}
+	long := be.Uint16(head[50:]) != 0
+	numGlyphs := int(be.Uint16(maxp[4:]))
+	glyph := func(i int) []byte {
+		var start, end int
+		switch {
+		case long && 4*i+8 <= len(loca):
+			start, end = int(be.Uint32(loca[4*i:])), int(be.Uint32(loca[4*i+4:]))
+		case !long && 2*i+4 <= len(loca):
+			start, end = 2*int(be.Uint16(loca[2*i:])), 2*int(be.Uint16(loca[2*i+2:]))
+		}
+		if start >= end || end > len(glyf) {
+			return nil
+		}
+		return glyf[start:end]
+	}
+
+	used := map[int]bool{0: true}
+	var todo []int
+	todo = append(todo, 0)
+	for _, r := range runes {
+		if i := int(fnt.Index(r)); !used[i] {
+			used[i] = true
+			todo = append(todo, i)
+		}
+	}
+	for len(todo) > 0 {
+		i := todo[len(todo)-1]
+		todo = todo[:len(todo)-1]
+		for _, c := range components(glyph(i)) {
+			if c < numGlyphs && !used[c] {
+				used[c] = true
+				todo = append(todo, c)
+			}
+		}
+	}
+
+	var newGlyf, newLoca bytes.Buffer
+	for i := 0; i <= numGlyphs; i++ {
+		off := newGlyf.Len()
+		if long {
+			binary.Write(&newLoca, be, uint32(off))
+		} else {
+			binary.Write(&newLoca, be, uint16(off/2))
+		}
+		if i == numGlyphs || !used[i] {
+			continue
+		}
+		g := glyph(i)
+		newGlyf.Write(g)
+		for newGlyf.Len()%4 != 0 {
+			newGlyf.WriteByte(0)
+		}
+	}
+	tables["glyf"] = newGlyf.Bytes()
+	tables["loca"] = newLoca.Bytes()
+	if _, ok := tables["cmap"]; !ok {
+		tags = append(tags, "cmap")
+	}
+	tables["cmap"] = cmap(fnt, runes)
+
+	return buildFont(data[:4], tags, tables), nil
+}
+
+// cmap returns a character map table mapping the runes
+// to their glyphs in fnt.  The map is a single Windows
+// Unicode subtable, of format 4 if the runes are in the
+// Basic Multilingual Plane and of format 12 otherwise.
+func cmap(fnt *truetype.Font, runes []rune) []byte {
+	type segment struct {
+		start, end rune
+		glyph      int
+	}
+	rs := append([]rune(nil), runes...)
+	sort.Slice(rs, func(i, j int) bool { return rs[i] < rs[j] })
+	var segs []segment
+	for _, r := range rs {
+		g := int(fnt.Index(r))
+		if g == 0 {
+			continue
+		}
+		if n := len(segs); n > 0 {
+			s := &segs[n-1]
+			if r == s.end {
+				continue
+			}
+			if r == s.end+1 && g == s.glyph+int(r-s.start) {
+				s.end = r
+				continue
+			}
+		}
+		segs = append(segs, segment{start: r, end: r, glyph: g})
+	}
+
+	be := binary.BigEndian
+	var sub bytes.Buffer
+	encoding := uint16(1)
+	n := len(segs) + 1
+	if (len(segs) == 0 || segs[len(segs)-1].end <= 0xffff) && 16+8*n <= 0xffff {
+		// The last segment maps 0xffff to the missing glyph.
+		segs = append(segs, segment{start: 0xffff, end: 0xffff})
+		entrySelector := 0
+		for 1<<uint(entrySelector+1) <= n {
+			entrySelector++
+		}
+		searchRange := 2 << uint(entrySelector)
+		for _, v := range []int{4, 16 + 8*n, 0, 2 * n, searchRange, entrySelector, 2*n - searchRange} {
+			binary.Write(&sub, be, uint16(v))
+		}
+		for _, s := range segs {
+			binary.Write(&sub, be, uint16(s.end))
+		}
+		binary.Write(&sub, be, uint16(0))
+		for _, s := range segs {
+			binary.Write(&sub, be, uint16(s.start))
+		}
+		for _, s := range segs {
+			delta := s.glyph - int(s.start)
+			if s.start == 0xffff {
+				delta = 1
+			}
+			binary.Write(&sub, be, uint16(delta))
+		}
+		for range segs {
+			binary.Write(&sub, be, uint16(0))
+		}
+	} else {
+		encoding = 10
+		for _, v := range []uint32{12 << 16, uint32(16 + 12*len(segs)), 0, uint32(len(segs))} {
+			binary.Write(&sub, be, v)
+		}
+		for _, s := range segs {
+			for _, v := range []uint32{uint32(s.start), uint32(s.end), uint32(s.glyph)} {
+				binary.Write(&sub, be, v)
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	for _, v := range []uint16{0, 1, 3, encoding} {
+		binary.Write(&out, be, v)
+	}
+	binary.Write(&out, be, uint32(12))
+	out.Write(sub.Bytes())
+	return out.Bytes()
+}
+
+// components returns the indices of the glyphs that
+// a composite glyph is built from.
+func components(g []byte) []int {
+	be := binary.BigEndian
+	if len(g) < 10 || int16(be.Uint16(g)) >= 0 {
+		return nil
+	}
+	const (
+		argsAreWords   = 0x0001
+		haveScale      = 0x0008
+		moreComponents = 0x0020
+		haveXYScale    = 0x0040
+		haveTwoByTwo   = 0x0080
+	)
+	var idx []int
+	p := 10
+	for p+4 <= len(g) {
+		flags := be.Uint16(g[p:])
+		idx = append(idx, int(be.Uint16(g[p+2:])))
+		p += 4
+		if flags&argsAreWords != 0 {
+			p += 4
+		} else {
+			p += 2
+		}
+		switch {
+		case flags&haveScale != 0:
+			p += 2
+		case flags&haveXYScale != 0:
+			p += 4
+		case flags&haveTwoByTwo != 0:
+			p += 8
+		}
+		if flags&moreComponents == 0 {
+			break
+		}
+	}
+	return idx
+}
+
+// buildFont assembles a TrueType font from its tables.
+func buildFont(version []byte, tags []string, tables map[string][]byte) []byte {
+	be := binary.BigEndian
+	sort.Strings(tags)
+	n := len(tags)
+	var out bytes.Buffer
+	out.Write(version)
+	entrySelector := 0
+	for 1<<uint(entrySelector+1) <= n {
+		entrySelector++
+	}
+	searchRange := 16 << uint(entrySelector)
+	binary.Write(&out, be, uint16(n))
+	binary.Write(&out, be, uint16(searchRange))
+	binary.Write(&out, be, uint16(entrySelector))
+	binary.Write(&out, be, uint16(16*n-searchRange))
+
+	off := 12 + 16*n
+	headOff := -1
+	for _, tag := range tags {
+		t := tables[tag]
+		if tag == "head" {
+			// The checksum adjustment is computed
+			// over the font with a zero value.
+			t = append([]byte(nil), t...)
+			be.PutUint32(t[8:], 0)
+			tables[tag] = t
+			headOff = off
+		}
+		out.WriteString(tag)
+		binary.Write(&out, be, checksum(t))
+		binary.Write(&out, be, uint32(off))
+		binary.Write(&out, be, uint32(len(t)))
+		off += (len(t) + 3) &^ 3
+	}
+	for _, tag := range tags {
+		t := tables[tag]
+		out.Write(t)
+		for i := len(t); i%4 != 0; i++ {
+			out.WriteByte(0)
+		}
+	}
+	b := out.Bytes()
+	if headOff >= 0 {
+		be.PutUint32(b[headOff+8:], 0xb1b0afba-checksum(b))
+	}
+	return b
+}
+
+// checksum returns the TrueType checksum of the data.
+func checksum(b []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(b); i += 4 {
+		var v [4]byte
+		copy(v[:], b[i:])
+		sum += binary.BigEndian.Uint32(v[:])
+	}
+	return sum
+}
@@ -0,0 +1,60 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg
+
+import (
+	"testing"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestSubsetFont(t *testing.T) {
+	data, err := SubsetFont(goregular.TTF, []rune("Ab"))
+	if err != nil {
+		t.Fatalf("could not subset font: %v", err)
+	}
+	if len(data) >= len(goregular.TTF) {
+		t.Errorf("subset not smaller than font: got:%d bytes, font has %d bytes", len(data), len(goregular.TTF))
+	}
+	if checksum(data) != 0xb1b0afba {
+		t.Errorf("invalid font checksum")
+	}
+	fnt, err := truetype.Parse(data)
+	if err != nil {
+		t.Fatalf("could not parse subset: %v", err)
+	}
+	orig, err := truetype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("could not parse font: %v", err)
+	}
+	for _, r := range "AbB" {
+		want := truetype.Index(0)
+		if r != 'B' {
+			want = orig.Index(r)
+		}
+		if got := fnt.Index(r); got != want {
+			t.Errorf("unexpected glyph index for %q: got:%d want:%d", r, got, want)
+		}
+	}
+	if _, err := SubsetFont([]byte("no font"), nil); err == nil {
+		t.Errorf("expected error for invalid font data")
+	}
+}
+
+func TestComponents(t *testing.T) {
+	glyph := []byte{
+		0xff, 0xff, 0, 0, 0, 0, 0, 0, 0, 0, // header of a composite glyph
+		0x00, 0x21, 0x00, 0x05, 0, 0, 0, 0, // words and more components
+		0x00, 0x08, 0x00, 0x07, 0, 0, 0, 0, // bytes and scale
+	}
+	got := components(glyph)
+	if len(got) != 2 || got[0] != 5 || got[1] != 7 {
+		t.Errorf("unexpected components: got:%v want:[5 7]", got)
+	}
+	if got := components([]byte{0, 1, 0, 0, 0, 0, 0, 0, 0, 0}); got != nil {
+		t.Errorf("unexpected components of simple glyph: %v", got)
+	}
+}
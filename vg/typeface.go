@@ -0,0 +1,412 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/golang/freetype/truetype"
+)
+
+// FontWeight is the weight of a typeface.  The values
+// follow the CSS scale from 100 (thin) to 900 (black).
+type FontWeight int
+
+const (
+	WeightThin       FontWeight = 100
+	WeightExtraLight FontWeight = 200
+	WeightLight      FontWeight = 300
+	WeightNormal     FontWeight = 400
+	WeightMedium     FontWeight = 500
+	WeightSemiBold   FontWeight = 600
+	WeightBold       FontWeight = 700
+	WeightExtraBold  FontWeight = 800
+	WeightBlack      FontWeight = 900
+)
+
+// FontStyle is the slant of a typeface.
+type FontStyle int
+
+const (
+	StyleNormal FontStyle = iota
+	StyleItalic
+	StyleOblique
+)
+
+// A Typeface selects one face of a font family.
+type Typeface struct {
+	// Family is the name of the font family,
+	// for example "Helvetica".
+	Family string
+
+	// Weight is the weight of the face.  The zero
+	// value is treated as WeightNormal.
+	Weight FontWeight
+
+	// Style is the slant of the face.
+	Style FontStyle
+}
+
+// weight returns the weight of the face with the
+// zero value replaced by WeightNormal.
+func (t Typeface) weight() FontWeight {
+	if t.Weight == 0 {
+		return WeightNormal
+	}
+	return t.Weight
+}
+
+// name returns the font name used for a registered face,
+// for example "Family-BoldItalic".
+func (t Typeface) name() string {
+	var s string
+	switch w := t.weight(); w {
+	case WeightNormal:
+	case WeightThin:
+		s = "Thin"
+	case WeightExtraLight:
+		s = "ExtraLight"
+	case WeightLight:
+		s = "Light"
+	case WeightMedium:
+		s = "Medium"
+	case WeightSemiBold:
+		s = "SemiBold"
+	case WeightBold:
+		s = "Bold"
+	case WeightExtraBold:
+		s = "ExtraBold"
+	case WeightBlack:
+		s = "Black"
+	default:
+		s = fmt.Sprintf("W%d", w)
+	}
+	switch t.Style {
+	case StyleItalic:
+		s += "Italic"
+	case StyleOblique:
+		s += "Oblique"
+	}
+	if s == "" {
+		return t.Family
+	}
+	return t.Family + "-" + s
+}
+
+// face is a registered face of a font family.
+type face struct {
+	Typeface
+	name string
+}
+
+var (
+	// families maps family names to their registered faces.
+	families = make(map[string][]face)
+
+	// faces maps font names to their typefaces.
+	faces = make(map[string]Typeface)
+
+	// fontBytes holds the font data of fonts added
+	// by RegisterFont, indexed by font name.
+	fontBytes = make(map[string][]byte)
+)
+
+func init() {
+	for _, f := range []face{
+		{Typeface{"Courier", WeightNormal, StyleNormal}, "Courier"},
+		{Typeface{"Courier", WeightBold, StyleNormal}, "Courier-Bold"},
+		{Typeface{"Courier", WeightNormal, StyleOblique}, "Courier-Oblique"},
+		{Typeface{"Courier", WeightBold, StyleOblique}, "Courier-BoldOblique"},
+
+		{Typeface{"Helvetica", WeightNormal, StyleNormal}, "Helvetica"},
+		{Typeface{"Helvetica", WeightBold, StyleNormal}, "Helvetica-Bold"},
+		{Typeface{"Helvetica", WeightNormal, StyleOblique}, "Helvetica-Oblique"},
+		{Typeface{"Helvetica", WeightBold, StyleOblique}, "Helvetica-BoldOblique"},
+
+		{Typeface{"Times", WeightNormal, StyleNormal}, "Times-Roman"},
+		{Typeface{"Times", WeightBold, StyleNormal}, "Times-Bold"},
+		{Typeface{"Times", WeightNormal, StyleItalic}, "Times-Italic"},
+		{Typeface{"Times", WeightBold, StyleItalic}, "Times-BoldItalic"},
+	} {
+		addFace(f)
+	}
+}
+
+// addFace adds f to the registry, replacing a face of
+// the same family, weight and style.  The caller must
+// hold fontLock if the registry may be shared.
+func addFace(f face) {
+	fs := families[f.Family]
+	for i, old := range fs {
+		if old.weight() == f.weight() && old.Style == f.Style {
+			delete(faces, old.name)
+			fs = append(fs[:i], fs[i+1:]...)
+			break
+		}
+	}
+	families[f.Family] = append(fs, f)
+	faces[f.name] = f.Typeface
+}
+
+// RegisterFont parses the TrueType data of a font, or of an
+// OpenType font with TrueType outlines, and registers it as
+// the given face of its family.  A previously registered face
+// with the same family, weight and style is replaced.
+// The registered font can be created by MakeTypeface.
+func RegisterFont(t Typeface, data []byte) error {
+	if t.Family == "" {
+		return errors.New("vg: missing font family name")
+	}
+	fnt, err := truetype.Parse(data)
+	if err != nil {
+		return fmt.Errorf("vg: failed to parse font for %q: %v", t.Family, err)
+	}
+	t.Weight = t.weight()
+	name := t.name()
+
+	fontLock.Lock()
+	defer fontLock.Unlock()
+	loadedFonts[name] = fnt
+	fontBytes[name] = data
+	addFace(face{Typeface: t, name: name})
+	return nil
+}
+
+// RegisterFontFile registers the font in the named
+// file as the given face of its family.
+// See RegisterFont for details.
+func RegisterFontFile(t Typeface, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return RegisterFont(t, data)
+}
+
+// FontFamilies returns the sorted names of all
+// registered font families.
+func FontFamilies() []string {
+	fontLock.RLock()
+	defer fontLock.RUnlock()
+	names := make([]string, 0, len(families))
+	for n := range families {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MakeTypeface returns a font of the given size using the
+// registered face of the family that best matches the
+// requested weight and style.  Matching follows the CSS
+// font matching rules: the style is matched first, with
+// italic and oblique substituting for each other, then
+// the closest available weight is chosen.
+func MakeTypeface(t Typeface, size Length) (Font, error) {
+	name, err := matchFace(t)
+	if err != nil {
+		return Font{Size: size}, err
+	}
+	return MakeFont(name, size)
+}
+
+// SetTypeface changes the font to the registered face
+// that best matches t, keeping the font size.
+// If an error is returned then the font is left unchanged.
+func (f *Font) SetTypeface(t Typeface) error {
+	name, err := matchFace(t)
+	if err != nil {
+		return err
+	}
+	return f.SetName(name)
+}
+
+// Typeface returns the typeface of the font.  Fonts that are
+// not part of a registered family, such as fonts added by
+// AddFont, are reported as the normal face of a family with
+// the font's name.
+func (f *Font) Typeface() Typeface {
+	fontLock.RLock()
+	t, ok := faces[f.name]
+	fontLock.RUnlock()
+	if !ok {
+		return Typeface{Family: f.name, Weight: WeightNormal}
+	}
+	return t
+}
+
+// FontData returns the font file data for the named font.
+func FontData(name string) ([]byte, error) {
+	fontLock.RLock()
+	data, ok := fontBytes[name]
+	fontLock.RUnlock()
+	if ok {
+		return data, nil
+	}
+	return fontData(name)
+}
+
+// matchFace returns the name of the registered face
+// best matching t.
+func matchFace(t Typeface) (string, error) {
+	fontLock.RLock()
+	defer fontLock.RUnlock()
+	fs := families[t.Family]
+	if len(fs) == 0 {
+		return "", errors.New("vg: unknown font family " + t.Family)
+	}
+
+	var styles []FontStyle
+	switch t.Style {
+	case StyleItalic:
+		styles = []FontStyle{StyleItalic, StyleOblique, StyleNormal}
+	case StyleOblique:
+		styles = []FontStyle{StyleOblique, StyleItalic, StyleNormal}
+	default:
+		styles = []FontStyle{StyleNormal, StyleOblique, StyleItalic}
+	}
+	var cands []face
+	for _, s := range styles {
+		for _, f := range fs {
+			if f.Style == s {
+				cands = append(cands, f)
+			}
+		}
+		if len(cands) > 0 {
+			break
+		}
+	}
+
+	want := t.weight()
+	best := cands[0]
+	bc, bd := weightRank(want, best.weight())
+	for _, f := range cands[1:] {
+		c, d := weightRank(want, f.weight())
+		if c < bc || (c == bc && d < bd) {
+			best, bc, bd = f, c, d
+		}
+	}
+	return best.name, nil
+}
+
+// weightRank ranks the weight w as a substitute for the
+// wanted weight.  Lower classes are better, and within a
+// class smaller distances are better.
+func weightRank(want, w FontWeight) (class int, dist FontWeight) {
+	switch {
+	case want >= WeightNormal && want <= WeightMedium:
+		switch {
+		case w >= want && w <= WeightMedium:
+			return 0, w - want
+		case w < want:
+			return 1, want - w
+		default:
+			return 2, w - want
+		}
+	case want < WeightNormal:
+		if w <= want {
+			return 0, want - w
+		}
+		return 1, w - want
+	default:
+		if w >= want {
+			return 0, w - want
+		}
+		return 1, want - w
+	}
+}
+
+// fontFallbacks are the font fallbacks set by SetFontFallbacks.
+// The slice is replaced but never modified, guarded by fontLock.
+var fontFallbacks []string
+
+// SetFontFallbacks sets the font families, or font names,
+// that are searched in order for glyphs missing in the
+// font of a text, for example CJK characters or symbols.
+// A fallback family is used with the face best matching
+// the weight and style of the original font.
+// SetFontFallbacks is safe for concurrent use with drawing.
+func SetFontFallbacks(names ...string) {
+	fb := append([]string(nil), names...)
+	fontLock.Lock()
+	fontFallbacks = fb
+	fontLock.Unlock()
+}
+
+// FontFallbacks returns the font fallbacks
+// set by SetFontFallbacks.
+func FontFallbacks() []string {
+	return append([]string(nil), fallbacks()...)
+}
+
+// fallbacks returns the font fallbacks
+// without copying them.
+func fallbacks() []string {
+	fontLock.RLock()
+	defer fontLock.RUnlock()
+	return fontFallbacks
+}
+
+// A TextRun is a part of a text that is drawn using a
+// single font.
+type TextRun struct {
+	Font Font
+	Text string
+}
+
+// Runs splits the string s into runs of text, each using the
+// font itself or the first of the FontFallbacks providing a
+// glyph for the run's characters.  Characters without a glyph
+// in any font stay with the font itself.
+func (f *Font) Runs(s string) []TextRun {
+	fbs := fallbacks()
+	if len(fbs) == 0 || f.font == nil {
+		return []TextRun{{Font: *f, Text: s}}
+	}
+
+	fonts := []Font{*f}
+	t := f.Typeface()
+	for _, fb := range fbs {
+		name, err := matchFace(Typeface{Family: fb, Weight: t.Weight, Style: t.Style})
+		if err != nil {
+			name = fb
+		}
+		fnt, err := MakeFont(name, f.Size)
+		if err != nil {
+			continue
+		}
+		fonts = append(fonts, fnt)
+	}
+
+	var (
+		runs  []TextRun
+		start int
+		cur   = -1
+	)
+	for i, r := range s {
+		idx := 0
+		for j, fnt := range fonts {
+			if fnt.font.Index(r) != 0 {
+				idx = j
+				break
+			}
+		}
+		if idx != cur && cur >= 0 {
+			runs = append(runs, TextRun{Font: fonts[cur], Text: s[start:i]})
+			start = i
+		}
+		cur = idx
+	}
+	if cur >= 0 && start < len(s) {
+		runs = append(runs, TextRun{Font: fonts[cur], Text: s[start:]})
+	}
+	if len(runs) == 0 {
+		runs = []TextRun{{Font: *f, Text: s}}
+	}
+	return runs
+}
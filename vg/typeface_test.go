@@ -0,0 +1,150 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg_test
+
+import (
+	"testing"
+
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+func TestMakeTypeface(t *testing.T) {
+	for _, test := range []struct {
+		face vg.Typeface
+		want string
+	}{
+		{face: vg.Typeface{Family: "Times"}, want: "Times-Roman"},
+		{face: vg.Typeface{Family: "Times", Weight: vg.WeightBold, Style: vg.StyleItalic}, want: "Times-BoldItalic"},
+		{face: vg.Typeface{Family: "Times", Style: vg.StyleOblique}, want: "Times-Italic"},
+		{face: vg.Typeface{Family: "Helvetica", Style: vg.StyleItalic}, want: "Helvetica-Oblique"},
+		{face: vg.Typeface{Family: "Courier", Weight: vg.WeightBlack}, want: "Courier-Bold"},
+		{face: vg.Typeface{Family: "Courier", Weight: vg.WeightLight}, want: "Courier"},
+		{face: vg.Typeface{Family: "Courier", Weight: vg.WeightSemiBold}, want: "Courier-Bold"},
+		{face: vg.Typeface{Family: "Courier", Weight: vg.WeightMedium}, want: "Courier"},
+	} {
+		fnt, err := vg.MakeTypeface(test.face, 10)
+		if err != nil {
+			t.Errorf("unexpected error for %+v: %v", test.face, err)
+			continue
+		}
+		if fnt.Name() != test.want {
+			t.Errorf("unexpected font for %+v: got:%q want:%q", test.face, fnt.Name(), test.want)
+		}
+		if fnt.Size != 10 {
+			t.Errorf("unexpected font size for %+v: got:%v want:10", test.face, fnt.Size)
+		}
+	}
+
+	_, err := vg.MakeTypeface(vg.Typeface{Family: "no such family"}, 10)
+	if err == nil {
+		t.Errorf("expected error for unknown family")
+	}
+}
+
+func TestRegisterFont(t *testing.T) {
+	regular := vg.Typeface{Family: "Go Test"}
+	bold := vg.Typeface{Family: "Go Test", Weight: vg.WeightBold}
+	if err := vg.RegisterFont(regular, goregular.TTF); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := vg.RegisterFont(bold, gobold.TTF); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := vg.RegisterFont(vg.Typeface{Family: "Go Test"}, []byte("not a font")); err == nil {
+		t.Errorf("expected error for invalid font data")
+	}
+
+	fnt, err := vg.MakeTypeface(vg.Typeface{Family: "Go Test", Weight: vg.WeightExtraBold, Style: vg.StyleItalic}, 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fnt.Typeface(); got != bold {
+		t.Errorf("unexpected typeface: got:%+v want:%+v", got, bold)
+	}
+	data, err := vg.FontData(fnt.Name())
+	if err != nil || len(data) != len(gobold.TTF) {
+		t.Errorf("unexpected font data: got %d bytes, error %v", len(data), err)
+	}
+
+	err = fnt.SetTypeface(regular)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fnt.Typeface(); got.Weight != vg.WeightNormal || fnt.Size != 12 {
+		t.Errorf("unexpected font after SetTypeface: got:%+v size %v", got, fnt.Size)
+	}
+
+	var found bool
+	for _, f := range vg.FontFamilies() {
+		found = found || f == "Go Test"
+	}
+	if !found {
+		t.Errorf("registered family missing in %v", vg.FontFamilies())
+	}
+}
+
+func TestFontRuns(t *testing.T) {
+	// The primary font has glyphs only for the
+	// characters of "Hello ", so that the others
+	// are taken from the fallback font.
+	data, err := vg.FontData("Helvetica")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err = vg.SubsetFont(data, []rune("Helo "))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	face := vg.Typeface{Family: "Runs Test"}
+	if err := vg.RegisterFont(face, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fnt, err := vg.MakeTypeface(face, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const txt = "Hello World"
+	if runs := fnt.Runs(txt); len(runs) != 1 || runs[0].Text != txt || runs[0].Font.Name() != fnt.Name() {
+		t.Errorf("unexpected runs without fallbacks: %+v", runs)
+	}
+
+	defer vg.SetFontFallbacks(vg.FontFallbacks()...)
+	fbs := []string{"no such font", "Helvetica"}
+	vg.SetFontFallbacks(fbs...)
+	fbs[1] = "Courier"
+	if got := vg.FontFallbacks(); len(got) != 2 || got[1] != "Helvetica" {
+		t.Errorf("unexpected font fallbacks: %v", got)
+	}
+
+	want := []struct{ font, text string }{
+		{font: fnt.Name(), text: "Hello "},
+		{font: "Helvetica", text: "W"},
+		{font: fnt.Name(), text: "o"},
+		{font: "Helvetica", text: "r"},
+		{font: fnt.Name(), text: "l"},
+		{font: "Helvetica", text: "d"},
+	}
+	runs := fnt.Runs(txt)
+	if len(runs) != len(want) {
+		t.Fatalf("unexpected runs: %+v", runs)
+	}
+	var width vg.Length
+	for i, r := range runs {
+		if r.Font.Name() != want[i].font || r.Text != want[i].text || r.Font.Size != 10 {
+			t.Errorf("unexpected run %d: got:%q in %s want:%q in %s", i, r.Text, r.Font.Name(), want[i].text, want[i].font)
+		}
+		width += r.Font.Width(r.Text)
+	}
+	if got := fnt.Width(txt); got != width {
+		t.Errorf("unexpected width: got:%v want:%v", got, width)
+	}
+	if runs := fnt.Runs(""); len(runs) != 1 {
+		t.Errorf("unexpected number of runs for empty text: %d", len(runs))
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"image"
 	"image/color"
 	"io"
+	"math"
 )
 
 // A Canvas is the main drawing interface for 2D vector
@@ -32,6 +33,26 @@ type Canvas interface {
 	// The initial dash pattern is a solid line.
 	SetLineDash(pattern []Length, offset Length)
 
+	// SetLineCap sets the shape of the ends of
+	// stroked open paths and of dashes.
+	//
+	// The initial line cap is ButtCap.
+	SetLineCap(LineCap)
+
+	// SetLineJoin sets the shape of the corners
+	// of stroked paths.
+	//
+	// The initial line join is MiterJoin.
+	SetLineJoin(LineJoin)
+
+	// SetMiterLimit sets the largest ratio of the
+	// miter length to the line width for which
+	// corners are mitered.  Sharper corners are
+	// beveled.
+	//
+	// The initial miter limit is DefaultMiterLimit.
+	SetMiterLimit(float64)
+
 	// SetColor sets the current drawing color.
 	// Note that fill color and stroke color are
 	// the same, so if you want different fill
@@ -42,6 +63,14 @@ type Canvas interface {
 	// called with a nil color then black is used.
 	SetColor(color.Color)
 
+	// SetFillStyle sets the style used to paint
+	// the interior of filled paths.  If the style
+	// is nil, paths are filled with the current
+	// color.
+	//
+	// The initial fill style is nil.
+	SetFillStyle(FillStyle)
+
 	// Rotate applies a rotation transform to the
 	// context.  The parameter is specified in
 	// radians.
@@ -56,10 +85,12 @@ type Canvas interface {
 	Scale(x, y float64)
 
 	// Push saves the current line width, the
-	// current dash pattern, the current
-	// transforms, and the current color
-	// onto a stack so that the state can later
-	// be restored by calling Pop().
+	// current dash pattern, the current line
+	// cap, join and miter limit, the current
+	// transforms, the current color and the
+	// current fill style onto a stack so that
+	// the state can later be restored by
+	// calling Pop().
 	Push()
 
 	// Pop restores the context saved by the
@@ -99,7 +130,11 @@ type CanvasWriterTo interface {
 func Initialize(c Canvas) {
 	c.SetLineWidth(Points(1))
 	c.SetLineDash([]Length{}, 0)
+	c.SetLineCap(ButtCap)
+	c.SetLineJoin(MiterJoin)
+	c.SetMiterLimit(DefaultMiterLimit)
 	c.SetColor(color.Black)
+	c.SetFillStyle(nil)
 }
 
 type Path []PathComp
@@ -147,6 +182,54 @@ func (p *Path) Close() {
 	*p = append(*p, PathComp{Type: CloseComp})
 }
 
+// Bounds returns the bounding box of the path.
+// The control points of curves are included in the
+// bounds, so the box may be larger than the area
+// covered by the path.
+func (p Path) Bounds() Rectangle {
+	r := Rectangle{
+		Min: Point{X: Length(math.Inf(1)), Y: Length(math.Inf(1))},
+		Max: Point{X: Length(math.Inf(-1)), Y: Length(math.Inf(-1))},
+	}
+	add := func(pt Point) {
+		r.Min.X = Length(math.Min(float64(r.Min.X), float64(pt.X)))
+		r.Min.Y = Length(math.Min(float64(r.Min.Y), float64(pt.Y)))
+		r.Max.X = Length(math.Max(float64(r.Max.X), float64(pt.X)))
+		r.Max.Y = Length(math.Max(float64(r.Max.Y), float64(pt.Y)))
+	}
+	for _, comp := range p {
+		switch comp.Type {
+		case MoveComp, LineComp:
+			add(comp.Pos)
+		case CurveComp:
+			for _, c := range comp.Control {
+				add(c)
+			}
+			add(comp.Pos)
+		case ArcComp:
+			onArc := func(a float64) {
+				add(Point{
+					X: comp.Pos.X + comp.Radius*Length(math.Cos(a)),
+					Y: comp.Pos.Y + comp.Radius*Length(math.Sin(a)),
+				})
+			}
+			s, e := comp.Start, comp.Start+comp.Angle
+			if e < s {
+				s, e = e, s
+			}
+			onArc(s)
+			onArc(e)
+			for a := math.Ceil(s/(math.Pi/2)) * math.Pi / 2; a < e; a += math.Pi / 2 {
+				onArc(a)
+			}
+		}
+	}
+	if r.Min.X > r.Max.X {
+		return Rectangle{}
+	}
+	return r
+}
+
 // Constants that tag the type of each path
 // component.
 const (
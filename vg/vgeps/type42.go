@@ -0,0 +1,179 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgeps
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"golang.org/x/image/math/fixed"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+// maxChunk is the largest number of font data bytes placed
+// in one string of the sfnts array.  Together with the
+// padding byte it is the maximal PostScript string length.
+const maxChunk = 65534
+
+// type42 is a TrueType font embedded as a Type 42 font.
+type type42 struct {
+	name  string
+	font  vg.Font
+	data  []byte
+	runes map[rune]bool
+}
+
+// psName returns a PostScript font name for the font name n.
+func psName(n string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return -1
+	}, n)
+}
+
+// glyphName returns the glyph name used for the rune r.
+func glyphName(r rune) string {
+	if r > 0xffff {
+		return fmt.Sprintf("u%X", r)
+	}
+	return fmt.Sprintf("uni%04X", r)
+}
+
+// writeTo writes the font resource to w.
+func (t *type42) writeTo(w io.Writer) error {
+	chunks, err := sfnts(t.data)
+	if err != nil {
+		return err
+	}
+
+	ttf := t.font.Font()
+	upe := ttf.FUnitsPerEm()
+	b := ttf.Bounds(fixed.Int26_6(upe))
+	s := 1 / float64(upe)
+
+	runes := make([]rune, 0, len(t.runes))
+	for r := range t.runes {
+		if ttf.Index(r) != 0 {
+			runes = append(runes, r)
+		}
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	fmt.Fprintf(w, "%%%%BeginResource: font %s\n", t.name)
+	fmt.Fprintf(w, "11 dict begin\n")
+	fmt.Fprintf(w, "/FontName /%s def\n", t.name)
+	fmt.Fprintf(w, "/FontType 42 def\n")
+	fmt.Fprintf(w, "/PaintType 0 def\n")
+	fmt.Fprintf(w, "/FontMatrix [1 0 0 1 0 0] def\n")
+	fmt.Fprintf(w, "/FontBBox [%.*g %.*g %.*g %.*g] def\n",
+		pr, float64(b.Min.X)*s, pr, float64(b.Min.Y)*s,
+		pr, float64(b.Max.X)*s, pr, float64(b.Max.Y)*s)
+	fmt.Fprintf(w, "/Encoding StandardEncoding def\n")
+	fmt.Fprintf(w, "/CharStrings %d dict dup begin\n", len(runes)+1)
+	fmt.Fprintf(w, "/.notdef 0 def\n")
+	for _, r := range runes {
+		fmt.Fprintf(w, "/%s %d def\n", glyphName(r), ttf.Index(r))
+	}
+	fmt.Fprintf(w, "end def\n")
+	fmt.Fprintf(w, "/sfnts [\n")
+	for _, c := range chunks {
+		// Each string carries a trailing padding byte,
+		// which is ignored by the interpreter.
+		str := hex.EncodeToString(append(c[:len(c):len(c)], 0))
+		io.WriteString(w, "<")
+		for len(str) > 72 {
+			io.WriteString(w, str[:72]+"\n")
+			str = str[72:]
+		}
+		io.WriteString(w, str+">\n")
+	}
+	fmt.Fprintf(w, "] def\n")
+	fmt.Fprintf(w, "FontName currentdict end definefont pop\n")
+	_, err = fmt.Fprintf(w, "%%%%EndResource\n")
+	return err
+}
+
+// sfnts splits TrueType font data into the strings of
+// the sfnts array of a Type 42 font.  Strings end at table
+// boundaries or at glyph boundaries in the glyf table.
+func sfnts(data []byte) ([][]byte, error) {
+	if len(data) < 12 {
+		return nil, errors.New("vgeps: invalid TrueType data")
+	}
+	be := binary.BigEndian
+	n := int(be.Uint16(data[4:]))
+	if len(data) < 12+16*n {
+		return nil, errors.New("vgeps: invalid TrueType table directory")
+	}
+
+	tables := make(map[string][]byte)
+	offs := make(map[string]int)
+	breaks := []int{len(data)}
+	for i := 0; i < n; i++ {
+		rec := data[12+16*i:]
+		tag := string(rec[:4])
+		off, l := int(be.Uint32(rec[8:])), int(be.Uint32(rec[12:]))
+		if off < 0 || l < 0 || off+l > len(data) {
+			return nil, fmt.Errorf("vgeps: invalid TrueType table %q", tag)
+		}
+		tables[tag] = data[off : off+l]
+		offs[tag] = off
+		breaks = append(breaks, off)
+	}
+
+	head, loca, maxp := tables["head"], tables["loca"], tables["maxp"]
+	if glyf, ok := offs["glyf"]; ok && len(head) >= 54 && len(maxp) >= 6 {
+		long := be.Uint16(head[50:]) != 0
+		glyphs := int(be.Uint16(maxp[4:]))
+		for i := 0; i <= glyphs; i++ {
+			var off int
+			switch {
+			case long && 4*i+4 <= len(loca):
+				off = int(be.Uint32(loca[4*i:]))
+			case !long && 2*i+2 <= len(loca):
+				off = 2 * int(be.Uint16(loca[2*i:]))
+			default:
+				continue
+			}
+			breaks = append(breaks, glyf+off)
+		}
+	}
+	sort.Ints(breaks)
+
+	var (
+		chunks [][]byte
+		start  int
+		last   int
+	)
+	for _, b := range breaks {
+		if b <= last || b > len(data) {
+			continue
+		}
+		if b-start > maxChunk && last > start {
+			chunks = append(chunks, data[start:last])
+			start = last
+		}
+		for b-start > maxChunk {
+			// A single table or glyph does not fit
+			// into a string and has to be split.
+			chunks = append(chunks, data[start:start+maxChunk])
+			start += maxChunk
+		}
+		last = b
+	}
+	if start < len(data) {
+		chunks = append(chunks, data[start:])
+	}
+	return chunks, nil
+}
@@ -26,13 +26,25 @@ type Canvas struct {
 	stack []context
 	w, h  vg.Length
 	buf   *bytes.Buffer
+
+	// hdr is the length of the header comments
+	// at the start of buf.
+	hdr int
+
+	// fonts holds the fonts embedded in the
+	// prolog, in the order of their first use.
+	fonts []*type42
 }
 
 type context struct {
 	color  color.Color
+	fill   vg.FillStyle
 	width  vg.Length
 	dashes []vg.Length
 	offs   vg.Length
+	cap    vg.LineCap
+	join   vg.LineJoin
+	miter  float64
 	font   string
 	fsize  vg.Length
 }
@@ -62,6 +74,7 @@ func NewTitle(w, h vg.Length, title string) *Canvas {
 	c.buf.WriteString(fmt.Sprintf("%%%%CreationDate: %s\n", time.Now()))
 	c.buf.WriteString("%%Orientation: Portrait\n")
 	c.buf.WriteString("%%EndComments\n")
+	c.hdr = c.buf.Len()
 	c.buf.WriteString("\n")
 	vg.Initialize(c)
 	return c
@@ -103,19 +116,43 @@ func (e *Canvas) SetLineDash(dashes []vg.Length, o vg.Length) {
 	}
 }
 
+func (e *Canvas) SetLineCap(lc vg.LineCap) {
+	if e.context().cap != lc {
+		e.context().cap = lc
+		// vg.LineCap values are the PostScript codes.
+		fmt.Fprintf(e.buf, "%d setlinecap\n", lc)
+	}
+}
+
+func (e *Canvas) SetLineJoin(lj vg.LineJoin) {
+	if e.context().join != lj {
+		e.context().join = lj
+		// vg.LineJoin values are the PostScript codes.
+		fmt.Fprintf(e.buf, "%d setlinejoin\n", lj)
+	}
+}
+
+func (e *Canvas) SetMiterLimit(limit float64) {
+	if e.context().miter != limit {
+		e.context().miter = limit
+		fmt.Fprintf(e.buf, "%.*g setmiterlimit\n", pr, limit)
+	}
+}
+
 func (e *Canvas) SetColor(c color.Color) {
 	if c == nil {
 		c = color.Black
 	}
 	if e.context().color != c {
 		e.context().color = c
-		r, g, b, _ := c.RGBA()
-		mx := float64(math.MaxUint16)
-		fmt.Fprintf(e.buf, "%.*g %.*g %.*g setrgbcolor\n", pr, float64(r)/mx,
-			pr, float64(g)/mx, pr, float64(b)/mx)
+		fmt.Fprintf(e.buf, "%s setrgbcolor\n", rgbString(c))
 	}
 }
 
+func (e *Canvas) SetFillStyle(fs vg.FillStyle) {
+	e.context().fill = fs
+}
+
 func (e *Canvas) Rotate(r float64) {
 	fmt.Fprintf(e.buf, "%.*g rotate\n", pr, r*180/math.Pi)
 }
@@ -148,10 +185,100 @@ func (e *Canvas) Stroke(path vg.Path) {
 }
 
 func (e *Canvas) Fill(path vg.Path) {
+	if fs := e.context().fill; fs != nil {
+		e.fillStyle(path, fs)
+		return
+	}
 	e.trace(path)
 	e.buf.WriteString("fill\n")
 }
 
+// fillStyle fills the path using the given fill style.
+// Gradients are painted with PostScript level 3 shadings,
+// hatch patterns are stroked within a clip of the path.
+func (e *Canvas) fillStyle(path vg.Path, fs vg.FillStyle) {
+	bounds := path.Bounds()
+	e.buf.WriteString("gsave\n")
+	defer e.buf.WriteString("grestore\n")
+
+	switch fs := fs.(type) {
+	case vg.LinearGradient:
+		if len(fs.Stops) == 0 {
+			return
+		}
+		p0, p1 := fs.Points(bounds)
+		e.trace(path)
+		e.buf.WriteString("clip newpath\n")
+		fmt.Fprintf(e.buf, "<< /ShadingType 2 /ColorSpace /DeviceRGB /Coords [%.*g %.*g %.*g %.*g] /Extend [true true]\n",
+			pr, p0.X.Dots(DPI), pr, p0.Y.Dots(DPI), pr, p1.X.Dots(DPI), pr, p1.Y.Dots(DPI))
+		e.shadingFunction(fs.Stops)
+		e.buf.WriteString(">> shfill\n")
+
+	case vg.RadialGradient:
+		if len(fs.Stops) == 0 {
+			return
+		}
+		ctr, r := fs.Circle(bounds)
+		e.trace(path)
+		e.buf.WriteString("clip newpath\n")
+		fmt.Fprintf(e.buf, "<< /ShadingType 3 /ColorSpace /DeviceRGB /Coords [%.*g %.*g 0 %.*g %.*g %.*g] /Extend [true true]\n",
+			pr, ctr.X.Dots(DPI), pr, ctr.Y.Dots(DPI), pr, ctr.X.Dots(DPI), pr, ctr.Y.Dots(DPI), pr, r.Dots(DPI))
+		e.shadingFunction(fs.Stops)
+		e.buf.WriteString(">> shfill\n")
+
+	case vg.HatchPattern:
+		if fs.Background != nil {
+			e.trace(path)
+			fmt.Fprintf(e.buf, "gsave %s setrgbcolor fill grestore\n", rgbString(fs.Background))
+		}
+		e.trace(path)
+		e.buf.WriteString("clip newpath\n")
+		fmt.Fprintf(e.buf, "%s setrgbcolor\n", rgbString(fs.Color))
+		if lines := fs.Lines(bounds); len(lines) > 0 {
+			fmt.Fprintf(e.buf, "%.*g setlinewidth [ ] 0 setdash\n", pr, fs.LineWidth().Dots(DPI))
+			e.trace(lines)
+			e.buf.WriteString("stroke\n")
+		}
+		if dots := fs.Dots(bounds); len(dots) > 0 {
+			e.trace(dots)
+			e.buf.WriteString("fill\n")
+		}
+
+	default:
+		panic(fmt.Sprintf("vgeps: unknown fill style %T", fs))
+	}
+}
+
+// shadingFunction writes a stitching function interpolating
+// linearly between the gradient stops.
+func (e *Canvas) shadingFunction(stops []vg.GradientStop) {
+	stops = vg.PaddedStops(stops)
+	e.buf.WriteString("/Function << /FunctionType 3 /Domain [0 1] /Functions [\n")
+	for i := 1; i < len(stops); i++ {
+		fmt.Fprintf(e.buf, "<< /FunctionType 2 /Domain [0 1] /C0 [%s] /C1 [%s] /N 1 >>\n",
+			rgbString(stops[i-1].Color), rgbString(stops[i].Color))
+	}
+	e.buf.WriteString("] /Bounds [")
+	for _, s := range stops[1 : len(stops)-1] {
+		fmt.Fprintf(e.buf, " %.*g", pr, s.Offset)
+	}
+	e.buf.WriteString(" ] /Encode [")
+	for i := 1; i < len(stops); i++ {
+		e.buf.WriteString(" 0 1")
+	}
+	e.buf.WriteString(" ] >>\n")
+}
+
+// rgbString returns the PostScript RGB components of the color.
+func rgbString(c color.Color) string {
+	if c == nil {
+		c = color.Black
+	}
+	r, g, b, _ := c.RGBA()
+	mx := float64(math.MaxUint16)
+	return fmt.Sprintf("%.*g %.*g %.*g", pr, float64(r)/mx, pr, float64(g)/mx, pr, float64(b)/mx)
+}
+
 func (e *Canvas) trace(path vg.Path) {
 	e.buf.WriteString("newpath\n")
 	for _, comp := range path {
@@ -192,14 +319,61 @@ func (e *Canvas) trace(path vg.Path) {
 }
 
 func (e *Canvas) FillString(fnt vg.Font, pt vg.Point, str string) {
-	if e.context().font != fnt.Name() || e.context().fsize != fnt.Size {
-		e.context().font = fnt.Name()
+	for _, run := range fnt.Runs(str) {
+		e.fillString(run.Font, pt, run.Text)
+		pt.X += run.Font.Width(run.Text)
+	}
+}
+
+// fillString draws str using the single font fnt.  The standard
+// PostScript fonts are referenced by name, other fonts with
+// available font data are embedded as Type 42 fonts.
+func (e *Canvas) fillString(fnt vg.Font, pt vg.Point, str string) {
+	name := fnt.Name()
+	t42 := e.embed(fnt)
+	if t42 != nil {
+		name = t42.name
+	}
+	if e.context().font != name || e.context().fsize != fnt.Size {
+		e.context().font = name
 		e.context().fsize = fnt.Size
 		fmt.Fprintf(e.buf, "/%s findfont %.*g scalefont setfont\n",
-			fnt.Name(), pr, fnt.Size)
+			name, pr, fnt.Size)
 	}
 	fmt.Fprintf(e.buf, "%.*g %.*g moveto\n", pr, pt.X.Dots(DPI), pr, pt.Y.Dots(DPI))
-	fmt.Fprintf(e.buf, "(%s) show\n", str)
+	if t42 == nil {
+		fmt.Fprintf(e.buf, "(%s) show\n", str)
+		return
+	}
+	for i, r := range str {
+		if i > 0 {
+			e.buf.WriteString(" ")
+		}
+		t42.runes[r] = true
+		fmt.Fprintf(e.buf, "/%s glyphshow", glyphName(r))
+	}
+	e.buf.WriteString("\n")
+}
+
+// embed returns the embedded font for fnt, or nil if fnt is
+// a standard PostScript font or its font data is unavailable.
+func (e *Canvas) embed(fnt vg.Font) *type42 {
+	if _, ok := vg.FontMap[fnt.Name()]; ok {
+		return nil
+	}
+	name := psName(fnt.Name())
+	for _, t := range e.fonts {
+		if t.name == name {
+			return t
+		}
+	}
+	data, err := vg.FontData(fnt.Name())
+	if err != nil || fnt.Font() == nil {
+		return nil
+	}
+	t := &type42{name: name, font: fnt, data: data, runes: make(map[rune]bool)}
+	e.fonts = append(e.fonts, t)
+	return t
 }
 
 // DrawImage implements the vg.Canvas.DrawImage method.
@@ -211,12 +385,34 @@ func (c *Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
 // WriteTo writes the canvas to an io.Writer.
 func (e *Canvas) WriteTo(w io.Writer) (int64, error) {
 	b := bufio.NewWriter(w)
-	n, err := e.buf.WriteTo(b)
+	var n int64
+	if len(e.fonts) > 0 {
+		hdr, err := b.Write(e.buf.Next(e.hdr))
+		n += int64(hdr)
+		if err != nil {
+			return n, err
+		}
+		var prolog bytes.Buffer
+		prolog.WriteString("%%BeginProlog\n")
+		for _, t := range e.fonts {
+			if err := t.writeTo(&prolog); err != nil {
+				return n, err
+			}
+		}
+		prolog.WriteString("%%EndProlog\n")
+		m, err := prolog.WriteTo(b)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	m, err := e.buf.WriteTo(b)
+	n += m
 	if err != nil {
 		return n, err
 	}
-	m, err := fmt.Fprintln(b, "showpage")
-	n += int64(m)
+	k, err := fmt.Fprintln(b, "showpage")
+	n += int64(k)
 	if err != nil {
 		return n, err
 	}
@@ -0,0 +1,132 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgeps
+
+import (
+	"bytes"
+	"image/color"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+func TestType42(t *testing.T) {
+	face := vg.Typeface{Family: "Go Regular"}
+	if err := vg.RegisterFont(face, goregular.TTF); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fnt, err := vg.MakeTypeface(face, 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := New(100, 100)
+	c.FillString(fnt, vg.Point{X: 10, Y: 10}, "Aé")
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"%%BeginResource: font GoRegular\n",
+		"/FontType 42 def\n",
+		"/uni0041 ",
+		"/uni00E9 ",
+		"/GoRegular findfont 12 scalefont setfont\n",
+		"/uni0041 glyphshow /uni00E9 glyphshow\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in output", want)
+		}
+	}
+	if strings.Index(out, "%%EndComments") > strings.Index(out, "%%BeginProlog") {
+		t.Errorf("prolog written before the end of the header comments")
+	}
+
+	chunks, err := sfnts(goregular.TTF)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var n int
+	for _, c := range chunks {
+		if len(c) > maxChunk {
+			t.Errorf("sfnts string too long: %d", len(c))
+		}
+		n += len(c)
+	}
+	if n != len(goregular.TTF) {
+		t.Errorf("unexpected sfnts length: got:%d want:%d", n, len(goregular.TTF))
+	}
+}
+
+func TestFillStyles(t *testing.T) {
+	stops := []vg.GradientStop{
+		{Offset: 0, Color: color.White},
+		{Offset: 0.5, Color: color.Black},
+		{Offset: 1, Color: color.White},
+	}
+	for _, test := range []struct {
+		name string
+		fs   vg.FillStyle
+		want []string
+	}{
+		{
+			name: "linear",
+			fs:   vg.LinearGradient{X1: 1, Stops: stops},
+			want: []string{
+				"clip newpath\n",
+				"<< /ShadingType 2 /ColorSpace /DeviceRGB /Coords [10 10 90 10] /Extend [true true]\n",
+				"/Function << /FunctionType 3 /Domain [0 1] /Functions [\n",
+				"<< /FunctionType 2 /Domain [0 1] /C0 [1 1 1] /C1 [0 0 0] /N 1 >>\n",
+				"<< /FunctionType 2 /Domain [0 1] /C0 [0 0 0] /C1 [1 1 1] /N 1 >>\n",
+				"] /Bounds [ 0.5 ] /Encode [ 0 1 0 1 ] >>\n",
+				">> shfill\n",
+			},
+		},
+		{
+			name: "radial",
+			fs:   vg.RadialGradient{CX: 0.5, CY: 0.5, R: 0.5, Stops: stops},
+			want: []string{
+				"clip newpath\n",
+				"<< /ShadingType 3 /ColorSpace /DeviceRGB /Coords [50 50 0 50 50 40] /Extend [true true]\n",
+				">> shfill\n",
+			},
+		},
+		{
+			name: "hatch",
+			fs:   vg.HatchPattern{Kind: vg.HorizontalHatch, Color: color.Black, Background: color.White, Spacing: 10},
+			want: []string{
+				"gsave 1 1 1 setrgbcolor fill grestore\n",
+				"clip newpath\n",
+				"0 0 0 setrgbcolor\n",
+				"0.5 setlinewidth [ ] 0 setdash\n",
+				"10 50 moveto\n90 50 lineto\n",
+				"stroke\n",
+			},
+		},
+	} {
+		c := New(100, 100)
+		c.SetFillStyle(test.fs)
+		var p vg.Path
+		p.Move(vg.Point{X: 10, Y: 10})
+		p.Line(vg.Point{X: 90, Y: 10})
+		p.Line(vg.Point{X: 90, Y: 90})
+		p.Close()
+		c.Fill(p)
+		var buf bytes.Buffer
+		if _, err := c.WriteTo(&buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+		for _, want := range test.want {
+			if !strings.Contains(out, want) {
+				t.Errorf("missing %q in output for %s", want, test.name)
+			}
+		}
+	}
+}
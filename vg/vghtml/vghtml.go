@@ -0,0 +1,496 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package vghtml implements the vg.Canvas interface by
+// recording JavaScript that replays the drawing on an HTML5
+// canvas element.  The output is a self-contained HTML page
+// or a script for an existing canvas element.
+package vghtml // import "github.com/hneemann/nplot/vg/vghtml"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+// pr is the precision to use when outputting float64s.
+const pr = 5
+
+const (
+	// DefaultWidth and DefaultHeight are the default canvas
+	// dimensions.
+	DefaultWidth  = 4 * vg.Inch
+	DefaultHeight = 4 * vg.Inch
+
+	// DefaultID is the default id of the canvas element.
+	DefaultID = "nplot"
+)
+
+// Path operations of the path function of the script.
+const (
+	opMove = iota
+	opLine
+	opArc
+	opQuad
+	opCubic
+	opClose
+)
+
+// Canvas implements the vg.Canvas interface, recording
+// the drawing as JavaScript calls on the 2D context of
+// an HTML5 canvas element.
+type Canvas struct {
+	w, h vg.Length
+	id   string
+
+	buf   *bytes.Buffer
+	stack []context
+
+	// images holds the data URLs of the drawn
+	// images, which are loaded before drawing.
+	images []string
+}
+
+type context struct {
+	color     color.Color
+	fill      vg.FillStyle
+	lineWidth vg.Length
+}
+
+type option func(*Canvas)
+
+// UseWH specifies the width and height of the canvas.
+func UseWH(w, h vg.Length) option {
+	return func(c *Canvas) {
+		if w <= 0 || h <= 0 {
+			panic("vghtml: w and h must both be > 0")
+		}
+		c.w, c.h = w, h
+	}
+}
+
+// UseID specifies the id of the canvas element.
+// The default is DefaultID.
+func UseID(id string) option {
+	return func(c *Canvas) {
+		c.id = id
+	}
+}
+
+// New returns a new HTML canvas.
+func New(w, h vg.Length) *Canvas {
+	return NewWith(UseWH(w, h))
+}
+
+// NewWith returns a new HTML canvas created according to the
+// specified options.  The currently accepted options are UseWH
+// and UseID.  If size or id are not specified, defaults are used.
+func NewWith(opts ...option) *Canvas {
+	c := &Canvas{
+		w:     DefaultWidth,
+		h:     DefaultHeight,
+		id:    DefaultID,
+		buf:   new(bytes.Buffer),
+		stack: []context{{}},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	vg.Initialize(c)
+	return c
+}
+
+func (c *Canvas) Size() (w, h vg.Length) {
+	return c.w, c.h
+}
+
+func (c *Canvas) context() *context {
+	return &c.stack[len(c.stack)-1]
+}
+
+func (c *Canvas) SetLineWidth(w vg.Length) {
+	c.context().lineWidth = w
+	fmt.Fprintf(c.buf, "c.lineWidth=%.*g;\n", pr, w.Points())
+}
+
+func (c *Canvas) SetLineDash(dashes []vg.Length, offs vg.Length) {
+	ds := make([]string, len(dashes))
+	for i, d := range dashes {
+		ds[i] = fmt.Sprintf("%.*g", pr, d.Points())
+	}
+	fmt.Fprintf(c.buf, "c.setLineDash([%s]);c.lineDashOffset=%.*g;\n",
+		strings.Join(ds, ","), pr, offs.Points())
+}
+
+func (c *Canvas) SetLineCap(lc vg.LineCap) {
+	name := "butt"
+	switch lc {
+	case vg.RoundCap:
+		name = "round"
+	case vg.SquareCap:
+		name = "square"
+	}
+	fmt.Fprintf(c.buf, "c.lineCap=%q;\n", name)
+}
+
+func (c *Canvas) SetLineJoin(lj vg.LineJoin) {
+	name := "miter"
+	switch lj {
+	case vg.RoundJoin:
+		name = "round"
+	case vg.BevelJoin:
+		name = "bevel"
+	}
+	fmt.Fprintf(c.buf, "c.lineJoin=%q;\n", name)
+}
+
+func (c *Canvas) SetMiterLimit(limit float64) {
+	fmt.Fprintf(c.buf, "c.miterLimit=%.*g;\n", pr, limit)
+}
+
+func (c *Canvas) SetColor(clr color.Color) {
+	if clr == nil {
+		clr = color.Black
+	}
+	c.context().color = clr
+	fmt.Fprintf(c.buf, "c.strokeStyle=c.fillStyle=%q;\n", colorString(clr))
+}
+
+func (c *Canvas) SetFillStyle(fs vg.FillStyle) {
+	c.context().fill = fs
+}
+
+func (c *Canvas) Rotate(rot float64) {
+	fmt.Fprintf(c.buf, "c.rotate(%g);\n", rot)
+}
+
+func (c *Canvas) Translate(pt vg.Point) {
+	fmt.Fprintf(c.buf, "c.translate(%.*g,%.*g);\n", pr, pt.X.Points(), pr, pt.Y.Points())
+}
+
+func (c *Canvas) Scale(x, y float64) {
+	fmt.Fprintf(c.buf, "c.scale(%g,%g);\n", x, y)
+}
+
+func (c *Canvas) Push() {
+	c.stack = append(c.stack, *c.context())
+	c.buf.WriteString("c.save();\n")
+}
+
+func (c *Canvas) Pop() {
+	c.stack = c.stack[:len(c.stack)-1]
+	c.buf.WriteString("c.restore();\n")
+}
+
+func (c *Canvas) Stroke(path vg.Path) {
+	if c.context().lineWidth <= 0 {
+		return
+	}
+	fmt.Fprintf(c.buf, "S(%s);\n", pathData(path))
+}
+
+func (c *Canvas) Fill(path vg.Path) {
+	switch fs := c.context().fill.(type) {
+	case nil:
+		fmt.Fprintf(c.buf, "F(%s);\n", pathData(path))
+
+	case vg.LinearGradient:
+		p0, p1 := fs.Points(path.Bounds())
+		fmt.Fprintf(c.buf, "c.save();g=c.createLinearGradient(%.*g,%.*g,%.*g,%.*g);",
+			pr, p0.X.Points(), pr, p0.Y.Points(), pr, p1.X.Points(), pr, p1.Y.Points())
+		c.gradient(path, fs.Stops)
+
+	case vg.RadialGradient:
+		ctr, r := fs.Circle(path.Bounds())
+		fmt.Fprintf(c.buf, "c.save();g=c.createRadialGradient(%.*g,%.*g,0,%.*g,%.*g,%.*g);",
+			pr, ctr.X.Points(), pr, ctr.Y.Points(), pr, ctr.X.Points(), pr, ctr.Y.Points(), pr, r.Points())
+		c.gradient(path, fs.Stops)
+
+	case vg.HatchPattern:
+		// Hatch patterns are drawn within a clip of
+		// the path, anchored at the canvas origin.
+		bounds := path.Bounds()
+		fmt.Fprintf(c.buf, "c.save();p(%s);c.clip();\n", pathData(path))
+		if fs.Background != nil {
+			fmt.Fprintf(c.buf, "c.fillStyle=%q;c.fill();\n", colorString(fs.Background))
+		}
+		clr := fs.Color
+		if clr == nil {
+			clr = color.Black
+		}
+		fmt.Fprintf(c.buf, "c.strokeStyle=c.fillStyle=%q;c.setLineDash([]);c.lineWidth=%.*g;\n",
+			colorString(clr), pr, fs.LineWidth().Points())
+		if lines := fs.Lines(bounds); len(lines) > 0 {
+			fmt.Fprintf(c.buf, "S(%s);\n", pathData(lines))
+		}
+		if dots := fs.Dots(bounds); len(dots) > 0 {
+			fmt.Fprintf(c.buf, "F(%s);\n", pathData(dots))
+		}
+		c.buf.WriteString("c.restore();\n")
+
+	default:
+		panic(fmt.Sprintf("vghtml: unknown fill style %T", fs))
+	}
+}
+
+// gradient writes the stops of the gradient g created
+// before and fills the path with it.
+func (c *Canvas) gradient(path vg.Path, stops []vg.GradientStop) {
+	if len(stops) == 0 {
+		c.buf.WriteString("c.restore();\n")
+		return
+	}
+	for _, s := range stops {
+		fmt.Fprintf(c.buf, "g.addColorStop(%.*g,%q);", pr, s.Offset, colorString(s.Color))
+	}
+	fmt.Fprintf(c.buf, "c.fillStyle=g;F(%s);c.restore();\n", pathData(path))
+}
+
+// pathData returns the JavaScript array of the path
+// operations and their coordinates.
+func pathData(path vg.Path) string {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	num := func(v ...float64) {
+		for _, v := range v {
+			fmt.Fprintf(&buf, ",%.*g", pr, v)
+		}
+	}
+	for i, comp := range path {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		switch comp.Type {
+		case vg.MoveComp:
+			buf.WriteString(fmt.Sprint(opMove))
+			num(comp.Pos.X.Points(), comp.Pos.Y.Points())
+		case vg.LineComp:
+			buf.WriteString(fmt.Sprint(opLine))
+			num(comp.Pos.X.Points(), comp.Pos.Y.Points())
+		case vg.ArcComp:
+			buf.WriteString(fmt.Sprint(opArc))
+			num(comp.Pos.X.Points(), comp.Pos.Y.Points(), comp.Radius.Points(),
+				comp.Start, comp.Start+comp.Angle)
+		case vg.CurveComp:
+			switch len(comp.Control) {
+			case 1:
+				buf.WriteString(fmt.Sprint(opQuad))
+				num(comp.Control[0].X.Points(), comp.Control[0].Y.Points(),
+					comp.Pos.X.Points(), comp.Pos.Y.Points())
+			case 2:
+				buf.WriteString(fmt.Sprint(opCubic))
+				num(comp.Control[0].X.Points(), comp.Control[0].Y.Points(),
+					comp.Control[1].X.Points(), comp.Control[1].Y.Points(),
+					comp.Pos.X.Points(), comp.Pos.Y.Points())
+			default:
+				panic("vghtml: invalid number of control points")
+			}
+		case vg.CloseComp:
+			buf.WriteString(fmt.Sprint(opClose))
+		default:
+			panic(fmt.Sprintf("vghtml: unknown path component type: %d", comp.Type))
+		}
+	}
+	buf.WriteByte(']')
+	return buf.String()
+}
+
+// FillString draws str at position pt using the specified font.
+func (c *Canvas) FillString(font vg.Font, pt vg.Point, str string) {
+	if font.Size == 0 {
+		return
+	}
+	fmt.Fprintf(c.buf, "T(%s,%s,%.*g,%.*g);\n", jsString(fontString(font)), jsString(str),
+		pr, pt.X.Points(), pr, pt.Y.Points())
+}
+
+// DrawImage implements the vg.Canvas.DrawImage method.
+func (c *Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
+	buf := new(bytes.Buffer)
+	err := png.Encode(buf, img)
+	if err != nil {
+		panic(fmt.Errorf("vghtml: error encoding image to PNG: %v", err))
+	}
+	c.images = append(c.images, "data:image/png;base64,"+base64.StdEncoding.EncodeToString(buf.Bytes()))
+	sz := rect.Size()
+	fmt.Fprintf(c.buf, "I(%d,%.*g,%.*g,%.*g,%.*g);\n", len(c.images)-1,
+		pr, rect.Min.X.Points(), pr, rect.Min.Y.Points(), pr, sz.X.Points(), pr, sz.Y.Points())
+}
+
+// fontString returns the CSS font of the font, followed
+// by the vg.FontFallbacks.
+func fontString(font vg.Font) string {
+	t := font.Typeface()
+	fams := append([]string{t.Family}, vg.FontFallbacks()...)
+	for i, f := range fams {
+		fams[i] = `"` + strings.Replace(f, `"`, "", -1) + `"`
+	}
+
+	var style string
+	switch t.Style {
+	case vg.StyleItalic:
+		style = "italic "
+	case vg.StyleOblique:
+		style = "oblique "
+	}
+	weight := t.Weight
+	if weight == 0 {
+		weight = vg.WeightNormal
+	}
+	return fmt.Sprintf("%s%d %.*gpx %s", style, weight, pr, font.Size.Points(), strings.Join(fams, ","))
+}
+
+// colorString returns the CSS representation of the color.
+func colorString(clr color.Color) string {
+	if clr == nil {
+		clr = color.Black
+	}
+	r, g, b, a := clr.RGBA()
+	if a == 0 {
+		return "rgba(0,0,0,0)"
+	}
+	if a == math.MaxUint16 {
+		return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+	}
+	un := func(v uint32) uint32 { return v * math.MaxUint16 / a >> 8 }
+	return fmt.Sprintf("rgba(%d,%d,%d,%.*g)", un(r), un(g), un(b), 3, float64(a)/math.MaxUint16)
+}
+
+// jsString returns s as a JavaScript string literal that
+// is safe to use within a script element.
+func jsString(s string) string {
+	b, err := json.Marshal(s)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// script is the code shared by all canvases.  It defines
+// the functions used by the recorded drawing:
+//
+//	p(a)             builds the path of the operations in a,
+//	S(a), F(a)       stroke and fill the path of a,
+//	T(f, s, x, y)    draws the text s at x, y using the font f,
+//	I(i, x, y, w, h) draws image i into a rectangle.
+const script = `var p = function(a) {
+	c.beginPath();
+	for (var i = 0; i < a.length;) {
+		switch (a[i++]) {
+		case 0: c.moveTo(a[i], a[i+1]); i += 2; break;
+		case 1: c.lineTo(a[i], a[i+1]); i += 2; break;
+		case 2: c.arc(a[i], a[i+1], a[i+2], a[i+3], a[i+4], a[i+4] < a[i+3]); i += 5; break;
+		case 3: c.quadraticCurveTo(a[i], a[i+1], a[i+2], a[i+3]); i += 4; break;
+		case 4: c.bezierCurveTo(a[i], a[i+1], a[i+2], a[i+3], a[i+4], a[i+5]); i += 6; break;
+		case 5: c.closePath(); break;
+		}
+	}
+};
+var S = function(a) { p(a); c.stroke(); };
+var F = function(a) { p(a); c.fill(); };
+var T = function(f, s, x, y) {
+	c.save(); c.font = f; c.translate(x, y); c.scale(1, -1); c.fillText(s, 0, 0); c.restore();
+};
+var I = function(i, x, y, w, h) {
+	c.save(); c.translate(x, y + h); c.scale(1, -1); c.drawImage(img[i], 0, 0, w, h); c.restore();
+};
+`
+
+// WriteTo writes the canvas as an HTML page to w.
+func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
+	wc := writerCounter{Writer: w}
+	b := bufio.NewWriter(&wc)
+	fmt.Fprintf(b, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>nplot</title>
+</head>
+<body>
+<canvas id="%s" style="width:%.*gpt;height:%.*gpt"></canvas>
+<script>
+`, html.EscapeString(c.id), pr, c.w.Points(), pr, c.h.Points())
+	c.writeScript(b)
+	b.WriteString("</script>\n</body>\n</html>\n")
+	err := b.Flush()
+	return wc.n, err
+}
+
+// writeScript writes the script drawing the canvas.
+func (c *Canvas) writeScript(b *bufio.Writer) {
+	fmt.Fprintf(b, "(function() {\n")
+	fmt.Fprintf(b, "var e = document.getElementById(%s);\n", jsString(c.id))
+	// The canvas has the size given in points, with a
+	// resolution matching the device pixels.  The origin
+	// is moved to the bottom left corner.
+	fmt.Fprintf(b, "var w = %.*g, h = %.*g;\n", pr, c.w.Points(), pr, c.h.Points())
+	fmt.Fprintf(b, "var r = (window.devicePixelRatio || 1) * 4 / 3;\n")
+	fmt.Fprintf(b, "e.style.width = w + \"pt\"; e.style.height = h + \"pt\";\n")
+	fmt.Fprintf(b, "e.width = Math.ceil(w * r); e.height = Math.ceil(h * r);\n")
+	fmt.Fprintf(b, "var c = e.getContext(\"2d\"), g;\n")
+	b.WriteString(script)
+	b.WriteString("var draw = function() {\n")
+	fmt.Fprintf(b, "c.setTransform(r, 0, 0, -r, 0, h * r);\n")
+	b.Write(c.buf.Bytes())
+	b.WriteString("};\n")
+	b.WriteString("var img = [")
+	for i, src := range c.images {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString("\n" + jsString(src))
+	}
+	b.WriteString("];\n")
+	b.WriteString(`var n = img.length;
+img = img.map(function(s) {
+	var i = new Image();
+	i.onload = function() { if (--n == 0) draw(); };
+	i.src = s;
+	return i;
+});
+if (n == 0) draw();
+})();
+`)
+}
+
+// A ScriptCanvas is a canvas with a WriteTo method that
+// writes only the script, which draws on the canvas element
+// with the id of the canvas in an existing page.
+type ScriptCanvas struct {
+	*Canvas
+}
+
+// WriteTo implements the io.WriterTo interface, writing
+// the script.
+func (c ScriptCanvas) WriteTo(w io.Writer) (int64, error) {
+	wc := writerCounter{Writer: w}
+	b := bufio.NewWriter(&wc)
+	c.writeScript(b)
+	err := b.Flush()
+	return wc.n, err
+}
+
+// writerCounter implements the io.Writer interface, and counts
+// the total number of bytes written.
+type writerCounter struct {
+	io.Writer
+	n int64
+}
+
+func (w *writerCounter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,105 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vghtml
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+func TestCanvas(t *testing.T) {
+	fnt, err := vg.MakeFont("Times-BoldItalic", 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := NewWith(UseWH(100, 50), UseID("plot"))
+	c.Push()
+	c.Translate(vg.Point{X: 10, Y: 20})
+	c.SetColor(color.NRGBA{R: 255, A: 128})
+	c.SetLineDash([]vg.Length{2, 1}, 0.5)
+	var p vg.Path
+	p.Move(vg.Point{X: 0, Y: 0})
+	p.Line(vg.Point{X: 10, Y: 0})
+	p.Arc(vg.Point{X: 10, Y: 10}, 10, -math.Pi/2, math.Pi)
+	p.Close()
+	c.Stroke(p)
+	c.SetFillStyle(vg.LinearGradient{X1: 1, Stops: []vg.GradientStop{{Offset: 0, Color: color.White}}})
+	c.Fill(p)
+	c.Pop()
+	c.FillString(fnt, vg.Point{X: 5, Y: 5}, "</script>")
+	c.DrawImage(vg.Rectangle{Max: vg.Point{X: 4, Y: 4}}, image.NewGray(image.Rect(0, 0, 2, 2)))
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<canvas id="plot" style="width:100pt;height:50pt"></canvas>`,
+		`document.getElementById("plot")`,
+		"c.save();\nc.translate(10,20);\n",
+		`c.strokeStyle=c.fillStyle="rgba(255,0,0,0.502)";`,
+		"c.setLineDash([2,1]);c.lineDashOffset=0.5;\n",
+		"S([0,0,0,1,10,0,2,10,10,10,-1.5708,1.5708,5]);\n",
+		`g=c.createLinearGradient(0,0,20,0);g.addColorStop(0,"#ffffff");c.fillStyle=g;F([`,
+		"c.restore();\n",
+		`T("italic 700 12px \"Times\"","\u003c/script\u003e",5,5);`,
+		"I(0,0,0,4,4);\n",
+		`"data:image/png;base64,`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in output", want)
+		}
+	}
+	if strings.Count(out, "</script>") != 1 {
+		t.Errorf("unexpected end of script in output")
+	}
+
+	buf.Reset()
+	if _, err := (ScriptCanvas{c}).WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if script := buf.String(); !strings.HasPrefix(script, "(function() {\n") || strings.Contains(script, "</") {
+		t.Errorf("unexpected script output:\n%s", script)
+	}
+
+	buf.Reset()
+	c = NewWith(UseWH(10, 10), UseID(`a"b<&>`))
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out = buf.String()
+	for _, want := range []string{
+		`<canvas id="a&#34;b&lt;&amp;&gt;" style=`,
+		`document.getElementById("a\"b\u003c\u0026\u003e")`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in output", want)
+		}
+	}
+}
+
+func TestColorString(t *testing.T) {
+	for _, test := range []struct {
+		clr  color.Color
+		want string
+	}{
+		{clr: nil, want: "#000000"},
+		{clr: color.RGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff}, want: "#123456"},
+		{clr: color.NRGBA{R: 0xff, G: 0x80, A: 0x40}, want: "rgba(255,128,0,0.251)"},
+		{clr: color.Transparent, want: "rgba(0,0,0,0)"},
+	} {
+		if got := colorString(test.clr); got != test.want {
+			t.Errorf("unexpected color for %v: got:%q want:%q", test.clr, got, test.want)
+		}
+	}
+}
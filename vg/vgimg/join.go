@@ -0,0 +1,295 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgimg
+
+import (
+	"math"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+// lineState holds the parts of the line style
+// which are not handled by gg itself.
+type lineState struct {
+	join       vg.LineJoin
+	miterLimit float64
+
+	// dashes and offs are the dash pattern
+	// in device units.
+	dashes []float64
+	offs   float64
+}
+
+// corner is a corner of a stroked path in device space.
+type corner struct {
+	pt      vg.Point
+	in, out vg.Point
+
+	// closing is set for the corner at the start
+	// of a closed subpath, which gg strokes as
+	// two line ends instead of a join.
+	closing bool
+
+	// dist is the length of the subpath
+	// up to the corner.
+	dist float64
+}
+
+// joins paints the parts of the corners of the stroked
+// path p which gg does not draw: the miters of MiterJoin
+// corners and the joins at the start of closed subpaths.
+func (c *Canvas) joins(p vg.Path) {
+	st := c.line[len(c.line)-1]
+	hw := c.width.Dots(c.DPI()) / 2
+
+	corners := c.corners(p)
+	if len(corners) == 0 {
+		return
+	}
+
+	// The corners are painted in device space.
+	c.ctx.Push()
+	defer c.ctx.Pop()
+	c.ctx.Identity()
+	painted := false
+	for _, cr := range corners {
+		if len(st.dashes) > 0 && !onDash(st.dashes, st.offs, cr.dist) {
+			continue
+		}
+		n0, n1 := outerNormals(cr.in, cr.out)
+		if n0 == n1 {
+			continue
+		}
+		a := cr.pt.Add(n0.Scale(vg.Length(hw)))
+		b := cr.pt.Add(n1.Scale(vg.Length(hw)))
+		switch st.join {
+		case vg.MiterJoin:
+			sum := n0.Add(n1)
+			l := math.Sqrt(float64(sum.Dot(sum)))
+			if l == 0 || 2/l > st.miterLimit {
+				if !cr.closing {
+					continue
+				}
+				c.polygon(cr.pt, a, b)
+				break
+			}
+			tip := cr.pt.Add(sum.Scale(vg.Length(2 * hw / (l * l))))
+			if cr.closing {
+				c.polygon(cr.pt, a, tip, b)
+			} else {
+				c.polygon(a, tip, b)
+			}
+		case vg.RoundJoin:
+			if !cr.closing {
+				continue
+			}
+			c.ctx.DrawCircle(float64(cr.pt.X), float64(cr.pt.Y), hw)
+		case vg.BevelJoin:
+			if !cr.closing {
+				continue
+			}
+			c.polygon(cr.pt, a, b)
+		}
+		painted = true
+	}
+	if painted {
+		c.ctx.Fill()
+	}
+}
+
+// polygon adds a closed polygon to the current path.
+func (c *Canvas) polygon(pts ...vg.Point) {
+	c.ctx.MoveTo(float64(pts[0].X), float64(pts[0].Y))
+	for _, pt := range pts[1:] {
+		c.ctx.LineTo(float64(pt.X), float64(pt.Y))
+	}
+	c.ctx.ClosePath()
+}
+
+// outerNormals returns the unit normals of the directions
+// d0 and d1 on the outer side of the corner between them.
+func outerNormals(d0, d1 vg.Point) (n0, n1 vg.Point) {
+	u0, u1 := unit(d0), unit(d1)
+	if u0.X*u1.Y-u0.Y*u1.X > 0 {
+		return vg.Point{X: u0.Y, Y: -u0.X}, vg.Point{X: u1.Y, Y: -u1.X}
+	}
+	return vg.Point{X: -u0.Y, Y: u0.X}, vg.Point{X: -u1.Y, Y: u1.X}
+}
+
+func unit(d vg.Point) vg.Point {
+	l := vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+	return vg.Point{X: d.X / l, Y: d.Y / l}
+}
+
+// onDash returns whether the point at the distance d
+// along a subpath lies on a dash of the pattern.
+func onDash(dashes []float64, offs, d float64) bool {
+	if len(dashes) == 1 {
+		dashes = []float64{dashes[0], dashes[0]}
+	}
+	var total float64
+	for _, l := range dashes {
+		total += l
+	}
+	if total <= 0 {
+		return true
+	}
+	d = math.Mod(d+offs, total)
+	if d < 0 {
+		d += total
+	}
+	for i, l := range dashes {
+		if d < l {
+			return i%2 == 0
+		}
+		d -= l
+	}
+	return true
+}
+
+// minSegment is the length in device units below which
+// a line segment is considered to have no direction.
+const minSegment = 1e-6
+
+// corners returns the corners between the segments
+// of the path in device space.
+func (c *Canvas) corners(p vg.Path) []corner {
+	dpi := c.DPI()
+	dev := func(pt vg.Point) vg.Point {
+		x, y := c.ctx.TransformPoint(pt.X.Dots(dpi), pt.Y.Dots(dpi))
+		return vg.Point{X: vg.Length(x), Y: vg.Length(y)}
+	}
+
+	var (
+		corners     []corner
+		start, cur  vg.Point
+		first, last vg.Point
+		hasCur      bool
+		hasSeg      bool
+		dist        float64
+	)
+	segment := func(d0, d1, end vg.Point, length float64) {
+		if hasSeg {
+			corners = append(corners, corner{pt: cur, in: last, out: d0, dist: dist})
+		} else {
+			first = d0
+			hasSeg = true
+		}
+		last = d1
+		dist += length
+		cur = end
+	}
+	lineTo := func(end vg.Point) {
+		d := end.Sub(cur)
+		if math.Hypot(float64(d.X), float64(d.Y)) < minSegment {
+			// Ignore segments closing arcs and other
+			// rounding residues without a direction.
+			cur = end
+			return
+		}
+		segment(d, d, end, math.Hypot(float64(d.X), float64(d.Y)))
+	}
+	moveTo := func(pt vg.Point) {
+		start, cur = pt, pt
+		hasCur, hasSeg = true, false
+		dist = 0
+	}
+
+	for _, comp := range p {
+		switch comp.Type {
+		case vg.MoveComp:
+			moveTo(dev(comp.Pos))
+
+		case vg.LineComp:
+			lineTo(dev(comp.Pos))
+
+		case vg.ArcComp:
+			at := func(a float64) vg.Point {
+				sin, cos := math.Sincos(a)
+				return comp.Pos.Add(vg.Point{X: comp.Radius * vg.Length(cos), Y: comp.Radius * vg.Length(sin)})
+			}
+			s := dev(at(comp.Start))
+			if hasCur {
+				lineTo(s)
+			} else {
+				moveTo(s)
+			}
+			if comp.Angle == 0 || comp.Radius == 0 {
+				break
+			}
+			// The tangents are approximated by short
+			// chords at the ends of the arc.
+			const eps = 1e-4
+			end := comp.Start + comp.Angle
+			d0 := dev(at(comp.Start + eps*comp.Angle)).Sub(s)
+			d1 := dev(at(end)).Sub(dev(at(end - eps*comp.Angle)))
+			n := int(math.Ceil(math.Abs(comp.Angle) / (math.Pi / 16)))
+			var pts []vg.Point
+			for i := 0; i <= n; i++ {
+				pts = append(pts, dev(at(comp.Start+comp.Angle*float64(i)/float64(n))))
+			}
+			segment(d0, d1, pts[n], length(pts))
+
+		case vg.CurveComp:
+			ctrl := make([]vg.Point, 0, len(comp.Control)+2)
+			ctrl = append(ctrl, cur)
+			for _, pt := range comp.Control {
+				ctrl = append(ctrl, dev(pt))
+			}
+			ctrl = append(ctrl, dev(comp.Pos))
+			var d0, d1 vg.Point
+			for _, pt := range ctrl[1:] {
+				if d0 = pt.Sub(ctrl[0]); d0 != (vg.Point{}) {
+					break
+				}
+			}
+			end := ctrl[len(ctrl)-1]
+			for i := len(ctrl) - 2; i >= 0; i-- {
+				if d1 = end.Sub(ctrl[i]); d1 != (vg.Point{}) {
+					break
+				}
+			}
+			if d0 == (vg.Point{}) {
+				break
+			}
+			const n = 16
+			var pts []vg.Point
+			for i := 0; i <= n; i++ {
+				pts = append(pts, bezier(ctrl, float64(i)/n))
+			}
+			segment(d0, d1, end, length(pts))
+
+		case vg.CloseComp:
+			lineTo(start)
+			if hasSeg {
+				corners = append(corners, corner{pt: start, in: last, out: first, closing: true})
+			}
+			moveTo(start)
+		}
+	}
+	return corners
+}
+
+// bezier returns the point at t of the Bézier
+// curve with the control points ctrl.
+func bezier(ctrl []vg.Point, t float64) vg.Point {
+	pts := append([]vg.Point(nil), ctrl...)
+	for n := len(pts) - 1; n > 0; n-- {
+		for i := 0; i < n; i++ {
+			pts[i] = pts[i].Add(pts[i+1].Sub(pts[i]).Scale(vg.Length(t)))
+		}
+	}
+	return pts[0]
+}
+
+// length returns the length of the polyline through pts.
+func length(pts []vg.Point) float64 {
+	var l float64
+	for i := 1; i < len(pts); i++ {
+		d := pts[i].Sub(pts[i-1])
+		l += math.Hypot(float64(d.X), float64(d.Y))
+	}
+	return l
+}
@@ -15,6 +15,7 @@ import (
 	"image/jpeg"
 	"image/png"
 	"io"
+	"math"
 
 	"github.com/fogleman/gg"
 	"golang.org/x/image/tiff"
@@ -29,6 +30,8 @@ type Canvas struct {
 	img   draw.Image
 	w, h  vg.Length
 	color []color.Color
+	fill  []vg.FillStyle
+	line  []lineState
 
 	// dpi is the number of dots per inch for this canvas.
 	dpi int
@@ -97,12 +100,13 @@ func NewWith(o ...option) *Canvas {
 	}
 	if c.ctx == nil {
 		c.ctx = gg.NewContextForImage(c.img)
-		c.ctx.SetLineCapButt()
 		c.img = c.ctx.Image().(draw.Image)
 		c.ctx.InvertY()
 	}
 	draw.Draw(c.img, c.img.Bounds(), &image.Uniform{c.backgroundColor}, image.ZP, draw.Src)
 	c.color = []color.Color{color.Black}
+	c.fill = []vg.FillStyle{nil}
+	c.line = []lineState{{}}
 	vg.Initialize(c)
 	return c
 }
@@ -197,6 +201,34 @@ func (c *Canvas) SetLineDash(ds []vg.Length, offs vg.Length) {
 	}
 	c.ctx.SetDashOffset(offs.Dots(c.DPI()))
 	c.ctx.SetDash(dashes...)
+	c.line[len(c.line)-1].dashes = dashes
+	c.line[len(c.line)-1].offs = offs.Dots(c.DPI())
+}
+
+func (c *Canvas) SetLineCap(lc vg.LineCap) {
+	switch lc {
+	case vg.RoundCap:
+		c.ctx.SetLineCapRound()
+	case vg.SquareCap:
+		c.ctx.SetLineCapSquare()
+	default:
+		c.ctx.SetLineCapButt()
+	}
+}
+
+func (c *Canvas) SetLineJoin(lj vg.LineJoin) {
+	c.line[len(c.line)-1].join = lj
+	// gg has no miter joins, the miters are
+	// added to the beveled corners by joins.
+	if lj == vg.RoundJoin {
+		c.ctx.SetLineJoinRound()
+	} else {
+		c.ctx.SetLineJoinBevel()
+	}
+}
+
+func (c *Canvas) SetMiterLimit(limit float64) {
+	c.line[len(c.line)-1].miterLimit = limit
 }
 
 func (c *Canvas) SetColor(clr color.Color) {
@@ -207,6 +239,10 @@ func (c *Canvas) SetColor(clr color.Color) {
 	c.color[len(c.color)-1] = clr
 }
 
+func (c *Canvas) SetFillStyle(fs vg.FillStyle) {
+	c.fill[len(c.fill)-1] = fs
+}
+
 func (c *Canvas) Rotate(t float64) {
 	c.ctx.Rotate(t)
 }
@@ -221,11 +257,15 @@ func (c *Canvas) Scale(x, y float64) {
 
 func (c *Canvas) Push() {
 	c.color = append(c.color, c.color[len(c.color)-1])
+	c.fill = append(c.fill, c.fill[len(c.fill)-1])
+	c.line = append(c.line, c.line[len(c.line)-1])
 	c.ctx.Push()
 }
 
 func (c *Canvas) Pop() {
 	c.color = c.color[:len(c.color)-1]
+	c.fill = c.fill[:len(c.fill)-1]
+	c.line = c.line[:len(c.line)-1]
 	c.ctx.Pop()
 }
 
@@ -235,13 +275,94 @@ func (c *Canvas) Stroke(p vg.Path) {
 	}
 	c.outline(p)
 	c.ctx.Stroke()
+	c.joins(p)
 }
 
 func (c *Canvas) Fill(p vg.Path) {
+	if fs := c.fill[len(c.fill)-1]; fs != nil {
+		c.fillStyle(p, fs)
+		return
+	}
 	c.outline(p)
 	c.ctx.Fill()
 }
 
+// fillStyle fills the path p using the given fill style.
+// Gradients are rendered by gg gradient patterns in device
+// space, hatch patterns are stroked within a clip of the path.
+func (c *Canvas) fillStyle(p vg.Path, fs vg.FillStyle) {
+	dpi := c.DPI()
+	bounds := p.Bounds()
+	device := func(pt vg.Point) (x, y float64) {
+		return c.ctx.TransformPoint(pt.X.Dots(dpi), pt.Y.Dots(dpi))
+	}
+
+	c.ctx.Push()
+	defer c.ctx.Pop()
+
+	switch fs := fs.(type) {
+	case vg.LinearGradient:
+		p0, p1 := fs.Points(bounds)
+		x0, y0 := device(p0)
+		x1, y1 := device(p1)
+		g := gg.NewLinearGradient(x0, y0, x1, y1)
+		addStops(g, fs.Stops)
+		c.ctx.SetFillStyle(g)
+		c.outline(p)
+		c.ctx.Fill()
+
+	case vg.RadialGradient:
+		ctr, r := fs.Circle(bounds)
+		x0, y0 := device(ctr)
+		x1, y1 := device(ctr.Add(vg.Point{X: r}))
+		g := gg.NewRadialGradient(x0, y0, 0, x0, y0, math.Hypot(x1-x0, y1-y0))
+		addStops(g, fs.Stops)
+		c.ctx.SetFillStyle(g)
+		c.outline(p)
+		c.ctx.Fill()
+
+	case vg.HatchPattern:
+		c.outline(p)
+		if fs.Background != nil {
+			c.ctx.SetColor(fs.Background)
+			c.ctx.FillPreserve()
+		}
+		c.ctx.Clip()
+		defer c.ctx.ResetClip()
+
+		clr := fs.Color
+		if clr == nil {
+			clr = color.Black
+		}
+		c.ctx.SetColor(clr)
+		if lines := fs.Lines(bounds); len(lines) > 0 {
+			c.ctx.SetLineWidth(fs.LineWidth().Dots(dpi))
+			c.ctx.SetDash()
+			c.outline(lines)
+			c.ctx.Stroke()
+		}
+		if dots := fs.Dots(bounds); len(dots) > 0 {
+			c.outline(dots)
+			c.ctx.Fill()
+		}
+
+	default:
+		panic(fmt.Sprintf("vgimg: unknown fill style %T", fs))
+	}
+}
+
+// addStops adds the gradient stops to g.
+func addStops(g gg.Gradient, stops []vg.GradientStop) {
+	// gg does not clamp positions before the first stop.
+	for _, s := range vg.PaddedStops(stops) {
+		clr := s.Color
+		if clr == nil {
+			clr = color.Black
+		}
+		g.AddColorStop(s.Offset, clr)
+	}
+}
+
 func (c *Canvas) outline(p vg.Path) {
 	for _, comp := range p {
 		switch comp.Type {
@@ -296,14 +417,15 @@ func (c *Canvas) FillString(font vg.Font, pt vg.Point, str string) {
 	c.ctx.Push()
 	defer c.ctx.Pop()
 
-	c.ctx.SetFontFace(font.FontFace(c.DPI()))
-
-	x := pt.X.Dots(c.DPI())
-	y := pt.Y.Dots(c.DPI())
-	h := c.h.Dots(c.DPI())
-
 	c.ctx.InvertY()
-	c.ctx.DrawString(str, x, h-y)
+	h := c.h.Dots(c.DPI())
+	for _, run := range font.Runs(str) {
+		c.ctx.SetFontFace(run.Font.FontFace(c.DPI()))
+		x := pt.X.Dots(c.DPI())
+		y := pt.Y.Dots(c.DPI())
+		c.ctx.DrawString(run.Text, x, h-y)
+		pt.X += run.Font.Width(run.Text)
+	}
 }
 
 // DrawImage implements the vg.Canvas.DrawImage method.
@@ -344,13 +466,22 @@ func (w *writerCounter) Write(p []byte) (int, error) {
 // that writes a jpeg image.
 type JpegCanvas struct {
 	*Canvas
+
+	// Quality is the quality of the image in the range
+	// 1 to 100.  If Quality is zero, jpeg.DefaultQuality
+	// is used.
+	Quality int
 }
 
 // WriteTo implements the io.WriterTo interface, writing a jpeg image.
 func (c JpegCanvas) WriteTo(w io.Writer) (int64, error) {
+	var opts *jpeg.Options
+	if c.Quality != 0 {
+		opts = &jpeg.Options{Quality: c.Quality}
+	}
 	wc := writerCounter{Writer: w}
 	b := bufio.NewWriter(&wc)
-	if err := jpeg.Encode(b, c.img, nil); err != nil {
+	if err := jpeg.Encode(b, c.img, opts); err != nil {
 		return wc.n, err
 	}
 	err := b.Flush()
@@ -361,13 +492,17 @@ func (c JpegCanvas) WriteTo(w io.Writer) (int64, error) {
 // writes a png image.
 type PngCanvas struct {
 	*Canvas
+
+	// Compression is the compression level of the image.
+	Compression png.CompressionLevel
 }
 
 // WriteTo implements the io.WriterTo interface, writing a png image.
 func (c PngCanvas) WriteTo(w io.Writer) (int64, error) {
 	wc := writerCounter{Writer: w}
 	b := bufio.NewWriter(&wc)
-	if err := png.Encode(b, c.img); err != nil {
+	enc := png.Encoder{CompressionLevel: c.Compression}
+	if err := enc.Encode(b, c.img); err != nil {
 		return wc.n, err
 	}
 	err := b.Flush()
@@ -10,6 +10,7 @@ import (
 	"image/color"
 	"io/ioutil"
 	"log"
+	"math"
 	"reflect"
 	"sync"
 	"testing"
@@ -139,3 +140,86 @@ func TestIssue540(t *testing.T) {
 		t.Fatalf("images differ")
 	}
 }
+
+func TestLineJoin(t *testing.T) {
+	for _, test := range []struct {
+		join  vg.LineJoin
+		limit float64
+		tip   bool
+	}{
+		{join: vg.MiterJoin, limit: vg.DefaultMiterLimit, tip: true},
+		{join: vg.MiterJoin, limit: 1.2, tip: false},
+		{join: vg.RoundJoin, limit: vg.DefaultMiterLimit, tip: false},
+		{join: vg.BevelJoin, limit: vg.DefaultMiterLimit, tip: false},
+	} {
+		c := vgimg.NewWith(vgimg.UseWH(100, 100), vgimg.UseDPI(72))
+		dc := draw.New(c)
+		dc.StrokeLines(draw.LineStyle{
+			Color:      color.Black,
+			Width:      10,
+			Join:       test.join,
+			MiterLimit: test.limit,
+		}, []vg.Point{{X: 20, Y: 20}, {X: 50, Y: 55}, {X: 80, Y: 20}})
+
+		// The pixel lies inside the miter of the
+		// corner, beyond its round or beveled outline.
+		r, _, _, _ := c.Image().At(49, 39).RGBA()
+		if tip := r < 0x8000; tip != test.tip {
+			t.Errorf("unexpected corner for join %d and miter limit %v: got tip:%t want tip:%t",
+				test.join, test.limit, tip, test.tip)
+		}
+	}
+}
+
+func TestFillStyles(t *testing.T) {
+	stops := []vg.GradientStop{{Offset: 0, Color: color.Black}, {Offset: 1, Color: color.White}}
+	for _, test := range []struct {
+		name string
+		fs   vg.FillStyle
+		// probe are pixels and their expected red
+		// components, with a tolerance of 0x10.
+		probe map[[2]int]uint8
+	}{
+		{
+			name: "linear",
+			fs:   vg.LinearGradient{X1: 1, Stops: stops},
+			probe: map[[2]int]uint8{
+				{0, 50}: 0x00, {50, 50}: 0x80, {99, 50}: 0xff,
+				{50, 0}: 0x80, {50, 99}: 0x80,
+			},
+		},
+		{
+			name: "radial",
+			fs:   vg.RadialGradient{CX: 0.5, CY: 0.5, R: 0.5, Stops: stops},
+			probe: map[[2]int]uint8{
+				{50, 50}: 0x00, {75, 50}: 0x80, {50, 25}: 0x80,
+				{0, 0}: 0xff, {99, 99}: 0xff,
+			},
+		},
+		{
+			name: "horizontal hatch",
+			fs:   vg.HatchPattern{Kind: vg.HorizontalHatch, Color: color.Black, Background: color.White, Spacing: 10, Width: 2},
+			probe: map[[2]int]uint8{
+				{50, 50}: 0x00, {50, 55}: 0xff, {50, 60}: 0x00,
+			},
+		},
+	} {
+		c := vgimg.NewWith(vgimg.UseWH(100, 100), vgimg.UseDPI(72), vgimg.UseBackgroundColor(color.Transparent))
+		c.SetFillStyle(test.fs)
+		var p vg.Path
+		p.Move(vg.Point{X: 0, Y: 0})
+		p.Line(vg.Point{X: 100, Y: 0})
+		p.Line(vg.Point{X: 100, Y: 100})
+		p.Line(vg.Point{X: 0, Y: 100})
+		p.Close()
+		c.Fill(p)
+
+		img := c.Image()
+		for pt, want := range test.probe {
+			r, _, _, _ := img.At(pt[0], pt[1]).RGBA()
+			if got := uint8(r >> 8); math.Abs(float64(got)-float64(want)) > 0x10 {
+				t.Errorf("unexpected red component at %v for %s: got:%#x want:%#x", pt, test.name, got, want)
+			}
+		}
+	}
+}
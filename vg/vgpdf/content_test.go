@@ -0,0 +1,163 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgpdf
+
+import (
+	"bytes"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+// newUncompressed returns a canvas writing
+// uncompressed content streams.
+func newUncompressed(w, h vg.Length) *Canvas {
+	c := New(w, h)
+	c.doc.SetCompression(false)
+	return c
+}
+
+// content returns the PDF file written by c.
+func content(t *testing.T, c *Canvas) string {
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("could not write canvas: %v", err)
+	}
+	return buf.String()
+}
+
+func TestLineStyleState(t *testing.T) {
+	c := newUncompressed(100, 100)
+	c.SetColor(color.Black)
+	var p vg.Path
+	p.Move(vg.Point{X: 10, Y: 10})
+	p.Line(vg.Point{X: 90, Y: 90})
+	for i := 0; i < 3; i++ {
+		c.SetLineCap(vg.RoundCap)
+		c.SetLineJoin(vg.BevelJoin)
+		c.SetMiterLimit(4)
+		c.Stroke(p)
+	}
+	c.Push()
+	c.SetLineCap(vg.ButtCap)
+	c.Pop()
+	c.SetLineCap(vg.RoundCap)
+	c.NextPage()
+	c.SetLineCap(vg.RoundCap)
+	c.SetLineJoin(vg.BevelJoin)
+	c.SetMiterLimit(4)
+	c.Stroke(p)
+
+	got := content(t, c)
+	for _, test := range []struct {
+		op   string
+		want int
+	}{
+		// gofpdf writes the line cap and join it
+		// set last at the start of every page.
+		{op: "\n1 J\n", want: 2},
+		{op: "\n0 J\n", want: 3},
+		{op: "\n2 j\n", want: 2},
+		{op: "\n4 M\n", want: 2},
+		{op: " M\n", want: 2},
+	} {
+		if n := strings.Count(got, test.op); n != test.want {
+			t.Errorf("unexpected number of %q operators: got:%d want:%d", strings.TrimSpace(test.op), n, test.want)
+		}
+	}
+}
+
+// fillTriangle fills a triangle on a new canvas
+// with fs and returns the written PDF file.
+func fillTriangle(t *testing.T, fs vg.FillStyle) string {
+	c := newUncompressed(100, 100)
+	c.SetFillStyle(fs)
+	var p vg.Path
+	p.Move(vg.Point{X: 10, Y: 10})
+	p.Line(vg.Point{X: 90, Y: 10})
+	p.Line(vg.Point{X: 90, Y: 90})
+	p.Close()
+	c.Fill(p)
+	return content(t, c)
+}
+
+func TestFillStyles(t *testing.T) {
+	stops := func(n int) []vg.GradientStop {
+		s := make([]vg.GradientStop, n)
+		for i := range s {
+			var col color.Color = color.White
+			if i%2 == 1 {
+				col = color.Black
+			}
+			s[i] = vg.GradientStop{Offset: float64(i) / float64(n-1), Color: col}
+		}
+		return s
+	}
+	for _, test := range []struct {
+		name string
+		fs   vg.FillStyle
+		want map[string]int
+	}{
+		{
+			name: "linear",
+			fs:   vg.LinearGradient{X1: 1, Stops: stops(2)},
+			want: map[string]int{
+				"W n\n":           2,
+				"/ShadingType 2 ": 1,
+				" sh\n":           1,
+			},
+		},
+		{
+			// Every segment after the first one is
+			// clipped to the half-plane behind its start.
+			name: "linear 3 stops",
+			fs:   vg.LinearGradient{X1: 1, Stops: stops(3)},
+			want: map[string]int{
+				"W n\n":           4,
+				" h W n\n":        1,
+				"/ShadingType 2 ": 2,
+				" sh\n":           2,
+			},
+		},
+		{
+			name: "radial",
+			fs:   vg.RadialGradient{CX: 0.5, CY: 0.5, R: 0.5, Stops: stops(2)},
+			want: map[string]int{
+				"/ShadingType 3 ": 1,
+				" sh\n":           1,
+			},
+		},
+		{
+			// Radial gradients of more than two stops are
+			// approximated by 64 filled rings per segment
+			// over a rectangle of the last color.
+			name: "radial 3 stops",
+			fs:   vg.RadialGradient{CX: 0.5, CY: 0.5, R: 0.5, Stops: stops(3)},
+			want: map[string]int{
+				"/ShadingType": 0,
+				" re f\n":      1,
+				" c\nf\n":      128,
+			},
+		},
+		{
+			name: "cross hatch",
+			fs:   vg.HatchPattern{Kind: vg.CrossHatch, Color: color.Black, Spacing: 10},
+			want: map[string]int{
+				"W n\n":  1,
+				" m\n":   1 + 18,
+				"l\nS\n": 1,
+			},
+		},
+	} {
+		got := fillTriangle(t, test.fs)
+		for op, want := range test.want {
+			if n := strings.Count(got, op); n != want {
+				t.Errorf("unexpected number of %q for %s: got:%d want:%d", strings.TrimSpace(op), test.name, n, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgpdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+// subset is an embedded TrueType font that is reduced to
+// the glyphs of the characters drawn with it when the PDF
+// file is written.
+type subset struct {
+	data  []byte
+	runes map[rune]bool
+
+	// size is the length of the
+	// data of the written subset.
+	size int
+}
+
+// subset returns the TrueType data of the font reduced to the
+// glyphs of the used characters.
+func (s *subset) subset() ([]byte, error) {
+	runes := make([]rune, 0, len(s.runes))
+	for r := range s.runes {
+		runes = append(runes, r)
+	}
+	out, err := vg.SubsetFont(s.data, runes)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > len(s.data) {
+		return s.data, nil
+	}
+	return out, nil
+}
+
+// fontLoader provides the font files of the font subsets
+// of a canvas to gofpdf when the document is written.
+type fontLoader map[string]*subset
+
+// Open implements the gofpdf.FontLoader interface.
+func (l fontLoader) Open(name string) (io.Reader, error) {
+	s, ok := l[name]
+	if !ok {
+		return nil, fmt.Errorf("vgpdf: unknown font file %q", name)
+	}
+	ttf, err := s.subset()
+	if err != nil {
+		return nil, err
+	}
+	s.size = len(ttf)
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write(ttf)
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// length1 replaces the lengths of the original font files,
+// which gofpdf announces as the Length1 of the embedded font
+// files in the PDF document pdf, by the lengths of the written
+// subsets.  The numbers are padded with spaces, so that the
+// offsets of the cross-reference table stay valid.
+func (l fontLoader) length1(pdf []byte) []byte {
+	// gofpdf writes the font files sorted by name.
+	files := make([]string, 0, len(l))
+	for f := range l {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	off := 0
+	for _, f := range files {
+		s := l[f]
+		if s.size == 0 {
+			continue
+		}
+		key := fmt.Sprintf("/Length1 %d\n", len(s.data))
+		i := bytes.Index(pdf[off:], []byte(key))
+		if i < 0 {
+			continue
+		}
+		i += off
+		copy(pdf[i:], fmt.Sprintf("/Length1 %-*d", len(key)-len("/Length1 \n"), s.size))
+		off = i + len(key)
+	}
+	return pdf
+}
+
+// cp1252 maps the characters 0x80 to 0x9f of the Windows-1252
+// encoding used for text in PDF files to their runes.
+var cp1252 = [32]rune{
+	0x20ac, 0, 0x201a, 0x0192, 0x201e, 0x2026, 0x2020, 0x2021,
+	0x02c6, 0x2030, 0x0160, 0x2039, 0x0152, 0, 0x017d, 0,
+	0, 0x2018, 0x2019, 0x201c, 0x201d, 0x2022, 0x2013, 0x2014,
+	0x02dc, 0x2122, 0x0161, 0x203a, 0x0153, 0, 0x017e, 0x0178,
+}
+
+// encode returns the text encoded in Windows-1252.  Characters
+// that can not be encoded are replaced by a question mark and
+// reported by an error.
+func encode(s string) (string, error) {
+	var err error
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		c := encodeRune(r)
+		if c == '?' && r != '?' && err == nil {
+			err = fmt.Errorf("vgpdf: character %q can not be encoded in Windows-1252", r)
+		}
+		b = append(b, c)
+	}
+	return string(b), err
+}
+
+// encodeRune returns the Windows-1252 code of r.
+func encodeRune(r rune) byte {
+	if r < 0x80 || (r >= 0xa0 && r <= 0xff) {
+		return byte(r)
+	}
+	for i, c := range cp1252 {
+		if c == r && c != 0 {
+			return byte(0x80 + i)
+		}
+	}
+	return '?'
+}
+
+// decodeRune returns the rune of the Windows-1252 code c.
+func decodeRune(c byte) rune {
+	if c >= 0x80 && c < 0xa0 {
+		return cp1252[c-0x80]
+	}
+	return rune(c)
+}
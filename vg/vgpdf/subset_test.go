@@ -0,0 +1,114 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgpdf
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/fixed"
+	"rsc.io/pdf"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+func TestSubset(t *testing.T) {
+	s := subset{data: goregular.TTF, runes: map[rune]bool{'A': true, 'é': true}}
+	data, err := s.subset()
+	if err != nil {
+		t.Fatalf("could not subset font: %v", err)
+	}
+	if len(data) >= len(goregular.TTF) {
+		t.Errorf("subset not smaller than font: got:%d bytes, font has %d bytes", len(data), len(goregular.TTF))
+	}
+
+	sub, err := truetype.Parse(data)
+	if err != nil {
+		t.Fatalf("could not parse subset: %v", err)
+	}
+	for _, test := range []struct {
+		r    rune
+		want bool
+	}{
+		{r: 'A', want: true},
+		{r: 'é', want: true},
+	} {
+		var g truetype.GlyphBuf
+		err := g.Load(sub, fixed.Int26_6(sub.FUnitsPerEm()), sub.Index(test.r), 0)
+		if err != nil {
+			t.Errorf("could not load glyph %q: %v", test.r, err)
+			continue
+		}
+		if got := len(g.Points) > 0; got != test.want {
+			t.Errorf("unexpected glyph outline for %q: got:%t want:%t", test.r, got, test.want)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	const (
+		txt  = "aé€–☺"
+		want = "a\xe9\x80\x96?"
+	)
+	got, err := encode(txt)
+	if got != want {
+		t.Errorf("unexpected encoding: got:%q want:%q", got, want)
+	}
+	if err == nil {
+		t.Errorf("expected error for character not in Windows-1252")
+	}
+	if _, err := encode("aé€–?"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for i, r := range []rune("aé€–?") {
+		if d := decodeRune(got[i]); d != r {
+			t.Errorf("unexpected decoding of %x: got:%q want:%q", got[i], d, r)
+		}
+	}
+}
+
+func TestLength1(t *testing.T) {
+	fnt, err := vg.MakeFont("Helvetica", 12)
+	if err != nil {
+		t.Fatalf("could not make font: %v", err)
+	}
+	c := New(100, 100)
+	c.FillString(fnt, vg.Point{X: 10, Y: 10}, "Abc")
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("could not write canvas: %v", err)
+	}
+
+	r, err := pdf.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("could not read PDF: %v", err)
+	}
+	page := r.Page(1)
+	desc := page.Font(page.Fonts()[0]).V.Key("FontDescriptor")
+	file := desc.Key("FontFile2")
+	data, err := ioutil.ReadAll(file.Reader())
+	if err != nil {
+		t.Fatalf("could not read font file: %v", err)
+	}
+	raw, err := vg.FontData(fnt.Name())
+	if err != nil {
+		t.Fatalf("could not read font data: %v", err)
+	}
+	if len(data) >= len(raw) {
+		t.Errorf("font file not subset: got:%d bytes, font has %d bytes", len(data), len(raw))
+	}
+	if got := file.Key("Length1").Int64(); got != int64(len(data)) {
+		t.Errorf("unexpected Length1: got:%d want:%d", got, len(data))
+	}
+
+	c = New(100, 100)
+	c.FillString(fnt, vg.Point{X: 10, Y: 10}, "☺")
+	if _, err := c.WriteTo(ioutil.Discard); err == nil {
+		t.Errorf("expected error for character not in Windows-1252")
+	}
+}
@@ -4,11 +4,16 @@
 
 // Package vgpdf implements the vg.Canvas interface
 // using gofpdf (github.com/jung-kurt/gofpdf).
+//
+// Text is encoded in Windows-1252, so characters outside of
+// that encoding, such as CJK characters or emoji, can not be
+// drawn, not even with vg.SetFontFallbacks.  They are replaced
+// by question marks and reported by an error of WriteTo.
 package vgpdf // import "github.com/hneemann/nplot/vg/vgpdf"
 
 import (
-	"bufio"
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"image"
 	"image/color"
@@ -19,6 +24,8 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	pdf "github.com/jung-kurt/gofpdf"
 
@@ -44,14 +51,55 @@ type Canvas struct {
 	// The default is to embed fonts.
 	// This makes the PDF file more portable but also larger.
 	embed bool
+
+	// Switch to embed only the glyphs used in the PDF file.
+	// The default is to subset embedded fonts.
+	subset  bool
+	subsets fontLoader
+
+	info Info
+
+	// lineCap and lineJoin are the line cap and join
+	// last set by gofpdf, which starts new pages with
+	// them.
+	lineCap  vg.LineCap
+	lineJoin vg.LineJoin
+
+	// err is the first error of drawing,
+	// returned by WriteTo.
+	err error
+}
+
+// Info is the document information of a PDF file.
+type Info struct {
+	Title    string
+	Author   string
+	Subject  string
+	Keywords string
+	Creator  string
+
+	// CreationDate is the creation date of the document.
+	// If CreationDate is zero, the time given by the
+	// SOURCE_DATE_EPOCH environment variable is used if
+	// it is set, and the time of writing otherwise.
+	CreationDate time.Time
 }
 
 type context struct {
-	fill  color.Color
-	line  color.Color
-	width vg.Length
+	fill   color.Color
+	line   color.Color
+	width  vg.Length
+	dashes []float64
+	offs   float64
+	style  vg.FillStyle
+	cap    vg.LineCap
+	join   vg.LineJoin
+	miter  float64
 }
 
+// pdfMiterLimit is the initial miter limit of a PDF page.
+const pdfMiterLimit = 10
+
 // New creates a new PDF Canvas.
 func New(w, h vg.Length) *Canvas {
 	cfg := pdf.InitType{
@@ -66,7 +114,12 @@ func New(w, h vg.Length) *Canvas {
 		stack: make([]context, 1),
 		fonts: make(map[vg.Font]struct{}),
 		embed: true,
+
+		subset:  true,
+		subsets: make(fontLoader),
 	}
+	c.doc.SetCatalogSort(true)
+	c.doc.SetFontLoader(c.subsets)
 	c.NextPage()
 	vg.Initialize(c)
 	return c
@@ -81,6 +134,32 @@ func (c *Canvas) EmbedFonts(v bool) bool {
 	return prev
 }
 
+// SubsetFonts specifies whether embedded fonts are reduced
+// to the glyphs used in the PDF file.  It must be called
+// before text is drawn to the canvas.
+// SubsetFonts returns the previous value before modification.
+func (c *Canvas) SubsetFonts(v bool) bool {
+	prev := c.subset
+	c.subset = v
+	return prev
+}
+
+// Info returns the document information of the canvas.
+func (c *Canvas) Info() Info {
+	return c.info
+}
+
+// SetInfo sets the document information of the canvas.
+func (c *Canvas) SetInfo(info Info) {
+	c.info = info
+	c.doc.SetTitle(info.Title, true)
+	c.doc.SetAuthor(info.Author, true)
+	c.doc.SetSubject(info.Subject, true)
+	c.doc.SetKeywords(info.Keywords, true)
+	c.doc.SetCreator(info.Creator, true)
+	c.doc.SetCreationDate(info.CreationDate)
+}
+
 func (c *Canvas) DPI() float64 {
 	return float64(c.dpi)
 }
@@ -104,9 +183,52 @@ func (c *Canvas) SetLineDash(dashes []vg.Length, offs vg.Length) {
 	for i, d := range dashes {
 		ds[i] = c.unit(d)
 	}
+	c.context().dashes = ds
+	c.context().offs = c.unit(offs)
 	c.doc.SetDashPattern(ds, c.unit(offs))
 }
 
+func (c *Canvas) SetLineCap(lc vg.LineCap) {
+	if c.context().cap == lc {
+		return
+	}
+	c.context().cap = lc
+	c.lineCap = lc
+	switch lc {
+	case vg.RoundCap:
+		c.doc.SetLineCapStyle("round")
+	case vg.SquareCap:
+		c.doc.SetLineCapStyle("square")
+	default:
+		c.doc.SetLineCapStyle("butt")
+	}
+}
+
+func (c *Canvas) SetLineJoin(lj vg.LineJoin) {
+	if c.context().join == lj {
+		return
+	}
+	c.context().join = lj
+	c.lineJoin = lj
+	switch lj {
+	case vg.RoundJoin:
+		c.doc.SetLineJoinStyle("round")
+	case vg.BevelJoin:
+		c.doc.SetLineJoinStyle("bevel")
+	default:
+		c.doc.SetLineJoinStyle("miter")
+	}
+}
+
+func (c *Canvas) SetMiterLimit(limit float64) {
+	if c.context().miter == limit {
+		return
+	}
+	c.context().miter = limit
+	// gofpdf has no support for the miter limit.
+	c.doc.RawWriteStr(fmt.Sprintf("%g M", math.Max(limit, 1)))
+}
+
 func (c *Canvas) SetColor(clr color.Color) {
 	if clr == nil {
 		clr = color.Black
@@ -120,6 +242,10 @@ func (c *Canvas) SetColor(clr color.Color) {
 	c.doc.SetAlpha(a, "Normal")
 }
 
+func (c *Canvas) SetFillStyle(fs vg.FillStyle) {
+	c.context().style = fs
+}
+
 func (c *Canvas) Rotate(r float64) {
 	c.doc.TransformRotate(-r*180/math.Pi, 0, 0)
 }
@@ -150,16 +276,219 @@ func (c *Canvas) Stroke(p vg.Path) {
 }
 
 func (c *Canvas) Fill(p vg.Path) {
+	if fs := c.context().style; fs != nil {
+		c.fillStyle(p, fs)
+		return
+	}
 	c.pdfPath(p, "F")
 }
 
+// fillStyle fills the path using the given fill style.
+// The path is used as a clip, gradients are painted with
+// the two color shadings of gofpdf and hatch patterns are
+// stroked within the clip.
+func (c *Canvas) fillStyle(p vg.Path, fs vg.FillStyle) {
+	bounds := p.Bounds()
+	if sz := bounds.Size(); sz.X <= 0 || sz.Y <= 0 {
+		return
+	}
+
+	c.doc.TransformBegin()
+	defer c.restore()
+	c.trace(p, "")
+	c.doc.RawWriteStr("W n\n")
+
+	switch fs := fs.(type) {
+	case vg.LinearGradient:
+		stops := vg.PaddedStops(fs.Stops)
+		p0, p1 := fs.Points(bounds)
+		d := p1.Sub(p0)
+		for i := 1; i < len(stops); i++ {
+			a := p0.Add(d.Scale(vg.Length(stops[i-1].Offset)))
+			b := p0.Add(d.Scale(vg.Length(stops[i].Offset)))
+			if a == b {
+				continue
+			}
+			if i > 1 {
+				// Later segments only cover the gradient
+				// beyond their start.
+				c.clipHalfPlane(a, b.Sub(a), bounds)
+			}
+			c.linearGradient(bounds, a, b, stops[i-1].Color, stops[i].Color)
+			if i > 1 {
+				c.doc.ClipEnd()
+			}
+		}
+
+	case vg.RadialGradient:
+		stops := vg.PaddedStops(fs.Stops)
+		if len(stops) == 0 {
+			return
+		}
+		ctr, rad := fs.Circle(bounds)
+		if len(stops) == 2 && rad > 0 {
+			c.radialGradient(bounds, ctr, rad, stops[0].Color, stops[1].Color)
+			return
+		}
+		// Gradients with more stops are approximated
+		// by concentric rings, painted from the outside.
+		c.fillRect(bounds, stops[len(stops)-1].Color)
+		const rings = 64
+		for i := len(stops) - 1; i > 0; i-- {
+			s0, s1 := stops[i-1], stops[i]
+			for j := rings; j > 0; j-- {
+				t := float64(j) / rings
+				r := rad * vg.Length(s0.Offset+t*(s1.Offset-s0.Offset))
+				if r <= 0 {
+					continue
+				}
+				c.setFill(lerp(s0.Color, s1.Color, t))
+				x, y := c.pdfPoint(ctr)
+				c.doc.Circle(x, y, c.unit(r), "F")
+			}
+		}
+
+	case vg.HatchPattern:
+		if fs.Background != nil {
+			c.fillRect(bounds, fs.Background)
+		}
+		r, g, b, _ := rgba(fs.Color)
+		c.doc.SetDrawColor(r, g, b)
+		c.doc.SetFillColor(r, g, b)
+		if lines := fs.Lines(bounds); len(lines) > 0 {
+			c.doc.SetLineWidth(c.unit(fs.LineWidth()))
+			c.doc.SetDashPattern(nil, 0)
+			c.pdfPath(lines, "D")
+		}
+		if dots := fs.Dots(bounds); len(dots) > 0 {
+			c.pdfPath(dots, "F")
+		}
+
+	default:
+		panic(fmt.Sprintf("vgpdf: unknown fill style %T", fs))
+	}
+}
+
+// restore ends the graphics state started by fillStyle and
+// brings the state recorded by gofpdf back in line with the
+// current context.
+func (c *Canvas) restore() {
+	c.doc.TransformEnd()
+	ctx := c.context()
+	r, g, b, _ := rgba(ctx.fill)
+	c.doc.SetFillColor(r, g, b)
+	r, g, b, _ = rgba(ctx.line)
+	c.doc.SetDrawColor(r, g, b)
+	c.doc.SetLineWidth(c.unit(ctx.width))
+	c.doc.SetDashPattern(ctx.dashes, ctx.offs)
+}
+
+// linearGradient paints the rectangle r with a gradient
+// from color c0 at point a to color c1 at point b.
+func (c *Canvas) linearGradient(r vg.Rectangle, a, b vg.Point, c0, c1 color.Color) {
+	x, y := c.pdfPoint(r.Min)
+	w, h := c.pdfPoint(r.Size())
+	ax, ay := c.pdfPoint(a)
+	bx, by := c.pdfPoint(b)
+	r0, g0, b0, _ := rgba(c0)
+	r1, g1, b1, _ := rgba(c1)
+	// gofpdf expects the gradient vector relative to the
+	// rectangle, with its y axis pointing downwards.
+	c.doc.LinearGradient(x, y, w, h, r0, g0, b0, r1, g1, b1,
+		(ax-x)/w, (y+h-ay)/h, (bx-x)/w, (y+h-by)/h)
+}
+
+// radialGradient paints a square covering the rectangle r with
+// a gradient from color c0 at ctr to color c1 at the circle
+// around ctr with radius rad.
+func (c *Canvas) radialGradient(r vg.Rectangle, ctr vg.Point, rad vg.Length, c0, c1 color.Color) {
+	var half float64
+	for _, p := range []vg.Point{r.Min, r.Max, {X: r.Min.X, Y: r.Max.Y}, {X: r.Max.X, Y: r.Min.Y}} {
+		half = math.Max(half, c.unit(vg.Length(math.Hypot(float64(p.X-ctr.X), float64(p.Y-ctr.Y)))))
+	}
+	x, y := c.pdfPoint(ctr)
+	r0, g0, b0, _ := rgba(c0)
+	r1, g1, b1, _ := rgba(c1)
+	c.doc.RadialGradient(x-half, y-half, 2*half, 2*half, r0, g0, b0, r1, g1, b1,
+		0.5, 0.5, 0.5, 0.5, c.unit(rad)/(2*half))
+}
+
+// clipHalfPlane clips to the half plane starting at the
+// point p and extending in the direction d.  The half plane
+// is cut off well beyond the rectangle r.
+func (c *Canvas) clipHalfPlane(p, d vg.Point, r vg.Rectangle) {
+	sz := r.Size()
+	ext := 2*(sz.X+sz.Y) + vg.Length(math.Hypot(float64(p.X-r.Min.X), float64(p.Y-r.Min.Y)))
+	n := vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+	u := vg.Point{X: d.X / n, Y: d.Y / n}
+	v := vg.Point{X: -u.Y, Y: u.X}
+	var pts []pdf.PointType
+	for _, q := range []vg.Point{
+		p.Add(v.Scale(ext)),
+		p.Add(v.Scale(ext)).Add(u.Scale(ext)),
+		p.Sub(v.Scale(ext)).Add(u.Scale(ext)),
+		p.Sub(v.Scale(ext)),
+	} {
+		x, y := c.pdfPoint(q)
+		pts = append(pts, pdf.PointType{X: x, Y: y})
+	}
+	c.doc.ClipPolygon(pts, false)
+}
+
+// fillRect fills the rectangle r with the color clr.
+func (c *Canvas) fillRect(r vg.Rectangle, clr color.Color) {
+	c.setFill(clr)
+	x, y := c.pdfPoint(r.Min)
+	w, h := c.pdfPoint(r.Size())
+	c.doc.Rect(x, y, w, h, "F")
+}
+
+// setFill sets the fill color of the document
+// without changing the current context.
+func (c *Canvas) setFill(clr color.Color) {
+	r, g, b, _ := rgba(clr)
+	c.doc.SetFillColor(r, g, b)
+}
+
+// lerp returns the color at t in [0, 1] between c0 and c1.
+func lerp(c0, c1 color.Color, t float64) color.Color {
+	if c0 == nil {
+		c0 = color.Black
+	}
+	if c1 == nil {
+		c1 = color.Black
+	}
+	r0, g0, b0, a0 := c0.RGBA()
+	r1, g1, b1, a1 := c1.RGBA()
+	mix := func(x, y uint32) uint16 {
+		return uint16(float64(x) + t*(float64(y)-float64(x)))
+	}
+	return color.RGBA64{R: mix(r0, r1), G: mix(g0, g1), B: mix(b0, b1), A: mix(a0, a1)}
+}
+
 func (c *Canvas) FillString(fnt vg.Font, pt vg.Point, str string) {
 	if fnt.Size == 0 {
 		return
 	}
+	for _, run := range fnt.Runs(str) {
+		c.fillString(run.Font, pt, run.Text)
+		pt.X += run.Font.Width(run.Text)
+	}
+}
 
+// fillString draws str using the single font fnt.
+func (c *Canvas) fillString(fnt vg.Font, pt vg.Point, str string) {
 	c.font(fnt, pt)
 	c.doc.SetFont(fnt.Name(), "", c.unit(fnt.Size))
+	str, err := encode(str)
+	if err != nil && c.err == nil {
+		c.err = err
+	}
+	if s, ok := c.subsets[fontFile(fnt)]; ok {
+		for i := 0; i < len(str); i++ {
+			s.runes[decodeRune(str[i])] = true
+		}
+	}
 
 	c.Push()
 	defer c.Pop()
@@ -210,31 +539,84 @@ func (c *Canvas) font(fnt vg.Font, pt vg.Point) {
 	if _, ok := c.fonts[fnt]; ok {
 		return
 	}
-	if n, ok := vg.FontMap[fnt.Name()]; ok {
-		raw, err := fonts.Asset(n + ".ttf")
-		if err != nil {
-			log.Panicf("vgpdf: could not load TTF data from asset for TTF font %q: %v", n+".ttf", err)
-		}
+	raw, err := fontData(fnt.Name())
+	if err != nil {
+		log.Panicf("vgpdf: %v", err)
+	}
 
-		enc, err := fonts.Asset("cp1252.map")
-		if err != nil {
-			log.Panicf("vgpdf: could not load encoding map: %v", err)
-		}
+	enc, err := fonts.Asset("cp1252.map")
+	if err != nil {
+		log.Panicf("vgpdf: could not load encoding map: %v", err)
+	}
 
-		zdata, jdata, err := makeFont(raw, enc, c.embed)
-		if err != nil {
-			log.Panicf("vgpdf: could not generate font data for PDF: %v", err)
-		}
+	zdata, jdata, err := makeFont(raw, enc, c.embed)
+	if err != nil {
+		log.Panicf("vgpdf: could not generate font data for PDF: %v", err)
+	}
+	c.fonts[fnt] = struct{}{}
+	if !c.embed {
+		c.doc.AddFontFromBytes(fnt.Name(), "", jdata, zdata)
+		return
+	}
 
-		c.fonts[fnt] = struct{}{}
+	// Font files are named after the font, so that
+	// different fonts do not share the same file.
+	file := fontFile(fnt)
+	jdata, err = renameFontFile(jdata, file)
+	if err != nil {
+		log.Panicf("vgpdf: could not generate font data for PDF: %v", err)
+	}
+	if !c.subset {
 		c.doc.AddFontFromBytes(fnt.Name(), "", jdata, zdata)
 		return
 	}
-	log.Panicf("vgpdf: could not find font %q in the pre-registered fonts map", fnt.Name())
+	if _, ok := c.subsets[file]; !ok {
+		c.subsets[file] = &subset{data: raw, runes: make(map[rune]bool)}
+	}
+	c.doc.AddFontFromReader(fnt.Name(), "", bytes.NewReader(jdata))
+}
+
+// fontFile returns the name of the embedded font file of fnt.
+func fontFile(fnt vg.Font) string {
+	return fnt.Name() + ".z"
+}
+
+// renameFontFile sets the name of the font file in the
+// gofpdf font definition jdata.
+func renameFontFile(jdata []byte, file string) ([]byte, error) {
+	var def map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(jdata))
+	dec.UseNumber()
+	if err := dec.Decode(&def); err != nil {
+		return nil, err
+	}
+	def["File"] = file
+	return json.Marshal(def)
+}
+
+// fontData returns the TTF data of the named font.  The standard
+// fonts are loaded from the bundled assets, other fonts from the
+// vg font registry.
+func fontData(name string) ([]byte, error) {
+	if n, ok := vg.FontMap[name]; ok {
+		raw, err := fonts.Asset(n + ".ttf")
+		if err != nil {
+			return nil, fmt.Errorf("could not load TTF data from asset for TTF font %q: %v", n+".ttf", err)
+		}
+		return raw, nil
+	}
+	return vg.FontData(name)
 }
 
 // pdfPath processes a vg.Path and applies it to the canvas.
 func (c *Canvas) pdfPath(path vg.Path, style string) {
+	c.trace(path, style)
+	c.doc.DrawPath(style)
+}
+
+// trace adds the vg.Path to the current path of the canvas.
+// If style is empty, arcs are added without being painted.
+func (c *Canvas) trace(path vg.Path, style string) {
 	var (
 		xp float64
 		yp float64
@@ -268,11 +650,13 @@ func (c *Canvas) pdfPath(path vg.Path, style string) {
 			panic(fmt.Sprintf("Unknown path component type: %d\n", comp.Type))
 		}
 	}
-	c.doc.DrawPath(style)
-	return
 }
 
 func (c *Canvas) arc(comp vg.PathComp, style string) {
+	if style == "" {
+		c.arcTo(comp)
+		return
+	}
 	x0 := comp.Pos.X + comp.Radius*vg.Length(math.Cos(comp.Start))
 	y0 := comp.Pos.Y + comp.Radius*vg.Length(math.Sin(comp.Start))
 	c.doc.LineTo(c.pdfPointXY(x0, y0))
@@ -289,6 +673,32 @@ func (c *Canvas) arc(comp vg.PathComp, style string) {
 	c.doc.MoveTo(c.pdfPointXY(x1, y1))
 }
 
+// arcTo adds an arc to the current path, approximated
+// by cubic Bézier curves of at most a quarter circle each.
+func (c *Canvas) arcTo(comp vg.PathComp) {
+	at := func(a float64) (x, y, dx, dy vg.Length) {
+		sin, cos := math.Sincos(a)
+		r := comp.Radius
+		return comp.Pos.X + r*vg.Length(cos), comp.Pos.Y + r*vg.Length(sin), -r * vg.Length(sin), r * vg.Length(cos)
+	}
+	n := int(math.Ceil(math.Abs(comp.Angle) / (math.Pi / 2)))
+	if n < 1 {
+		n = 1
+	}
+	da := comp.Angle / float64(n)
+	k := vg.Length(4.0 / 3 * math.Tan(da/4))
+	x0, y0, dx0, dy0 := at(comp.Start)
+	c.doc.LineTo(c.pdfPointXY(x0, y0))
+	for i := 1; i <= n; i++ {
+		x1, y1, dx1, dy1 := at(comp.Start + float64(i)*da)
+		cx0, cy0 := c.pdfPointXY(x0+k*dx0, y0+k*dy0)
+		cx1, cy1 := c.pdfPointXY(x1-k*dx1, y1-k*dy1)
+		px, py := c.pdfPointXY(x1, y1)
+		c.doc.CurveBezierCubicTo(cx0, cy0, cx1, cy1, px, py)
+		x0, y0, dx0, dy0 = x1, y1, dx1, dy1
+	}
+}
+
 func (c *Canvas) pdfPointXY(x, y vg.Length) (float64, float64) {
 	return c.unit(x), c.unit(y)
 }
@@ -324,18 +734,42 @@ func (w *writerCounter) Write(p []byte) (int, error) {
 // WriteTo writes the Canvas to an io.Writer.
 // After calling Write, the canvas is closed
 // and may no longer be used for drawing.
+// An error is returned without writing if text
+// that can not be encoded has been drawn.
 func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	if c.info.CreationDate.IsZero() {
+		if t, ok := sourceDate(); ok {
+			c.doc.SetCreationDate(t)
+		}
+	}
 	c.Pop()
 	c.doc.Close()
-	wc := writerCounter{Writer: w}
-	b := bufio.NewWriter(&wc)
-	if err := c.doc.Output(b); err != nil {
-		return wc.n, err
+	var buf bytes.Buffer
+	if err := c.doc.Output(&buf); err != nil {
+		return 0, err
 	}
-	err := b.Flush()
+	wc := writerCounter{Writer: w}
+	_, err := wc.Write(c.subsets.length1(buf.Bytes()))
 	return wc.n, err
 }
 
+// sourceDate returns the time given by the SOURCE_DATE_EPOCH
+// environment variable used for reproducible builds.
+func sourceDate() (time.Time, bool) {
+	v := os.Getenv("SOURCE_DATE_EPOCH")
+	if v == "" {
+		return time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0).UTC(), true
+}
+
 // rgba converts a Go color into a gofpdf 3-tuple int + 1 float64
 func rgba(c color.Color) (int, int, int, float64) {
 	if c == nil {
@@ -397,12 +831,24 @@ func makeFont(font, encoding []byte, embed bool) (z, j []byte, err error) {
 // The new page is the new current page.
 // Modifications applied to the canvas will only be applied to that new page.
 func (c *Canvas) NextPage() {
+	sty := *c.context()
 	if c.doc.PageNo() > 0 {
 		c.Pop()
 	}
 	c.doc.SetMargins(0, 0, 0)
 	c.doc.AddPage()
+
+	// gofpdf starts the page with the line cap and join
+	// it set last, and the initial miter limit of PDF.
+	// The line style of the previous page is carried over.
+	ctx := c.context()
+	ctx.cap, ctx.join, ctx.miter = c.lineCap, c.lineJoin, pdfMiterLimit
 	c.Push()
 	c.Translate(vg.Point{0, c.h})
 	c.Scale(1, -1)
+	c.SetLineCap(sty.cap)
+	c.SetLineJoin(sty.join)
+	if sty.miter != 0 {
+		c.SetMiterLimit(sty.miter)
+	}
 }
@@ -12,10 +12,12 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hneemann/nplot"
 	"github.com/hneemann/nplot/cmpimg"
 	"github.com/hneemann/nplot/plotter"
+	"github.com/hneemann/nplot/vg"
 	"github.com/hneemann/nplot/vg/draw"
 	"github.com/hneemann/nplot/vg/vgpdf"
 )
@@ -183,3 +185,36 @@ func TestIssue540(t *testing.T) {
 		t.Fatalf("images differ")
 	}
 }
+
+func TestInfo(t *testing.T) {
+	info := vgpdf.Info{
+		Title:        "Title",
+		Author:       "Author",
+		CreationDate: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	write := func() []byte {
+		c := vgpdf.New(100, 100)
+		c.SetInfo(info)
+		dc := draw.New(c)
+		dc.StrokeLines(draw.LineStyle{Color: color.Black, Width: 1}, []vg.Point{{X: 10, Y: 10}, {X: 90, Y: 90}})
+		var buf bytes.Buffer
+		if _, err := c.WriteTo(&buf); err != nil {
+			t.Fatalf("could not write canvas: %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	got := write()
+	if !bytes.Equal(got, write()) {
+		t.Errorf("PDF output is not deterministic")
+	}
+	for _, want := range []string{
+		"/CreationDate (D:20200102030405)",
+		"/Title (\xfe\xff\x00T\x00i\x00t\x00l\x00e)",
+		"/Author (\xfe\xff\x00A\x00u\x00t\x00h\x00o\x00r)",
+	} {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("missing %q in PDF output", want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgsvg
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+// TextMode specifies how text is written to the SVG file.
+type TextMode int
+
+const (
+	// TextElements writes text as <text> elements that
+	// reference the font family, leaving the choice of
+	// the actual font to the SVG renderer.
+	TextElements TextMode = iota
+
+	// TextPaths converts text to the outlines of its glyphs,
+	// so that it looks the same in every SVG renderer and
+	// does not depend on installed fonts.
+	TextPaths
+
+	// TextEmbedFonts writes text as <text> elements and
+	// embeds the used fonts, reduced to the drawn glyphs,
+	// as @font-face rules.  The text remains selectable.
+	TextEmbedFonts
+)
+
+// UseTextMode specifies how text is written to the SVG file.
+// The default is TextElements.
+func UseTextMode(m TextMode) option {
+	return func(c *Canvas) {
+		c.textMode = m
+	}
+}
+
+// webFont is a font embedded as @font-face rule.
+type webFont struct {
+	font  vg.Font
+	runes map[rune]bool
+}
+
+// embed records that the runes of str are drawn using fnt.
+func (c *Canvas) embed(fnt vg.Font, str string) {
+	for _, run := range fnt.Runs(str) {
+		name := run.Font.Name()
+		wf, ok := c.fonts[name]
+		if !ok {
+			wf = &webFont{font: run.Font, runes: make(map[rune]bool)}
+			c.fonts[name] = wf
+			c.fontOrder = append(c.fontOrder, name)
+		}
+		for _, r := range run.Text {
+			wf.runes[r] = true
+		}
+	}
+}
+
+// fontFaces returns the definition of the @font-face rules
+// of the embedded fonts.  Fonts without available font data
+// are skipped.
+func (c *Canvas) fontFaces() []byte {
+	var buf bytes.Buffer
+	for _, name := range c.fontOrder {
+		wf := c.fonts[name]
+		data, err := vg.FontData(name)
+		if err != nil {
+			continue
+		}
+		runes := make([]rune, 0, len(wf.runes))
+		for r := range wf.runes {
+			runes = append(runes, r)
+		}
+		sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+		if sub, err := vg.SubsetFont(data, runes); err == nil {
+			data = sub
+		}
+		// The rules use the names written by fontString, so
+		// that the text elements select the embedded fonts.
+		fmt.Fprintf(&buf, "@font-face {%s;src:url(data:font/ttf;base64,%s) format(\"truetype\")}\n",
+			faceString(wf.font.Typeface()), base64.StdEncoding.EncodeToString(data))
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	return []byte("<defs>\n<style type=\"text/css\"><![CDATA[\n" + buf.String() + "]]></style>\n</defs>\n")
+}
+
+// fillPath writes the text str drawn at pt as the outlines
+// of its glyphs.
+func (c *Canvas) fillPath(fnt vg.Font, pt vg.Point, str string) {
+	var d bytes.Buffer
+	for _, run := range fnt.Runs(str) {
+		pt.X += outlines(&d, run.Font, pt, run.Text)
+	}
+	if d.Len() == 0 {
+		return
+	}
+	c.svg.Path(d.String(),
+		style(elm("fill", "#000000", colorString(c.context().color)),
+			elm("fill-opacity", "1", opacityString(c.context().color))))
+}
+
+// outlines writes the SVG path data of the glyph outlines
+// of str drawn at pt using the single font fnt, and returns
+// the advance of the text.
+func outlines(w io.Writer, fnt vg.Font, pt vg.Point, str string) vg.Length {
+	ttf := fnt.Font()
+	upe := fixed.Int26_6(ttf.FUnitsPerEm())
+	// scale converts font units to points.
+	scale := fnt.Size.Points() / float64(upe)
+
+	var (
+		g       truetype.GlyphBuf
+		x       float64
+		prev    truetype.Index
+		hasPrev bool
+	)
+	for _, r := range str {
+		idx := ttf.Index(r)
+		if hasPrev {
+			x += float64(ttf.Kern(upe, prev, idx))
+		}
+		if err := g.Load(ttf, upe, idx, font.HintingNone); err == nil {
+			ox := pt.X.Points() + x*scale
+			oy := pt.Y.Points()
+			start := 0
+			for _, end := range g.Ends {
+				contour(w, g.Points[start:end], ox, oy, scale)
+				start = end
+			}
+		}
+		x += float64(ttf.HMetric(upe, idx).AdvanceWidth)
+		prev, hasPrev = idx, true
+	}
+	return vg.Points(x * scale)
+}
+
+// contour writes the SVG path data of a closed TrueType
+// contour of on-curve and quadratic off-curve points.
+func contour(w io.Writer, pts []truetype.Point, ox, oy, scale float64) {
+	n := len(pts)
+	if n == 0 {
+		return
+	}
+	type point struct{ x, y float64 }
+	at := func(p truetype.Point) point {
+		return point{ox + float64(p.X)*scale, oy + float64(p.Y)*scale}
+	}
+	mid := func(a, b point) point {
+		return point{(a.x + b.x) / 2, (a.y + b.y) / 2}
+	}
+	on := func(p truetype.Point) bool { return p.Flags&1 != 0 }
+
+	// Start at an on-curve point, or between the
+	// last and first points if there is none.
+	first, begin, count := point{}, 0, n
+	for i, p := range pts {
+		if on(p) {
+			first, begin, count = at(p), i+1, n-1
+			break
+		}
+	}
+	if count == n {
+		first = mid(at(pts[n-1]), at(pts[0]))
+	}
+	fmt.Fprintf(w, "M%.*g,%.*g", pr, first.x, pr, first.y)
+
+	var (
+		ctrl    point
+		hasCtrl bool
+	)
+	for i := 0; i < count; i++ {
+		p := pts[(begin+i)%n]
+		q := at(p)
+		switch {
+		case on(p) && hasCtrl:
+			fmt.Fprintf(w, "Q%.*g,%.*g %.*g,%.*g", pr, ctrl.x, pr, ctrl.y, pr, q.x, pr, q.y)
+			hasCtrl = false
+		case on(p):
+			fmt.Fprintf(w, "L%.*g,%.*g", pr, q.x, pr, q.y)
+		case hasCtrl:
+			m := mid(ctrl, q)
+			fmt.Fprintf(w, "Q%.*g,%.*g %.*g,%.*g", pr, ctrl.x, pr, ctrl.y, pr, m.x, pr, m.y)
+			ctrl = q
+		default:
+			ctrl, hasCtrl = q, true
+		}
+	}
+	if hasCtrl {
+		fmt.Fprintf(w, "Q%.*g,%.*g %.*g,%.*g", pr, ctrl.x, pr, ctrl.y, pr, first.x, pr, first.y)
+	}
+	io.WriteString(w, "Z")
+}
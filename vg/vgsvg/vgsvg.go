@@ -17,6 +17,8 @@ import (
 	"image/png"
 	"io"
 	"math"
+	"strconv"
+	"strings"
 
 	svgo "github.com/ajstarks/svgo"
 
@@ -39,13 +41,29 @@ type Canvas struct {
 
 	buf   *bytes.Buffer
 	stack []context
+
+	// nDefs is the number of gradient and
+	// pattern definitions written so far.
+	nDefs int
+
+	textMode TextMode
+
+	// fonts holds the fonts to embed, indexed by
+	// font name, and fontOrder their names in the
+	// order of their first use.
+	fonts     map[string]*webFont
+	fontOrder []string
 }
 
 type context struct {
 	color      color.Color
+	fill       vg.FillStyle
 	dashArray  []vg.Length
 	dashOffset vg.Length
 	lineWidth  vg.Length
+	lineCap    vg.LineCap
+	lineJoin   vg.LineJoin
+	miterLimit float64
 	gEnds      int
 }
 
@@ -68,8 +86,8 @@ func New(w, h vg.Length) *Canvas {
 }
 
 // NewWith returns a new image canvas created according to the specified
-// options. The currently accepted options is UseWH. If size is not
-// specified, the default is used.
+// options. The currently accepted options are UseWH and UseTextMode.
+// If size is not specified, the default is used.
 func NewWith(opts ...option) *Canvas {
 	buf := new(bytes.Buffer)
 	c := &Canvas{
@@ -78,6 +96,7 @@ func NewWith(opts ...option) *Canvas {
 		h:     DefaultHeight,
 		buf:   buf,
 		stack: []context{{}},
+		fonts: make(map[string]*webFont),
 	}
 
 	for _, opt := range opts {
@@ -100,7 +119,10 @@ func NewWith(opts ...option) *Canvas {
 	// Swap the origin to the bottom left.
 	// This must be matched with a </g> when saving,
 	// before the closing </svg>.
-	c.svg.Gtransform(fmt.Sprintf("scale(1, -1) translate(0, -%.*g)", pr, c.h.Points()))
+	// The group also sets the initial miter limit, which
+	// differs from the default of SVG.
+	fmt.Fprintf(c.buf, `<g transform="scale(1, -1) translate(0, -%.*g)" style="stroke-miterlimit:%d">`+"\n",
+		pr, c.h.Points(), vg.DefaultMiterLimit)
 
 	vg.Initialize(c)
 	return c
@@ -123,10 +145,26 @@ func (c *Canvas) SetLineDash(dashes []vg.Length, offs vg.Length) {
 	c.context().dashOffset = offs
 }
 
+func (c *Canvas) SetLineCap(lc vg.LineCap) {
+	c.context().lineCap = lc
+}
+
+func (c *Canvas) SetLineJoin(lj vg.LineJoin) {
+	c.context().lineJoin = lj
+}
+
+func (c *Canvas) SetMiterLimit(limit float64) {
+	c.context().miterLimit = limit
+}
+
 func (c *Canvas) SetColor(clr color.Color) {
 	c.context().color = clr
 }
 
+func (c *Canvas) SetFillStyle(fs vg.FillStyle) {
+	c.context().fill = fs
+}
+
 func (c *Canvas) Rotate(rot float64) {
 	rot = rot * 180 / math.Pi
 	c.svg.Rotate(rot)
@@ -166,15 +204,140 @@ func (c *Canvas) Stroke(path vg.Path) {
 			elm("stroke-opacity", "1", opacityString(c.context().color)),
 			elm("stroke-width", "1", "%.*g", pr, c.context().lineWidth.Points()),
 			elm("stroke-dasharray", "none", dashArrayString(c)),
-			elm("stroke-dashoffset", "0", "%.*g", pr, c.context().dashOffset.Points())))
+			elm("stroke-dashoffset", "0", "%.*g", pr, c.context().dashOffset.Points()),
+			elm("stroke-linecap", "butt", lineCapString(c.context().lineCap)),
+			elm("stroke-linejoin", "miter", lineJoinString(c.context().lineJoin)),
+			elm("stroke-miterlimit", fmt.Sprint(vg.DefaultMiterLimit), "%.*g", pr, c.context().miterLimit)))
+}
+
+// lineCapString returns the SVG name of the line cap.
+func lineCapString(lc vg.LineCap) string {
+	switch lc {
+	case vg.RoundCap:
+		return "round"
+	case vg.SquareCap:
+		return "square"
+	default:
+		return "butt"
+	}
+}
+
+// lineJoinString returns the SVG name of the line join.
+func lineJoinString(lj vg.LineJoin) string {
+	switch lj {
+	case vg.RoundJoin:
+		return "round"
+	case vg.BevelJoin:
+		return "bevel"
+	default:
+		return "miter"
+	}
 }
 
 func (c *Canvas) Fill(path vg.Path) {
+	if fs := c.context().fill; fs != nil {
+		id := c.fillDef(fs, path.Bounds())
+		c.svg.Path(c.pathData(path), style("fill:url(#"+id+")"))
+		return
+	}
 	c.svg.Path(c.pathData(path),
 		style(elm("fill", "#000000", colorString(c.context().color)),
 			elm("fill-opacity", "1", opacityString(c.context().color))))
 }
 
+// fillDef writes the definition of a gradient or pattern
+// for a path with the given bounds and returns its id.
+func (c *Canvas) fillDef(fs vg.FillStyle, bounds vg.Rectangle) string {
+	c.nDefs++
+	id := fmt.Sprintf("fill%d", c.nDefs)
+	c.buf.WriteString("<defs>\n")
+	switch fs := fs.(type) {
+	case vg.LinearGradient:
+		p0, p1 := fs.Points(bounds)
+		fmt.Fprintf(c.buf, `<linearGradient id="%s" gradientUnits="userSpaceOnUse" x1="%.*g" y1="%.*g" x2="%.*g" y2="%.*g">`+"\n",
+			id, pr, p0.X.Points(), pr, p0.Y.Points(), pr, p1.X.Points(), pr, p1.Y.Points())
+		writeStops(c.buf, fs.Stops)
+		c.buf.WriteString("</linearGradient>\n")
+
+	case vg.RadialGradient:
+		ctr, r := fs.Circle(bounds)
+		fmt.Fprintf(c.buf, `<radialGradient id="%s" gradientUnits="userSpaceOnUse" cx="%.*g" cy="%.*g" r="%.*g">`+"\n",
+			id, pr, ctr.X.Points(), pr, ctr.Y.Points(), pr, r.Points())
+		writeStops(c.buf, fs.Stops)
+		c.buf.WriteString("</radialGradient>\n")
+
+	case vg.HatchPattern:
+		writePattern(c.buf, id, fs)
+
+	default:
+		panic(fmt.Sprintf("vgsvg: unknown fill style %T", fs))
+	}
+	c.buf.WriteString("</defs>\n")
+	return id
+}
+
+// writeStops writes the stop elements of a gradient.
+func writeStops(w io.Writer, stops []vg.GradientStop) {
+	for _, s := range stops {
+		fmt.Fprintf(w, `<stop offset="%.*g" %s/>`+"\n", pr, s.Offset,
+			style(elm("stop-color", "#000000", colorString(s.Color)),
+				elm("stop-opacity", "1", opacityString(s.Color))))
+	}
+}
+
+// writePattern writes a pattern element for a hatch pattern.
+// The pattern tile is a square of the pattern spacing in user
+// space, rotated for the diagonal kinds.
+func writePattern(w io.Writer, id string, h vg.HatchPattern) {
+	s := h.LineSpacing()
+	var rot float64
+	switch h.Kind {
+	case vg.VerticalHatch:
+		rot = 90
+	case vg.DiagonalHatch, vg.DiagonalCrossHatch:
+		rot = 45
+	case vg.BackDiagonalHatch:
+		rot = -45
+	}
+	fmt.Fprintf(w, `<pattern id="%s" patternUnits="userSpaceOnUse" x="0" y="0" width="%.*g" height="%.*g"`,
+		id, pr, s.Points(), pr, s.Points())
+	if rot != 0 {
+		fmt.Fprintf(w, ` patternTransform="rotate(%g)"`, rot)
+	}
+	w.Write([]byte(">\n"))
+	if h.Background != nil {
+		fmt.Fprintf(w, `<rect x="0" y="0" width="%.*g" height="%.*g" %s/>`+"\n", pr, s.Points(), pr, s.Points(),
+			style(elm("fill", "#000000", colorString(h.Background)),
+				elm("fill-opacity", "1", opacityString(h.Background))))
+	}
+	lw := h.LineWidth().Points()
+	stroke := style(elm("stroke", "none", colorString(h.Color)),
+		elm("stroke-opacity", "1", opacityString(h.Color)),
+		elm("stroke-width", "1", "%.*g", pr, lw))
+	line := func(x1, y1, x2, y2 float64) {
+		fmt.Fprintf(w, `<line x1="%.*g" y1="%.*g" x2="%.*g" y2="%.*g" %s/>`+"\n",
+			pr, x1, pr, y1, pr, x2, pr, y2, stroke)
+	}
+	switch h.Kind {
+	case vg.DotHatch:
+		for _, x := range []float64{0, s.Points()} {
+			for _, y := range []float64{0, s.Points()} {
+				fmt.Fprintf(w, `<circle cx="%.*g" cy="%.*g" r="%.*g" %s/>`+"\n", pr, x, pr, y, pr, lw,
+					style(elm("fill", "#000000", colorString(h.Color)),
+						elm("fill-opacity", "1", opacityString(h.Color))))
+			}
+		}
+	default:
+		line(0, 0, s.Points(), 0)
+		line(0, s.Points(), s.Points(), s.Points())
+		if h.Kind == vg.CrossHatch || h.Kind == vg.DiagonalCrossHatch {
+			line(0, 0, 0, s.Points())
+			line(s.Points(), 0, s.Points(), s.Points())
+		}
+	}
+	w.Write([]byte("</pattern>\n"))
+}
+
 func (c *Canvas) pathData(path vg.Path) string {
 	buf := new(bytes.Buffer)
 	var x, y float64
@@ -294,11 +457,14 @@ func large(a float64) int {
 // FillString draws str at position pt using the specified font.
 // Text passed to FillString is escaped with html.EscapeString.
 func (c *Canvas) FillString(font vg.Font, pt vg.Point, str string) {
-	fontStr, ok := fontMap[font.Name()]
-	if !ok {
-		panic(fmt.Sprintf("Unknown font: %s", font.Name()))
+	switch c.textMode {
+	case TextPaths:
+		c.fillPath(font, pt, str)
+		return
+	case TextEmbedFonts:
+		c.embed(font, str)
 	}
-	sty := style(fontStr,
+	sty := style(fontString(font),
 		elm("font-size", "medium", "%.*gpx", pr, font.Size.Points()),
 		elm("fill", "#000000", colorString(c.context().color)))
 	if sty != "" {
@@ -337,24 +503,46 @@ func (c *Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
 	)
 }
 
-var (
-	// fontMap maps Postscript-style font names to their
-	// corresponding SVG style string.
-	fontMap = map[string]string{
-		"Courier":               "font-family:Courier;font-weight:normal;font-style:normal",
-		"Courier-Bold":          "font-family:Courier;font-weight:bold;font-style:normal",
-		"Courier-Oblique":       "font-family:Courier;font-weight:normal;font-style:oblique",
-		"Courier-BoldOblique":   "font-family:Courier;font-weight:bold;font-style:oblique",
-		"Helvetica":             "font-family:Helvetica;font-weight:normal;font-style:normal",
-		"Helvetica-Bold":        "font-family:Helvetica;font-weight:bold;font-style:normal",
-		"Helvetica-Oblique":     "font-family:Helvetica;font-weight:normal;font-style:oblique",
-		"Helvetica-BoldOblique": "font-family:Helvetica;font-weight:bold;font-style:oblique",
-		"Times-Roman":           "font-family:Times;font-weight:normal;font-style:normal",
-		"Times-Bold":            "font-family:Times;font-weight:bold;font-style:normal",
-		"Times-Italic":          "font-family:Times;font-weight:normal;font-style:italic",
-		"Times-BoldItalic":      "font-family:Times;font-weight:bold;font-style:italic",
+// fontString returns the SVG style string selecting the
+// typeface of the font, followed by the vg.FontFallbacks.
+func fontString(font vg.Font) string {
+	return faceString(font.Typeface(), vg.FontFallbacks()...)
+}
+
+// faceString returns the SVG style string selecting the
+// typeface t, followed by the given fallback families.
+func faceString(t vg.Typeface, fallbacks ...string) string {
+	fams := append([]string{t.Family}, fallbacks...)
+	for i, f := range fams {
+		f = html.EscapeString(strings.Replace(f, "'", "", -1))
+		if strings.ContainsAny(f, " ,") {
+			f = "'" + f + "'"
+		}
+		fams[i] = f
 	}
-)
+
+	var weight string
+	switch t.Weight {
+	case 0, vg.WeightNormal:
+		weight = "normal"
+	case vg.WeightBold:
+		weight = "bold"
+	default:
+		weight = strconv.Itoa(int(t.Weight))
+	}
+
+	var slant string
+	switch t.Style {
+	case vg.StyleItalic:
+		slant = "italic"
+	case vg.StyleOblique:
+		slant = "oblique"
+	default:
+		slant = "normal"
+	}
+	return fmt.Sprintf("font-family:%s;font-weight:%s;font-style:%s",
+		strings.Join(fams, ","), weight, slant)
+}
 
 // WriteTo writes the canvas to an io.Writer.
 func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
@@ -364,6 +552,12 @@ func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
 		return n, err
 	}
 
+	m, err := b.Write(c.fontFaces())
+	n += int64(m)
+	if err != nil {
+		return n, err
+	}
+
 	// Close the groups and svg in the output buffer
 	// so that the Canvas is not closed and can be
 	// used again if needed.
@@ -375,7 +569,7 @@ func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
 		}
 	}
 
-	m, err := fmt.Fprintln(b, "</svg>")
+	m, err = fmt.Fprintln(b, "</svg>")
 	n += int64(m)
 	if err != nil {
 		return n, err
@@ -6,9 +6,13 @@ package vgsvg_test
 
 import (
 	"bytes"
+	"image/color"
 	"io/ioutil"
+	"strings"
 	"testing"
 
+	"golang.org/x/image/font/gofont/goregular"
+
 	"github.com/hneemann/nplot"
 	"github.com/hneemann/nplot/cmpimg"
 	"github.com/hneemann/nplot/plotter"
@@ -101,3 +105,113 @@ func TestHtmlEscape(t *testing.T) {
 		t.Fatalf("images differ:\ngot:\n%s\nwant:\n%s\n", b.Bytes(), want)
 	}
 }
+
+func TestTextMode(t *testing.T) {
+	face := vg.Typeface{Family: "Go Regular"}
+	if err := vg.RegisterFont(face, goregular.TTF); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fnt, err := vg.MakeTypeface(face, 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, test := range []struct {
+		mode vgsvg.TextMode
+		want []string
+		not  []string
+	}{
+		{
+			mode: vgsvg.TextElements,
+			want: []string{"<text ", ">Hello</text>"},
+			not:  []string{"@font-face"},
+		},
+		{
+			mode: vgsvg.TextPaths,
+			want: []string{`<path d="M`},
+			not:  []string{"<text ", "@font-face"},
+		},
+		{
+			mode: vgsvg.TextEmbedFonts,
+			want: []string{"<text ", "@font-face {font-family:'Go Regular'", "base64,"},
+		},
+	} {
+		c := vgsvg.NewWith(vgsvg.UseWH(5*vg.Centimeter, 5*vg.Centimeter), vgsvg.UseTextMode(test.mode))
+		c.FillString(fnt, vg.Point{X: 10, Y: 10}, "Hello")
+		var buf bytes.Buffer
+		if _, err := c.WriteTo(&buf); err != nil {
+			t.Fatalf("unexpected error for mode %d: %v", test.mode, err)
+		}
+		out := buf.String()
+		for _, want := range test.want {
+			if !strings.Contains(out, want) {
+				t.Errorf("missing %q in output for mode %d", want, test.mode)
+			}
+		}
+		for _, not := range test.not {
+			if strings.Contains(out, not) {
+				t.Errorf("unexpected %q in output for mode %d", not, test.mode)
+			}
+		}
+	}
+}
+
+func TestFillStyles(t *testing.T) {
+	stops := []vg.GradientStop{
+		{Offset: 0, Color: color.White},
+		{Offset: 1, Color: color.NRGBA{R: 255, A: 128}},
+	}
+	for _, test := range []struct {
+		name string
+		fs   vg.FillStyle
+		want []string
+	}{
+		{
+			name: "linear",
+			fs:   vg.LinearGradient{X1: 1, Stops: stops},
+			want: []string{
+				`<linearGradient id="fill1" gradientUnits="userSpaceOnUse" x1="10" y1="10" x2="90" y2="10">`,
+				`<stop offset="0" style="stop-color:#FFFFFF"/>`,
+				`<stop offset="1" style="stop-color:#FF0000;stop-opacity:0.50196"/>`,
+				`style="fill:url(#fill1)"`,
+			},
+		},
+		{
+			name: "radial",
+			fs:   vg.RadialGradient{CX: 0.5, CY: 0.5, R: 0.5, Stops: stops},
+			want: []string{
+				`<radialGradient id="fill1" gradientUnits="userSpaceOnUse" cx="50" cy="50" r="40">`,
+				`style="fill:url(#fill1)"`,
+			},
+		},
+		{
+			name: "hatch",
+			fs:   vg.HatchPattern{Kind: vg.DiagonalCrossHatch, Color: color.Black, Background: color.White, Spacing: 10},
+			want: []string{
+				`<pattern id="fill1" patternUnits="userSpaceOnUse" x="0" y="0" width="10" height="10" patternTransform="rotate(45)">`,
+				`<rect x="0" y="0" width="10" height="10" style="fill:#FFFFFF"/>`,
+				`<line x1="0" y1="0" x2="0" y2="10" style="stroke:#000000;stroke-width:0.5"/>`,
+				`style="fill:url(#fill1)"`,
+			},
+		},
+	} {
+		c := vgsvg.New(100, 100)
+		c.SetFillStyle(test.fs)
+		var p vg.Path
+		p.Move(vg.Point{X: 10, Y: 10})
+		p.Line(vg.Point{X: 90, Y: 10})
+		p.Line(vg.Point{X: 90, Y: 90})
+		p.Close()
+		c.Fill(p)
+		var buf bytes.Buffer
+		if _, err := c.WriteTo(&buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+		for _, want := range test.want {
+			if !strings.Contains(out, want) {
+				t.Errorf("missing %q in output for %s", want, test.name)
+			}
+		}
+	}
+}
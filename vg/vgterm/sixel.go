@@ -0,0 +1,115 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgterm
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"io"
+)
+
+// EncodeSixel writes the image m to w as sixel graphics.
+// The colors of the image are reduced to the Plan 9 palette
+// of 256 colors.
+func EncodeSixel(w io.Writer, m image.Image) error {
+	b := m.Bounds()
+	img, ok := m.(*image.Paletted)
+	if !ok || len(img.Palette) > 256 {
+		img = image.NewPaletted(b, palette.Plan9)
+		draw.Draw(img, b, m, b.Min, draw.Src)
+	}
+
+	bw := bufio.NewWriter(w)
+	// Enter sixel mode with square pixels and
+	// set the raster attributes.
+	fmt.Fprintf(bw, "\x1bP0;1q\"1;1;%d;%d", b.Dx(), b.Dy())
+
+	// Define the used colors only, numbered
+	// in the order of their first use.
+	regs := make(map[uint8]int)
+	for _, p := range img.Pix {
+		if _, ok := regs[p]; ok {
+			continue
+		}
+		regs[p] = len(regs)
+		r, g, b, _ := img.Palette[p].RGBA()
+		fmt.Fprintf(bw, "#%d;2;%d;%d;%d", regs[p], percent(r), percent(g), percent(b))
+	}
+
+	var (
+		bits = make([]byte, b.Dx())
+		used = make([]bool, len(img.Palette))
+	)
+	for y := b.Min.Y; y < b.Max.Y; y += 6 {
+		// Each band of 6 rows is written as one line
+		// of sixels per color.
+		var order []uint8
+		for i := range used {
+			used[i] = false
+		}
+		for dy := 0; dy < 6 && y+dy < b.Max.Y; dy++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				p := img.ColorIndexAt(x, y+dy)
+				if !used[p] {
+					used[p] = true
+					order = append(order, p)
+				}
+			}
+		}
+		for i, p := range order {
+			for x := range bits {
+				bits[x] = 0
+			}
+			for dy := 0; dy < 6 && y+dy < b.Max.Y; dy++ {
+				for x := b.Min.X; x < b.Max.X; x++ {
+					if img.ColorIndexAt(x, y+dy) == p {
+						bits[x-b.Min.X] |= 1 << uint(dy)
+					}
+				}
+			}
+			if i > 0 {
+				bw.WriteByte('$')
+			}
+			fmt.Fprintf(bw, "#%d", regs[p])
+			writeSixels(bw, bits)
+		}
+		bw.WriteByte('-')
+	}
+
+	bw.WriteString("\x1b\\")
+	return bw.Flush()
+}
+
+// writeSixels writes a line of sixels, compressing repeated
+// sixels and omitting trailing empty sixels.
+func writeSixels(w *bufio.Writer, bits []byte) {
+	n := len(bits)
+	for n > 0 && bits[n-1] == 0 {
+		n--
+	}
+	for i := 0; i < n; {
+		j := i + 1
+		for j < n && bits[j] == bits[i] {
+			j++
+		}
+		c := '?' + bits[i]
+		if j-i > 3 {
+			fmt.Fprintf(w, "!%d%c", j-i, c)
+		} else {
+			for k := i; k < j; k++ {
+				w.WriteByte(c)
+			}
+		}
+		i = j
+	}
+}
+
+// percent returns the color channel value v in percent.
+func percent(v uint32) int {
+	return int((v*100 + 0x7fff) / 0xffff)
+}
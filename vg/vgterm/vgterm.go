@@ -0,0 +1,472 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package vgterm implements the vg.Canvas interface for output
+// to text terminals.  The canvas is rasterized to a grid of
+// Unicode braille or block characters, optionally colored using
+// ANSI escape sequences, or encoded as a sixel image.
+package vgterm // import "github.com/hneemann/nplot/vg/vgterm"
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"unicode/utf8"
+
+	"github.com/fogleman/gg"
+
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/vgimg"
+)
+
+// Mode is the way the canvas is written to the terminal.
+type Mode int
+
+const (
+	// Braille writes the canvas as Unicode braille characters,
+	// each showing 2×4 dots.
+	Braille Mode = iota
+
+	// Blocks writes the canvas as Unicode half block characters,
+	// each showing 1×2 dots.  With colors, each dot has its own
+	// color.
+	Blocks
+
+	// Sixel writes the canvas as a sixel image, which is shown
+	// at full resolution by terminals that support sixel graphics.
+	Sixel
+)
+
+const (
+	// DefaultWidth and DefaultHeight are the default canvas
+	// dimensions.
+	DefaultWidth  = 4 * vg.Inch
+	DefaultHeight = 4 * vg.Inch
+
+	// DefaultDPI is the default number of braille dots per
+	// inch.  A canvas of the default width is written as 80
+	// braille characters per line.  The default resolution of
+	// the other modes is chosen to result in square dots and,
+	// for Sixel, in vgimg.DefaultDPI.
+	DefaultDPI = 40
+)
+
+// samples is the number of pixels per dot in each direction
+// that are rendered for the character modes.
+const samples = 4
+
+// threshold is the minimal difference of a color channel to
+// the background color, relative to the maximal value, for
+// a pixel to be drawn in the character modes.
+const threshold = 0.2
+
+// Canvas implements the vg.Canvas interface, drawing to an
+// image that is written as text or sixel graphics.
+type Canvas struct {
+	*vgimg.Canvas
+
+	ctx  *gg.Context
+	img  *image.RGBA
+	w, h vg.Length
+
+	mode   Mode
+	colors bool
+
+	// dpi is the number of dots per inch.
+	dpi int
+
+	// cols and rows are the number of characters
+	// per line and the number of lines of the
+	// character modes.
+	cols, rows int
+
+	color  []color.Color
+	labels []label
+}
+
+// label is a text that is written as characters.
+type label struct {
+	col, row int
+	text     string
+	vertical bool
+	color    color.Color
+}
+
+type option func(*Canvas)
+
+// UseWH specifies the width and height of the canvas.
+func UseWH(w, h vg.Length) option {
+	return func(c *Canvas) {
+		if w <= 0 || h <= 0 {
+			panic("vgterm: w and h must both be > 0")
+		}
+		c.w, c.h = w, h
+	}
+}
+
+// UseMode specifies the way the canvas is written.  The
+// default is Braille.
+func UseMode(m Mode) option {
+	return func(c *Canvas) {
+		c.mode = m
+	}
+}
+
+// UseDPI specifies the number of dots per inch.
+func UseDPI(dpi int) option {
+	return func(c *Canvas) {
+		if dpi <= 0 {
+			panic("vgterm: DPI must be > 0")
+		}
+		c.dpi = dpi
+	}
+}
+
+// UseColors specifies whether the character modes write
+// colors as 24-bit ANSI escape sequences.  Colors close to
+// black are written in the default color of the terminal,
+// so that they are visible on dark terminals as well.
+func UseColors(colors bool) option {
+	return func(c *Canvas) {
+		c.colors = colors
+	}
+}
+
+// New returns a new braille canvas without colors.
+func New(w, h vg.Length) *Canvas {
+	return NewWith(UseWH(w, h))
+}
+
+// NewWith returns a new terminal canvas created according to
+// the specified options.  The currently accepted options are
+// UseWH, UseMode, UseDPI and UseColors.  If size or resolution
+// are not specified, defaults are used.
+func NewWith(opts ...option) *Canvas {
+	c := &Canvas{
+		w:     DefaultWidth,
+		h:     DefaultHeight,
+		color: []color.Color{color.Black},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	cw, ch := c.cell()
+	if c.dpi == 0 {
+		switch c.mode {
+		case Sixel:
+			c.dpi = vgimg.DefaultDPI
+		default:
+			c.dpi = DefaultDPI * cw / 2
+		}
+	}
+	dots := func(l vg.Length, n int) int {
+		d := int(math.Ceil(l.Dots(float64(c.dpi))/float64(n) - 1e-6))
+		if d < 1 {
+			d = 1
+		}
+		return d
+	}
+	c.cols, c.rows = dots(c.w, cw), dots(c.h, ch)
+
+	ss := c.samples()
+	c.img = image.NewRGBA(image.Rect(0, 0, c.cols*cw*ss, c.rows*ch*ss))
+	c.ctx = gg.NewContextForRGBA(c.img)
+	c.ctx.InvertY()
+	c.Canvas = vgimg.NewWith(vgimg.UseImageWithContext(c.img, c.ctx), vgimg.UseDPI(c.dpi*ss))
+	return c
+}
+
+// cell returns the number of dots of a character
+// in each direction.
+func (c *Canvas) cell() (w, h int) {
+	switch c.mode {
+	case Braille:
+		return 2, 4
+	case Blocks:
+		return 1, 2
+	case Sixel:
+		return 1, 1
+	default:
+		panic(fmt.Sprintf("vgterm: unknown mode %d", c.mode))
+	}
+}
+
+// samples returns the number of pixels per dot in each direction.
+func (c *Canvas) samples() int {
+	if c.mode == Sixel {
+		return 1
+	}
+	return samples
+}
+
+func (c *Canvas) SetColor(clr color.Color) {
+	if clr == nil {
+		clr = color.Black
+	}
+	c.color[len(c.color)-1] = clr
+	c.Canvas.SetColor(clr)
+}
+
+func (c *Canvas) Push() {
+	c.color = append(c.color, c.color[len(c.color)-1])
+	c.Canvas.Push()
+}
+
+func (c *Canvas) Pop() {
+	c.color = c.color[:len(c.color)-1]
+	c.Canvas.Pop()
+}
+
+// FillString writes horizontal text as characters in the
+// character modes, centered at the position of the drawn
+// text.  Vertical text is written with one character per
+// line.  Otherwise the text is rasterized.
+func (c *Canvas) FillString(fnt vg.Font, pt vg.Point, str string) {
+	if c.mode == Sixel || fnt.Size == 0 {
+		c.Canvas.FillString(fnt, pt, str)
+		return
+	}
+	x0, y0 := c.ctx.TransformPoint(0, 0)
+	x1, y1 := c.ctx.TransformPoint(1, 0)
+	dx, dy := x1-x0, y1-y0
+	const eps = 1e-6
+	horizontal := dx > 0 && math.Abs(dy) <= eps*dx
+	vertical := math.Abs(dx) <= eps*math.Abs(dy)
+	if !horizontal && !vertical {
+		c.Canvas.FillString(fnt, pt, str)
+		return
+	}
+
+	dpi := c.Canvas.DPI()
+	mid := vg.Point{X: pt.X + fnt.Width(str)/2, Y: pt.Y + fnt.Size*0.35}
+	x, y := c.ctx.TransformPoint(mid.X.Dots(dpi), mid.Y.Dots(dpi))
+	cw, ch := c.cell()
+	col := x / float64(cw*samples)
+	row := y / float64(ch*samples)
+	// Move to the center of the first character.
+	n := float64(utf8.RuneCountInString(str)-1) / 2
+	if horizontal {
+		col -= n
+	} else {
+		row -= n
+	}
+	c.labels = append(c.labels, label{
+		col:      int(math.Floor(col)),
+		row:      int(math.Floor(row)),
+		text:     str,
+		vertical: vertical,
+		color:    c.color[len(c.color)-1],
+	})
+}
+
+// WriteTo writes the canvas to w in the mode of the canvas.
+func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
+	wc := writerCounter{Writer: w}
+	if c.mode == Sixel {
+		err := EncodeSixel(&wc, c.img)
+		return wc.n, err
+	}
+
+	b := bufio.NewWriter(&wc)
+	for _, line := range c.grid() {
+		c.writeLine(b, line)
+	}
+	err := b.Flush()
+	return wc.n, err
+}
+
+// char is a character of the grid with its foreground
+// and background colors.  Nil colors are the default
+// colors of the terminal.
+type char struct {
+	r      rune
+	fg, bg color.Color
+}
+
+// grid returns the characters of the character modes.
+func (c *Canvas) grid() [][]char {
+	cw, ch := c.cell()
+	bg := color.White
+
+	// dot returns whether the dot at x, y is drawn, and the
+	// pixel color that differs most from the background.
+	dot := func(x, y int) (color.Color, bool) {
+		var (
+			best color.Color
+			max  float64
+		)
+		for py := y * samples; py < (y+1)*samples; py++ {
+			for px := x * samples; px < (x+1)*samples; px++ {
+				p := c.img.RGBAAt(px, py)
+				if d := diff(p, bg); d > max {
+					best, max = p, d
+				}
+			}
+		}
+		return best, max > threshold
+	}
+
+	lines := make([][]char, c.rows)
+	for row := range lines {
+		lines[row] = make([]char, c.cols)
+		for col := range lines[row] {
+			lines[row][col] = c.char(col*cw, row*ch, dot)
+		}
+	}
+
+	for _, l := range c.labels {
+		col, row := l.col, l.row
+		for _, r := range l.text {
+			if col >= 0 && col < c.cols && row >= 0 && row < c.rows {
+				lines[row][col] = char{r: r, fg: c.ansi(l.color)}
+			}
+			if l.vertical {
+				row++
+			} else {
+				col++
+			}
+		}
+	}
+	return lines
+}
+
+// braille holds the bits of the braille dots of a character.
+var braille = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// char returns the character with the upper left dot at x, y.
+func (c *Canvas) char(x, y int, dot func(x, y int) (color.Color, bool)) char {
+	switch c.mode {
+	case Braille:
+		var (
+			bits rune
+			fg   color.Color
+			max  float64
+		)
+		for dy := range braille {
+			for dx, bit := range braille[dy] {
+				clr, ok := dot(x+dx, y+dy)
+				if !ok {
+					continue
+				}
+				bits |= bit
+				if d := diff(clr, color.White); d > max {
+					fg, max = clr, d
+				}
+			}
+		}
+		if bits == 0 {
+			return char{r: ' '}
+		}
+		return char{r: 0x2800 + bits, fg: c.ansi(fg)}
+
+	default:
+		top, t := dot(x, y)
+		bottom, b := dot(x, y+1)
+		switch {
+		case t && b && !c.colors:
+			return char{r: '█'}
+		case t && b:
+			return char{r: '▀', fg: c.ansi(top), bg: rgb(bottom)}
+		case t:
+			return char{r: '▀', fg: c.ansi(top)}
+		case b:
+			return char{r: '▄', fg: c.ansi(bottom)}
+		}
+		return char{r: ' '}
+	}
+}
+
+// ansi returns the foreground color of clr, or nil for the
+// default color of the terminal.
+func (c *Canvas) ansi(clr color.Color) color.Color {
+	if !c.colors || clr == nil || diff(clr, color.Black) < threshold {
+		return nil
+	}
+	return rgb(clr)
+}
+
+// rgb returns the opaque color of clr on a white background.
+func rgb(clr color.Color) color.Color {
+	r, g, b, a := clr.RGBA()
+	w := 0xffff - a
+	return color.RGBA{uint8((r + w) >> 8), uint8((g + w) >> 8), uint8((b + w) >> 8), 0xff}
+}
+
+// diff returns the largest difference of the color channels
+// of a and b relative to the maximal value.
+func diff(a, b color.Color) float64 {
+	r0, g0, b0, _ := rgb(a).RGBA()
+	r1, g1, b1, _ := rgb(b).RGBA()
+	d := func(x, y uint32) float64 {
+		return math.Abs(float64(x)-float64(y)) / 0xffff
+	}
+	return math.Max(d(r0, r1), math.Max(d(g0, g1), d(b0, b1)))
+}
+
+// writeLine writes a line of characters with trailing
+// spaces removed.
+func (c *Canvas) writeLine(w *bufio.Writer, line []char) {
+	n := len(line)
+	for n > 0 && line[n-1].r == ' ' && line[n-1].bg == nil {
+		n--
+	}
+	var fg, bg color.Color
+	for _, ch := range line[:n] {
+		if c.colors {
+			if !sameColor(ch.fg, fg) {
+				writeColor(w, 38, ch.fg)
+				fg = ch.fg
+			}
+			if !sameColor(ch.bg, bg) {
+				writeColor(w, 48, ch.bg)
+				bg = ch.bg
+			}
+		}
+		w.WriteRune(ch.r)
+	}
+	if fg != nil || bg != nil {
+		w.WriteString("\x1b[0m")
+	}
+	w.WriteByte('\n')
+}
+
+// writeColor writes the ANSI escape sequence that selects
+// the foreground (code 38) or background (code 48) color clr.
+func writeColor(w io.Writer, code int, clr color.Color) {
+	if clr == nil {
+		fmt.Fprintf(w, "\x1b[%dm", code+1)
+		return
+	}
+	r, g, b, _ := clr.RGBA()
+	fmt.Fprintf(w, "\x1b[%d;2;%d;%d;%dm", code, r>>8, g>>8, b>>8)
+}
+
+func sameColor(a, b color.Color) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.(color.RGBA) == b.(color.RGBA)
+}
+
+// writerCounter implements the io.Writer interface, and counts
+// the total number of bytes written.
+type writerCounter struct {
+	io.Writer
+	n int64
+}
+
+func (w *writerCounter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,213 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgterm
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/hneemann/nplot/vg"
+)
+
+func TestBraille(t *testing.T) {
+	// A canvas of 4×2 characters with 8×8 dots.
+	c := NewWith(UseWH(vg.Inch/5, vg.Inch/5), UseDPI(40))
+	c.SetLineWidth(vg.Inch / 40)
+	var p vg.Path
+	p.Move(vg.Point{X: 0, Y: vg.Inch/10 + vg.Inch/80})
+	p.Line(vg.Point{X: vg.Inch / 5, Y: vg.Inch/10 + vg.Inch/80})
+	c.Stroke(p)
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The line covers the lowest row of dots of the
+	// first line of characters.
+	want := "⣀⣀⣀⣀\n\n"
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected output:\ngot:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	c := NewWith(UseWH(vg.Inch/5, vg.Inch/5), UseDPI(20), UseMode(Blocks), UseColors(true))
+	c.SetColor(color.RGBA{R: 255, A: 255})
+	c.Fill(vg.Path{
+		{Type: vg.MoveComp, Pos: vg.Point{X: 0, Y: 0}},
+		{Type: vg.LineComp, Pos: vg.Point{X: vg.Inch / 10, Y: 0}},
+		{Type: vg.LineComp, Pos: vg.Point{X: vg.Inch / 10, Y: vg.Inch / 5}},
+		{Type: vg.LineComp, Pos: vg.Point{X: 0, Y: vg.Inch / 5}},
+		{Type: vg.CloseComp},
+	})
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	red := "\x1b[38;2;255;0;0m\x1b[48;2;255;0;0m▀▀\x1b[0m\n"
+	if got := buf.String(); got != red+red {
+		t.Errorf("unexpected output:\ngot:\n%q\nwant:\n%q", got, red+red)
+	}
+}
+
+func TestLabels(t *testing.T) {
+	fnt, err := vg.MakeFont("Helvetica", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c := NewWith(UseWH(2*vg.Inch, vg.Inch))
+	c.FillString(fnt, vg.Point{X: vg.Inch, Y: vg.Inch / 2}, "abc")
+	c.Push()
+	c.Translate(vg.Point{X: vg.Inch / 2, Y: vg.Inch / 2})
+	c.Rotate(1.5707963267948966)
+	c.FillString(fnt, vg.Point{}, "xy")
+	c.Pop()
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if len(lines) != 11 {
+		t.Fatalf("unexpected number of lines: got:%d want:11", len(lines))
+	}
+	var found bool
+	for i, l := range lines {
+		if !strings.Contains(l, "abc") {
+			continue
+		}
+		found = true
+		if got := utf8.RuneCountInString(l[:strings.Index(l, "abc")]); got < 20 || got > 22 {
+			t.Errorf("unexpected column of text: %d", got)
+		}
+		if i == 0 || !strings.HasSuffix(strings.TrimRight(lines[i-1], " "), "x") ||
+			!strings.HasSuffix(strings.TrimRight(l, "abc "), "y") {
+			t.Errorf("unexpected vertical text:\n%s", buf.String())
+		}
+	}
+	if !found {
+		t.Errorf("missing text in output:\n%s", buf.String())
+	}
+}
+
+func TestSixel(t *testing.T) {
+	img := image.NewPaletted(image.Rect(0, 0, 9, 8), palette.Plan9)
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 9; x++ {
+			img.SetColorIndex(x, y, uint8((x/3+y)%4*50))
+		}
+	}
+	var buf bytes.Buffer
+	if err := EncodeSixel(&buf, img); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := decodeSixel(buf.String())
+	if err != nil {
+		t.Fatalf("could not decode sixel: %v", err)
+	}
+	if got.Bounds() != img.Bounds() {
+		t.Fatalf("unexpected bounds: got:%v want:%v", got.Bounds(), img.Bounds())
+	}
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 9; x++ {
+			r0, g0, b0, _ := img.At(x, y).RGBA()
+			want := [3]int{percent(r0), percent(g0), percent(b0)}
+			if got.pix[y][x] != want {
+				t.Errorf("unexpected color at %d,%d: got:%v want:%v", x, y, got.pix[y][x], want)
+			}
+		}
+	}
+}
+
+// sixel is a decoded sixel image with colors in percent.
+type sixel struct {
+	w, h int
+	pix  [][][3]int
+}
+
+func (s *sixel) Bounds() image.Rectangle {
+	return image.Rect(0, 0, s.w, s.h)
+}
+
+// decodeSixel decodes the sixel images written by EncodeSixel.
+func decodeSixel(s string) (*sixel, error) {
+	if !strings.HasPrefix(s, "\x1bP0;1q\"") || !strings.HasSuffix(s, "\x1b\\") {
+		return nil, strconv.ErrSyntax
+	}
+	s = strings.TrimSuffix(s[len("\x1bP0;1q\""):], "\x1b\\")
+
+	// number reads a decimal number from s.
+	number := func() int {
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		n, _ := strconv.Atoi(s[:i])
+		s = s[i:]
+		return n
+	}
+	args := func() []int {
+		v := []int{number()}
+		for len(s) > 0 && s[0] == ';' {
+			s = s[1:]
+			v = append(v, number())
+		}
+		return v
+	}
+
+	attr := args()
+	if len(attr) != 4 {
+		return nil, strconv.ErrSyntax
+	}
+	img := &sixel{w: attr[2], h: attr[3], pix: make([][][3]int, attr[3])}
+	for y := range img.pix {
+		img.pix[y] = make([][3]int, img.w)
+	}
+
+	var (
+		colors = make(map[int][3]int)
+		cur    [3]int
+		x, y   int
+	)
+	for len(s) > 0 {
+		c := s[0]
+		s = s[1:]
+		n := 1
+		switch {
+		case c == '#':
+			v := args()
+			if len(v) == 5 {
+				colors[v[0]] = [3]int{v[2], v[3], v[4]}
+			}
+			cur = colors[v[0]]
+			continue
+		case c == '$':
+			x = 0
+			continue
+		case c == '-':
+			x, y = 0, y+6
+			continue
+		case c == '!':
+			n = number()
+			c, s = s[0], s[1:]
+		}
+		for ; n > 0; n-- {
+			for dy := 0; dy < 6; dy++ {
+				if (c-'?')&(1<<uint(dy)) != 0 && y+dy < img.h && x < img.w {
+					img.pix[y+dy][x] = cur
+				}
+			}
+			x++
+		}
+	}
+	return img, nil
+}
@@ -47,13 +47,27 @@ type Canvas struct {
 	// .tex file that can be fed to, e.g., pdflatex.
 	document bool
 	id       int64 // id is a unique identifier for this canvas
+	nshade   int   // nshade is the number of shadings declared so far
 }
 
 type context struct {
 	color      color.Color
+	fill       vg.FillStyle
 	dashArray  []vg.Length
 	dashOffset vg.Length
 	linew      vg.Length
+	line       lineState
+
+	// pgf is the line state in effect
+	// in the current PGF scope.
+	pgf lineState
+}
+
+// lineState holds the line cap, join and miter limit.
+type lineState struct {
+	cap        vg.LineCap
+	join       vg.LineJoin
+	miterLimit float64
 }
 
 // New returns a new LaTeX canvas.
@@ -84,6 +98,7 @@ func newCanvas(w, h vg.Length, document bool) *Canvas {
 	c.wtex("")
 	c.wtex(`\begin{pgfpicture}`)
 	c.stack = make([]context, 1)
+	c.context().pgf.miterLimit = 10 // The PGF default.
 	vg.Initialize(c)
 	return c
 }
@@ -108,11 +123,31 @@ func (c *Canvas) SetLineDash(pattern []vg.Length, offset vg.Length) {
 	c.context().dashOffset = offset
 }
 
+// SetLineCap implements the vg.Canvas.SetLineCap method.
+func (c *Canvas) SetLineCap(lc vg.LineCap) {
+	c.context().line.cap = lc
+}
+
+// SetLineJoin implements the vg.Canvas.SetLineJoin method.
+func (c *Canvas) SetLineJoin(lj vg.LineJoin) {
+	c.context().line.join = lj
+}
+
+// SetMiterLimit implements the vg.Canvas.SetMiterLimit method.
+func (c *Canvas) SetMiterLimit(limit float64) {
+	c.context().line.miterLimit = limit
+}
+
 // SetColor implements the vg.Canvas.SetColor method.
 func (c *Canvas) SetColor(clr color.Color) {
 	c.context().color = clr
 }
 
+// SetFillStyle implements the vg.Canvas.SetFillStyle method.
+func (c *Canvas) SetFillStyle(fs vg.FillStyle) {
+	c.context().fill = fs
+}
+
 // Rotate implements the vg.Canvas.Rotate method.
 func (c *Canvas) Rotate(rad float64) {
 	c.wtex(`\pgftransformrotate{%g}`, rad*degPerRadian)
@@ -155,17 +190,148 @@ func (c *Canvas) Stroke(p vg.Path) {
 
 // Fill implements the vg.Canvas.Fill method.
 func (c *Canvas) Fill(p vg.Path) {
+	if fs := c.context().fill; fs != nil {
+		c.fillStyle(p, fs)
+		return
+	}
 	c.wstyle()
 	c.wpath(p)
 	c.wtex(`\pgfusepath{fill, stroke}`)
 	c.wtex("")
 }
 
+// maxShading is the largest extent of a declared shading.
+// It is kept well below the largest TeX dimension.
+const maxShading = 8000
+
+// fillStyle fills the path using the given fill style.
+// Gradients are painted with PGF shadings, hatch patterns
+// are stroked within a clip of the path.
+func (c *Canvas) fillStyle(p vg.Path, fs vg.FillStyle) {
+	bounds := p.Bounds()
+	sz := bounds.Size()
+	diag := math.Hypot(float64(sz.X), float64(sz.Y))
+
+	c.Push()
+	defer c.Pop()
+
+	switch fs := fs.(type) {
+	case vg.LinearGradient:
+		if len(fs.Stops) == 0 {
+			return
+		}
+		p0, p1 := fs.Points(bounds)
+		d := p1.Sub(p0)
+		dist := math.Hypot(float64(d.X), float64(d.Y))
+		if dist == 0 {
+			dist = 1e-3
+		}
+		// The shading spans ref points for the gradient and is
+		// padded with the end colors to cover the whole path.
+		ref := math.Min(100, maxShading*dist/(2*diag+dist))
+		pad := ref * diag / dist
+		c.wpath(p)
+		c.wtex(`\pgfusepath{clip}`)
+		name := c.shadingName()
+		c.wtex(`\pgfdeclarehorizontalshading{%s}{%gpt}{%s}`, name, 2*pad+ref,
+			shadingColors(fs.Stops, pad, ref, 2*pad+ref))
+		mid := p0.Add(d.Scale(0.5))
+		c.wtex(`\pgftransformshift{\pgfpoint{%gpt}{%gpt}}`, mid.X, mid.Y)
+		c.wtex(`\pgftransformrotate{%g}`, math.Atan2(float64(d.Y), float64(d.X))*degPerRadian)
+		c.wtex(`\pgftransformxscale{%g}`, dist/ref)
+		c.wtex(`\pgftransformyscale{%g}`, dist/ref)
+		c.wtex(`\pgfuseshading{%s}`, name)
+
+	case vg.RadialGradient:
+		if len(fs.Stops) == 0 {
+			return
+		}
+		ctr, r := fs.Circle(bounds)
+		rad := math.Max(float64(r), 1e-3)
+		ref := math.Min(50, maxShading*rad/(2*diag+rad))
+		outer := ref * (1 + diag/rad)
+		c.wpath(p)
+		c.wtex(`\pgfusepath{clip}`)
+		name := c.shadingName()
+		c.wtex(`\pgfdeclareradialshading{%s}{\pgfpoint{0pt}{0pt}}{%s}`, name,
+			shadingColors(fs.Stops, 0, ref, outer))
+		c.wtex(`\pgftransformshift{\pgfpoint{%gpt}{%gpt}}`, ctr.X, ctr.Y)
+		c.wtex(`\pgftransformscale{%g}`, rad/ref)
+		c.wtex(`\pgfuseshading{%s}`, name)
+
+	case vg.HatchPattern:
+		if fs.Background != nil {
+			c.context().color = fs.Background
+			c.wcolor()
+			c.wpath(p)
+			c.wtex(`\pgfusepath{fill}`)
+		}
+		c.wpath(p)
+		c.wtex(`\pgfusepath{clip}`)
+		c.context().color = fs.Color
+		c.wcolor()
+		if lines := fs.Lines(bounds); len(lines) > 0 {
+			c.wtex(`\pgfsetdash{}{0pt}`)
+			c.wtex(`\pgfsetlinewidth{%gpt}`, fs.LineWidth())
+			c.wpath(lines)
+			c.wtex(`\pgfusepath{stroke}`)
+		}
+		if dots := fs.Dots(bounds); len(dots) > 0 {
+			c.wpath(dots)
+			c.wtex(`\pgfusepath{fill}`)
+		}
+
+	default:
+		panic(fmt.Errorf("vgtex: unknown fill style %T", fs))
+	}
+}
+
+// shadingName returns a new unique name for a shading.
+func (c *Canvas) shadingName() string {
+	c.nshade++
+	return fmt.Sprintf("nplot-%d-%d", c.id, c.nshade)
+}
+
+// shadingColors returns a PGF color specification for the stops.
+// The gradient starts at position start and has the given length,
+// the first and last colors are repeated to reach position end.
+func shadingColors(stops []vg.GradientStop, start, length, end float64) string {
+	stops = vg.PaddedStops(stops)
+	var specs []string
+	spec := func(pos float64, col color.Color) {
+		r, g, b, _ := rgb(col)
+		specs = append(specs, fmt.Sprintf("rgb(%gpt)=(%g,%g,%g)", pos, r, g, b))
+	}
+	spec(0, stops[0].Color)
+	for _, s := range stops {
+		spec(start+s.Offset*length, s.Color)
+	}
+	spec(end, stops[len(stops)-1].Color)
+	return strings.Join(specs, "; ")
+}
+
 // FillString implements the vg.Canvas.FillString method.
 func (c *Canvas) FillString(f vg.Font, pt vg.Point, text string) {
 	c.wcolor()
 	pt.X += 0.5 * f.Width(text)
-	c.wtex(`\pgftext[base,at={\pgfpoint{%gpt}{%gpt}}]{%s}`, pt.X, pt.Y, text)
+	c.wtex(`\pgftext[base,at={\pgfpoint{%gpt}{%gpt}}]{%s%s}`, pt.X, pt.Y, fontSeries(f), text)
+}
+
+// fontSeries returns the LaTeX declarations selecting the
+// weight and shape of the font's typeface.
+func fontSeries(f vg.Font) string {
+	var s string
+	t := f.Typeface()
+	if t.Weight >= vg.WeightSemiBold {
+		s += `\bfseries `
+	}
+	switch t.Style {
+	case vg.StyleItalic:
+		s += `\itshape `
+	case vg.StyleOblique:
+		s += `\slshape `
+	}
+	return s
 }
 
 // DrawImage implements the vg.Canvas.DrawImage method.
@@ -204,9 +370,40 @@ func (c *Canvas) wtex(s string, args ...interface{}) {
 func (c *Canvas) wstyle() {
 	c.wdash()
 	c.wlineWidth()
+	c.wline()
 	c.wcolor()
 }
 
+// wline writes the line cap, join and miter limit
+// if they differ from the ones in effect.
+func (c *Canvas) wline() {
+	ctx := c.context()
+	if ctx.line.cap != ctx.pgf.cap {
+		switch ctx.line.cap {
+		case vg.RoundCap:
+			c.wtex(`\pgfsetroundcap`)
+		case vg.SquareCap:
+			c.wtex(`\pgfsetrectcap`)
+		default:
+			c.wtex(`\pgfsetbuttcap`)
+		}
+	}
+	if ctx.line.join != ctx.pgf.join {
+		switch ctx.line.join {
+		case vg.RoundJoin:
+			c.wtex(`\pgfsetroundjoin`)
+		case vg.BevelJoin:
+			c.wtex(`\pgfsetbeveljoin`)
+		default:
+			c.wtex(`\pgfsetmiterjoin`)
+		}
+	}
+	if ctx.line.miterLimit != ctx.pgf.miterLimit {
+		c.wtex(`\pgfsetmiterlimit{%g}`, ctx.line.miterLimit)
+	}
+	ctx.pgf = ctx.line
+}
+
 func (c *Canvas) wdash() {
 	if len(c.context().dashArray) == 0 {
 		return
@@ -224,28 +421,30 @@ func (c *Canvas) wlineWidth() {
 }
 
 func (c *Canvas) wcolor() {
-	col := c.context().color
-	if col == nil {
-		col = color.Black
-	}
-	r, g, b, a := col.RGBA()
-	alpha := 255.0 / float64(a)
+	r, g, b, opacity := rgb(c.context().color)
 	// FIXME(sbinet) \color will last until the end of the current TeX group
 	// use \pgfsetcolor and \pgfsetstrokecolor instead.
 	// it needs a named color: define it on the fly (storing it at the beginning
 	// of the document.)
-	c.wtex(
-		`\color[rgb]{%g,%g,%g}`,
-		float64(r)*alpha/255.0,
-		float64(g)*alpha/255.0,
-		float64(b)*alpha/255.0,
-	)
-
-	opacity := float64(a) / math.MaxUint16
+	c.wtex(`\color[rgb]{%g,%g,%g}`, r, g, b)
 	c.wtex(`\pgfsetstrokeopacity{%g}`, opacity)
 	c.wtex(`\pgfsetfillopacity{%g}`, opacity)
 }
 
+// rgb returns the non-premultiplied color components
+// and the opacity of the color, in the range [0, 1].
+func rgb(col color.Color) (r, g, b, opacity float64) {
+	if col == nil {
+		col = color.Black
+	}
+	cr, cg, cb, ca := col.RGBA()
+	alpha := 255.0 / float64(ca)
+	return float64(cr) * alpha / 255.0,
+		float64(cg) * alpha / 255.0,
+		float64(cb) * alpha / 255.0,
+		float64(ca) / math.MaxUint16
+}
+
 func (c *Canvas) wpath(p vg.Path) {
 	for _, comp := range p {
 		switch comp.Type {
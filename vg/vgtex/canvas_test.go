@@ -5,11 +5,109 @@
 package vgtex_test
 
 import (
+	"bytes"
+	"image/color"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hneemann/nplot/cmpimg"
+	"github.com/hneemann/nplot/vg"
+	"github.com/hneemann/nplot/vg/vgtex"
 )
 
 func TestTexCanvas(t *testing.T) {
 	cmpimg.CheckPlot(Example, t, "scatter.tex")
 }
+
+func TestFillStyles(t *testing.T) {
+	stops := []vg.GradientStop{
+		{Offset: 0, Color: color.White},
+		{Offset: 1, Color: color.Black},
+	}
+	// shading matches the declaration of a shading
+	// and captures its name and color specification.
+	shading := regexp.MustCompile(`\\pgfdeclare(?:horizontal|radial)shading\{([^}]+)\}\{[^\n]*?\}\{(rgb\([^\n]*)\}\n`)
+	for _, test := range []struct {
+		name   string
+		fs     vg.FillStyle
+		colors string
+		want   []string
+	}{
+		{
+			// The shading of 100pt for the gradient is padded
+			// with the end colors by the diagonal of the path.
+			name:   "linear",
+			fs:     vg.LinearGradient{X1: 1, Stops: stops},
+			colors: "rgb(0pt)=(1,1,1); rgb(141.4213562373095pt)=(1,1,1); rgb(241.4213562373095pt)=(0,0,0); rgb(382.842712474619pt)=(0,0,0)",
+			want: []string{
+				`\pgfusepath{clip}`,
+				`\pgfdeclarehorizontalshading{`,
+				`\pgftransformshift{\pgfpoint{50pt}{10pt}}`,
+				`\pgftransformrotate{0}`,
+				`\pgftransformxscale{0.8}`,
+				`\pgftransformyscale{0.8}`,
+			},
+		},
+		{
+			name:   "radial",
+			fs:     vg.RadialGradient{CX: 0.5, CY: 0.5, R: 0.5, Stops: stops},
+			colors: "rgb(0pt)=(1,1,1); rgb(0pt)=(1,1,1); rgb(50pt)=(0,0,0); rgb(191.4213562373095pt)=(0,0,0)",
+			want: []string{
+				`\pgfusepath{clip}`,
+				`\pgfdeclareradialshading{`,
+				`\pgftransformshift{\pgfpoint{50pt}{50pt}}`,
+				`\pgftransformscale{0.8}`,
+			},
+		},
+		{
+			name: "hatch",
+			fs:   vg.HatchPattern{Kind: vg.HorizontalHatch, Color: color.Black, Background: color.White, Spacing: 10},
+			want: []string{
+				"\\color[rgb]{1,1,1}",
+				"\\pgfusepath{fill}",
+				"\\pgfusepath{clip}\n    \\color[rgb]{0,0,0}",
+				"\\pgfsetlinewidth{0.5pt}",
+				"\\pgfpathmoveto{\\pgfpoint{10pt}{50pt}}\n    \\pgflineto{\\pgfpoint{90pt}{50pt}}",
+				"\\pgfusepath{stroke}",
+			},
+		},
+	} {
+		c := vgtex.New(100, 100)
+		c.SetFillStyle(test.fs)
+		var p vg.Path
+		p.Move(vg.Point{X: 10, Y: 10})
+		p.Line(vg.Point{X: 90, Y: 10})
+		p.Line(vg.Point{X: 90, Y: 90})
+		p.Close()
+		c.Fill(p)
+		var buf bytes.Buffer
+		if _, err := c.WriteTo(&buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+		for _, want := range test.want {
+			if !strings.Contains(out, want) {
+				t.Errorf("missing %q in output for %s", want, test.name)
+			}
+		}
+
+		m := shading.FindStringSubmatch(out)
+		if test.colors == "" {
+			if m != nil {
+				t.Errorf("unexpected shading for %s", test.name)
+			}
+			continue
+		}
+		if m == nil {
+			t.Errorf("missing shading for %s", test.name)
+			continue
+		}
+		if m[2] != test.colors {
+			t.Errorf("unexpected shading colors for %s:\ngot: %s\nwant:%s", test.name, m[2], test.colors)
+		}
+		if !strings.Contains(out, `\pgfuseshading{`+m[1]+`}`) {
+			t.Errorf("shading %s not used for %s", m[1], test.name)
+		}
+	}
+}